@@ -0,0 +1,243 @@
+// Package analytics computes returns, volatility, drawdowns and risk-adjusted
+// ratios over tradingstore price series. The series are annualised with the
+// number of bars per year of their timeframe.
+package analytics
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dracory/tradingstore"
+)
+
+// TRADING_DAYS_PER_YEAR is the number of trading days per year of exchanges
+// closed on weekends and holidays
+const TRADING_DAYS_PER_YEAR = 252
+
+// CALENDAR_DAYS_PER_YEAR is the number of trading days per year of markets
+// trading every day, i.e. crypto
+const CALENDAR_DAYS_PER_YEAR = 365
+
+// Point is the value of a series at the time of a price
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series is a time series of values, in ascending time order
+type Series []Point
+
+// Values returns the values of the series
+func (series Series) Values() []float64 {
+	values := make([]float64, 0, len(series))
+
+	for _, point := range series {
+		values = append(values, point.Value)
+	}
+
+	return values
+}
+
+// Options define how the series are annualised and the ratios computed
+type Options struct {
+	// DaysPerYear is the number of trading days per year,
+	// defaults to TRADING_DAYS_PER_YEAR
+	DaysPerYear float64
+
+	// HoursPerDay is the number of trading hours per day of intraday
+	// timeframes, defaults to 24. Use 6.5 for US equities
+	HoursPerDay float64
+
+	// RiskFreeRate is the annual risk-free rate of the Sharpe and Sortino
+	// ratios, i.e. 0.02 for 2%
+	RiskFreeRate float64
+
+	// VolatilityWindow is the number of returns of the rolling volatility,
+	// no rolling volatility is computed if 0
+	VolatilityWindow int
+}
+
+// BarsPerYear returns the number of bars of the timeframe per year. Daily
+// and intraday bars are counted over the trading days and hours of the
+// options, weekly, monthly and yearly bars over the calendar.
+//
+// Parameters:
+// - timeframe: one of the tradingstore TIMEFRAME_* constants
+//
+// Returns:
+// - float64: the number of bars per year
+// - error: if the timeframe is not supported
+func (options Options) BarsPerYear(timeframe string) (float64, error) {
+	duration, err := tradingstore.TimeframeDuration(timeframe)
+
+	if err != nil {
+		return 0, errors.New("analytics: " + err.Error())
+	}
+
+	switch strings.ToLower(timeframe) {
+	case tradingstore.TIMEFRAME_1_WEEK:
+		return 52, nil
+	case tradingstore.TIMEFRAME_1_MONTH:
+		return 12, nil
+	case tradingstore.TIMEFRAME_1_YEAR:
+		return 1, nil
+	}
+
+	daysPerYear := options.DaysPerYear
+
+	if daysPerYear <= 0 {
+		daysPerYear = TRADING_DAYS_PER_YEAR
+	}
+
+	if duration >= 24*time.Hour {
+		return daysPerYear * float64(24*time.Hour) / float64(duration), nil
+	}
+
+	hoursPerDay := options.HoursPerDay
+
+	if hoursPerDay <= 0 {
+		hoursPerDay = 24
+	}
+
+	return daysPerYear * hoursPerDay * float64(time.Hour) / float64(duration), nil
+}
+
+// Report holds the analytics of a price series
+type Report struct {
+	// BarsPerYear is the number of bars per year the report is annualised with
+	BarsPerYear float64
+
+	// Returns are the simple returns of the closes
+	Returns Series
+
+	// LogReturns are the log returns of the closes
+	LogReturns Series
+
+	// RollingVolatility is the annualised volatility over the volatility
+	// window of the options, empty if not set
+	RollingVolatility Series
+
+	// Drawdowns are the drawdowns of the closes from their running peak
+	Drawdowns Series
+
+	// TotalReturn is the return from the first to the last close
+	TotalReturn float64
+
+	// AnnualizedReturn is the compound annual growth rate
+	AnnualizedReturn float64
+
+	// AnnualizedVolatility is the annualised standard deviation of the returns
+	AnnualizedVolatility float64
+
+	// MaxDrawdown is the largest drawdown of the closes
+	MaxDrawdown DrawdownPeriod
+
+	// Sharpe is the annualised Sharpe ratio
+	Sharpe float64
+
+	// Sortino is the annualised Sortino ratio
+	Sortino float64
+}
+
+// Analyze returns the analytics of the prices
+//
+// Parameters:
+// - prices: the prices, in ascending time order
+// - timeframe: the timeframe of the prices
+// - options: the annualisation and ratio options
+//
+// Returns:
+// - Report: the analytics
+// - error: if the timeframe is not supported or there are fewer than 2 prices
+func Analyze(prices []tradingstore.PriceInterface, timeframe string, options Options) (Report, error) {
+	barsPerYear, err := options.BarsPerYear(timeframe)
+
+	if err != nil {
+		return Report{}, err
+	}
+
+	if len(prices) < 2 {
+		return Report{}, errors.New("analytics: at least 2 prices are required")
+	}
+
+	if prices[0].CloseFloat() == 0 {
+		return Report{}, errors.New("analytics: the first close is zero")
+	}
+
+	returns := SimpleReturns(prices)
+
+	report := Report{
+		BarsPerYear:          barsPerYear,
+		Returns:              returns,
+		LogReturns:           LogReturns(prices),
+		RollingVolatility:    Series{},
+		Drawdowns:            Drawdowns(prices),
+		TotalReturn:          prices[len(prices)-1].CloseFloat()/prices[0].CloseFloat() - 1,
+		AnnualizedVolatility: Volatility(returns, barsPerYear),
+		MaxDrawdown:          MaxDrawdown(prices),
+		Sharpe:               Sharpe(returns, options.RiskFreeRate, barsPerYear),
+		Sortino:              Sortino(returns, options.RiskFreeRate, barsPerYear),
+	}
+
+	report.AnnualizedReturn = math.Pow(1+report.TotalReturn, barsPerYear/float64(len(returns))) - 1
+
+	if options.VolatilityWindow > 0 {
+		report.RollingVolatility, err = RollingVolatility(returns, options.VolatilityWindow, barsPerYear)
+
+		if err != nil {
+			return Report{}, err
+		}
+	}
+
+	return report, nil
+}
+
+// AnalyzeFromStore returns the analytics of the stored prices matching the
+// query options
+//
+// Parameters:
+// - ctx: the context
+// - store: the store to read the prices from
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - timeframe: the timeframe of the prices
+// - options: the annualisation and ratio options
+// - query: the query options of the prices, which are always listed in
+// ascending time order
+//
+// Returns:
+// - Report: the analytics
+// - error: if the query is not ordered by time ascending, or the prices could
+// not be read or analysed
+func AnalyzeFromStore(ctx context.Context, store tradingstore.StoreInterface, symbol string, exchange string, timeframe string, options Options, query tradingstore.PriceQueryInterface) (Report, error) {
+	if store == nil {
+		return Report{}, errors.New("analytics: store is nil")
+	}
+
+	if query == nil {
+		query = tradingstore.NewPriceQuery()
+	}
+
+	if query.IsOrderBySet() && !strings.EqualFold(query.OrderBy(), tradingstore.COLUMN_TIME) {
+		return Report{}, errors.New("analytics: only ordering by time is supported")
+	}
+
+	if query.IsOrderDirectionSet() && !strings.EqualFold(query.OrderDirection(), "asc") {
+		return Report{}, errors.New("analytics: only ascending order is supported")
+	}
+
+	// the returns require the prices in ascending time order, ordering by
+	// time without a direction would list them in descending order
+	query.SetOrderBy(tradingstore.COLUMN_TIME).SetOrderDirection("asc")
+
+	prices, err := store.PriceList(ctx, symbol, exchange, timeframe, query)
+
+	if err != nil {
+		return Report{}, err
+	}
+
+	return Analyze(prices, timeframe, options)
+}
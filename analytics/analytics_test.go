@@ -0,0 +1,355 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/dracory/tradingstore"
+	"github.com/dromara/carbon/v2"
+	_ "modernc.org/sqlite"
+)
+
+func testPrices(closes ...float64) []tradingstore.PriceInterface {
+	prices := []tradingstore.PriceInterface{}
+	start := carbon.Parse("2020-01-01 00:00:00", carbon.UTC)
+
+	for index, close := range closes {
+		value := strconv.FormatFloat(close, 'f', -1, 64)
+
+		prices = append(prices, tradingstore.NewPrice().
+			SetTime(start.Copy().AddDays(index).ToDateTimeString(carbon.UTC)).
+			SetOpen(value).
+			SetHigh(value).
+			SetLow(value).
+			SetClose(value).
+			SetVolume("10"))
+	}
+
+	return prices
+}
+
+func assertFloat(t *testing.T, name string, value float64, expected float64) {
+	t.Helper()
+
+	if math.Abs(value-expected) > 1e-9 {
+		t.Fatal(name, "MUST BE", expected, ", found:", value)
+	}
+}
+
+func TestBarsPerYear(t *testing.T) {
+	cases := []struct {
+		options   Options
+		timeframe string
+		expected  float64
+	}{
+		{Options{}, tradingstore.TIMEFRAME_1_DAY, 252},
+		{Options{DaysPerYear: CALENDAR_DAYS_PER_YEAR}, tradingstore.TIMEFRAME_1_DAY, 365},
+		{Options{}, tradingstore.TIMEFRAME_1_HOUR, 252 * 24},
+		{Options{HoursPerDay: 6.5}, tradingstore.TIMEFRAME_30_MINUTES, 252 * 13},
+		{Options{}, tradingstore.TIMEFRAME_1_WEEK, 52},
+		{Options{}, tradingstore.TIMEFRAME_1_MONTH, 12},
+	}
+
+	for _, c := range cases {
+		barsPerYear, err := c.options.BarsPerYear(c.timeframe)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		assertFloat(t, c.timeframe, barsPerYear, c.expected)
+	}
+
+	if _, err := (Options{}).BarsPerYear("tick_3"); err == nil {
+		t.Fatal("A custom timeframe MUST be rejected")
+	}
+}
+
+func TestReturns(t *testing.T) {
+	prices := testPrices(100, 110, 99)
+
+	returns := SimpleReturns(prices)
+
+	if len(returns) != 2 {
+		t.Fatal("Returns count MUST BE 2, found:", len(returns))
+	}
+
+	assertFloat(t, "return 0", returns[0].Value, 0.1)
+	assertFloat(t, "return 1", returns[1].Value, -0.1)
+
+	if !returns[1].Time.Equal(prices[2].TimeCarbon().StdTime()) {
+		t.Fatal("A return MUST be at the time of its price")
+	}
+
+	logReturns := LogReturns(prices)
+
+	assertFloat(t, "log return 0", logReturns[0].Value, math.Log(1.1))
+}
+
+func TestVolatility(t *testing.T) {
+	returns := SimpleReturns(testPrices(100, 110, 99, 99))
+
+	// returns 0.1, -0.1 and 0, sample standard deviation 0.1
+	assertFloat(t, "volatility", Volatility(returns, 1), 0.1)
+	assertFloat(t, "annualised volatility", Volatility(returns, 252), 0.1*math.Sqrt(252))
+
+	rolling, err := RollingVolatility(returns, 2, 1)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(rolling) != 2 {
+		t.Fatal("Rolling volatility count MUST BE 2, found:", len(rolling))
+	}
+
+	assertFloat(t, "rolling volatility 0", rolling[0].Value, math.Sqrt(0.02))
+
+	if _, err := RollingVolatility(returns, 1, 1); err == nil {
+		t.Fatal("A window of 1 MUST be rejected")
+	}
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	prices := testPrices(100, 120, 90, 100, 130, 117)
+
+	drawdown := MaxDrawdown(prices)
+
+	assertFloat(t, "max drawdown", drawdown.Value, -0.25)
+
+	if !drawdown.Peak.Equal(prices[1].TimeCarbon().StdTime()) || !drawdown.Trough.Equal(prices[2].TimeCarbon().StdTime()) {
+		t.Fatal("Unexpected drawdown peak and trough:", drawdown)
+	}
+
+	if !drawdown.IsRecovered() || !drawdown.Recovery.Equal(prices[4].TimeCarbon().StdTime()) {
+		t.Fatal("The drawdown MUST recover at the new peak")
+	}
+
+	drawdowns := Drawdowns(prices)
+
+	assertFloat(t, "last drawdown", drawdowns[5].Value, -0.1)
+
+	if MaxDrawdown(testPrices(1, 2, 3)).Value != 0 {
+		t.Fatal("Rising closes MUST NOT have a drawdown")
+	}
+}
+
+func TestSharpeAndSortino(t *testing.T) {
+	returns := SimpleReturns(testPrices(100, 110, 99, 99))
+
+	// mean 0, so both ratios are 0 without a risk-free rate
+	assertFloat(t, "sharpe", Sharpe(returns, 0, 252), 0)
+
+	rising := SimpleReturns(testPrices(100, 102, 101, 104))
+	values := rising.Values()
+	expectedSharpe := mean(values) / standardDeviation(values) * math.Sqrt(252)
+
+	assertFloat(t, "sharpe", Sharpe(rising, 0, 252), expectedSharpe)
+
+	downside := math.Sqrt(values[1] * values[1] / 3)
+	assertFloat(t, "sortino", Sortino(rising, 0, 252), mean(values)/downside*math.Sqrt(252))
+}
+
+func TestAnalyzeFromStore(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err := tradingstore.NewStore(tradingstore.NewStoreOptions{
+		DB:                   db,
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	err = store.InstrumentCreate(ctx, tradingstore.NewInstrument().
+		SetSymbol("AAPL").
+		SetExchange("NASDAQ").
+		SetTimeframes([]string{tradingstore.TIMEFRAME_1_DAY}))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, price := range testPrices(100, 120, 90, 100, 130) {
+		if err := store.PriceCreate(ctx, "AAPL", "NASDAQ", tradingstore.TIMEFRAME_1_DAY, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	report, err := AnalyzeFromStore(ctx, store, "AAPL", "NASDAQ", tradingstore.TIMEFRAME_1_DAY, Options{VolatilityWindow: 3}, nil)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	assertFloat(t, "bars per year", report.BarsPerYear, 252)
+	assertFloat(t, "total return", report.TotalReturn, 0.3)
+	assertFloat(t, "annualized return", report.AnnualizedReturn, math.Pow(1.3, 252.0/4)-1)
+	assertFloat(t, "max drawdown", report.MaxDrawdown.Value, -0.25)
+
+	if len(report.Returns) != 4 || len(report.RollingVolatility) != 2 || len(report.Drawdowns) != 5 {
+		t.Fatal("Unexpected report series lengths")
+	}
+
+	if _, err := AnalyzeFromStore(ctx, store, "AAPL", "NASDAQ", tradingstore.TIMEFRAME_1_DAY, Options{}, tradingstore.NewPriceQuery().SetLimit(1)); err == nil {
+		t.Fatal("A single price MUST be rejected")
+	}
+
+	// ordering by time without a direction MUST still analyse the prices in ascending order
+	ordered, err := AnalyzeFromStore(ctx, store, "AAPL", "NASDAQ", tradingstore.TIMEFRAME_1_DAY, Options{}, tradingstore.NewPriceQuery().
+		SetOrderBy(tradingstore.COLUMN_TIME))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	assertFloat(t, "total return ordered by time", ordered.TotalReturn, 0.3)
+
+	if _, err := AnalyzeFromStore(ctx, store, "AAPL", "NASDAQ", tradingstore.TIMEFRAME_1_DAY, Options{}, tradingstore.NewPriceQuery().
+		SetOrderBy(tradingstore.COLUMN_TIME).SetOrderDirection("desc")); err == nil {
+		t.Fatal("Descending order MUST be rejected")
+	}
+
+	if _, err := AnalyzeFromStore(ctx, store, "AAPL", "NASDAQ", tradingstore.TIMEFRAME_1_DAY, Options{}, tradingstore.NewPriceQuery().
+		SetOrderBy(tradingstore.COLUMN_CLOSE)); err == nil {
+		t.Fatal("Ordering by another column than time MUST be rejected")
+	}
+}
+
+func TestCorrelate(t *testing.T) {
+	a := testPrices(100, 110, 99, 108.9, 98.01)
+	b := testPrices(50, 55, 49.5, 54.45, 49.005)
+	c := testPrices(100, 90, 99, 89.1, 98.01)
+
+	// no price on the third day
+	d := testPrices(10, 11, 12, 13, 12)
+	d = append(d[:2], d[3:]...)
+
+	matrix, err := Correlate([][]tradingstore.PriceInterface{a, b, c, d}, CorrelationOptions{})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	assertFloat(t, "correlation a a", matrix.Correlation[0][0], 1)
+	assertFloat(t, "correlation a b", matrix.Correlation[0][1], 1)
+	assertFloat(t, "correlation b a", matrix.Correlation[1][0], 1)
+	assertFloat(t, "correlation a c", matrix.Correlation[0][2], -1)
+
+	if matrix.Observations[0][1] != 4 || matrix.Observations[0][3] != 3 {
+		t.Fatal("Pairwise observations MUST only skip the missing prices of the pair, found:", matrix.Observations)
+	}
+
+	returns := SimpleReturns(a).Values()
+	variance := standardDeviation(returns) * standardDeviation(returns)
+
+	assertFloat(t, "covariance a a", matrix.Covariance[0][0], variance)
+
+	listwise, err := Correlate([][]tradingstore.PriceInterface{a, b, c, d}, CorrelationOptions{MissingData: MISSING_DATA_LISTWISE})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if listwise.Observations[0][1] != 3 {
+		t.Fatal("Listwise observations MUST skip the missing prices of any series, found:", listwise.Observations[0][1])
+	}
+
+	flat, err := Correlate([][]tradingstore.PriceInterface{a, testPrices(1, 1, 1, 1, 1)}, CorrelationOptions{})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !math.IsNaN(flat.Correlation[0][1]) {
+		t.Fatal("A series which does not vary MUST have a NaN correlation")
+	}
+
+	if _, err := Correlate(nil, CorrelationOptions{MissingData: "ALL"}); err == nil {
+		t.Fatal("An unsupported missing data handling MUST be rejected")
+	}
+}
+
+func TestCorrelateFromStore(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err := tradingstore.NewStore(tradingstore.NewStoreOptions{
+		DB:                   db,
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	closes := map[string][]float64{
+		"AAA": {100, 110, 99, 108.9},
+		"BBB": {100, 90, 99, 89.1},
+	}
+
+	ids := []string{}
+
+	for _, symbol := range []string{"AAA", "BBB"} {
+		instrument := tradingstore.NewInstrument().
+			SetSymbol(symbol).
+			SetExchange("NYSE").
+			SetTimeframes([]string{tradingstore.TIMEFRAME_1_DAY})
+
+		if err := store.InstrumentCreate(ctx, instrument); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		ids = append(ids, instrument.ID())
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for symbol, symbolCloses := range closes {
+		for _, price := range testPrices(symbolCloses...) {
+			if err := store.PriceCreate(ctx, symbol, "NYSE", tradingstore.TIMEFRAME_1_DAY, price); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		}
+	}
+
+	// in the reverse order of creation
+	matrix, err := CorrelateFromStore(ctx, store, tradingstore.NewInstrumentQuery().SetIDIn([]string{ids[1], ids[0]}), tradingstore.TIMEFRAME_1_DAY, CorrelationOptions{}, nil)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(matrix.Instruments) != 2 || matrix.Instruments[0].Symbol() != "BBB" || matrix.Index(ids[0]) != 1 {
+		t.Fatal("The instruments MUST be in the order of the IDs")
+	}
+
+	assertFloat(t, "correlation", matrix.Correlation[0][1], -1)
+}
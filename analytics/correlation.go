@@ -0,0 +1,289 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/dracory/tradingstore"
+)
+
+// Missing data handling, how the times at which some instruments have no
+// price are handled
+const MISSING_DATA_LISTWISE = "LISTWISE" // Use only the times at which every instrument has a price
+const MISSING_DATA_PAIRWISE = "PAIRWISE" // Use the times at which both instruments of each pair have a price (default)
+
+// CorrelationOptions define how the correlation matrix is computed
+type CorrelationOptions struct {
+	// MissingData is MISSING_DATA_PAIRWISE (default) or MISSING_DATA_LISTWISE
+	MissingData string
+
+	// LogReturns correlates the log returns instead of the simple returns
+	LogReturns bool
+}
+
+// CorrelationMatrix holds the correlation and covariance of the returns of
+// several instruments. The rows and columns are in the order of the
+// instruments, or of the price series they were computed from.
+//
+// Pairs with fewer than 2 returns, or a return which does not vary,
+// have a NaN correlation.
+type CorrelationMatrix struct {
+	// Instruments are the instruments of the rows and columns,
+	// empty when computed from price series
+	Instruments []tradingstore.InstrumentInterface
+
+	// Correlation is the Pearson correlation of the returns
+	Correlation [][]float64
+
+	// Covariance is the sample covariance of the returns
+	Covariance [][]float64
+
+	// Observations is the number of returns each pair was computed from
+	Observations [][]int
+}
+
+// Index returns the row and column of the instrument, -1 if not found
+func (matrix CorrelationMatrix) Index(instrumentID string) int {
+	for index, instrument := range matrix.Instruments {
+		if instrument.ID() == instrumentID {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// Correlate returns the correlation and covariance matrices of the returns
+// of the price series.
+//
+// The series are aligned by time, and the returns are computed between the
+// consecutive times at which the aligned series have a price, so a missing
+// price widens the return of the other series rather than misaligning them.
+//
+// Parameters:
+// - prices: the price series, each in ascending time order
+// - options: the correlation options
+//
+// Returns:
+// - CorrelationMatrix: the matrices, without instruments
+// - error: if the missing data handling is not supported
+func Correlate(prices [][]tradingstore.PriceInterface, options CorrelationOptions) (CorrelationMatrix, error) {
+	missingData := options.MissingData
+
+	if missingData == "" {
+		missingData = MISSING_DATA_PAIRWISE
+	}
+
+	if missingData != MISSING_DATA_PAIRWISE && missingData != MISSING_DATA_LISTWISE {
+		return CorrelationMatrix{}, errors.New("analytics: missing data handling is not supported: " + missingData)
+	}
+
+	count := len(prices)
+
+	matrix := CorrelationMatrix{
+		Instruments:  []tradingstore.InstrumentInterface{},
+		Correlation:  squareMatrix[float64](count),
+		Covariance:   squareMatrix[float64](count),
+		Observations: squareMatrix[int](count),
+	}
+
+	if count < 1 {
+		return matrix, nil
+	}
+
+	closes := make([]map[int64]float64, count)
+
+	for index, series := range prices {
+		closes[index] = map[int64]float64{}
+
+		for _, price := range series {
+			closes[index][priceTime(price).UnixNano()] = price.CloseFloat()
+		}
+	}
+
+	all := make([]int, count)
+
+	for index := range all {
+		all[index] = index
+	}
+
+	listwise := alignedReturns(closes, all, options.LogReturns)
+
+	for i := 0; i < count; i++ {
+		for j := i; j < count; j++ {
+			var a, b []float64
+
+			if missingData == MISSING_DATA_LISTWISE {
+				a, b = listwise[i], listwise[j]
+			} else {
+				pair := alignedReturns(closes, []int{i, j}, options.LogReturns)
+				a, b = pair[i], pair[j]
+			}
+
+			covariance, correlation := covarianceAndCorrelation(a, b)
+
+			matrix.Covariance[i][j], matrix.Covariance[j][i] = covariance, covariance
+			matrix.Correlation[i][j], matrix.Correlation[j][i] = correlation, correlation
+			matrix.Observations[i][j], matrix.Observations[j][i] = len(a), len(a)
+		}
+	}
+
+	return matrix, nil
+}
+
+// CorrelateFromStore returns the correlation and covariance matrices of the
+// returns of the instruments matching the instrument query, over their
+// stored prices of the timeframe matching the price query.
+//
+// To correlate given instruments, query them by ID with SetIDIn, the rows
+// and columns are then in the order of the IDs. Otherwise they are in the
+// order the instruments are listed in.
+//
+// Parameters:
+// - ctx: the context
+// - store: the store to read the instruments and prices from
+// - instrumentQuery: the instruments to correlate
+// - timeframe: the timeframe of the prices
+// - options: the correlation options
+// - priceQuery: the window of the prices, i.e. SetTimeGte and SetTimeLte
+//
+// Returns:
+// - CorrelationMatrix: the matrices, with the instruments
+// - error: if the instruments or prices could not be read
+func CorrelateFromStore(ctx context.Context, store tradingstore.StoreInterface, instrumentQuery tradingstore.InstrumentQueryInterface, timeframe string, options CorrelationOptions, priceQuery tradingstore.PriceQueryInterface) (CorrelationMatrix, error) {
+	if store == nil {
+		return CorrelationMatrix{}, errors.New("analytics: store is nil")
+	}
+
+	if instrumentQuery == nil {
+		return CorrelationMatrix{}, errors.New("analytics: instrument query is nil")
+	}
+
+	if priceQuery == nil {
+		priceQuery = tradingstore.NewPriceQuery()
+	}
+
+	instruments, err := store.InstrumentList(ctx, instrumentQuery)
+
+	if err != nil {
+		return CorrelationMatrix{}, err
+	}
+
+	if instrumentQuery.IsIDInSet() {
+		order := map[string]int{}
+
+		for index, id := range instrumentQuery.IDIn() {
+			order[id] = index
+		}
+
+		sort.SliceStable(instruments, func(i, j int) bool {
+			return order[instruments[i].ID()] < order[instruments[j].ID()]
+		})
+	}
+
+	prices := [][]tradingstore.PriceInterface{}
+
+	for _, instrument := range instruments {
+		series, err := store.PriceList(ctx, instrument.Symbol(), instrument.Exchange(), timeframe, priceQuery)
+
+		if err != nil {
+			return CorrelationMatrix{}, err
+		}
+
+		prices = append(prices, series)
+	}
+
+	matrix, err := Correlate(prices, options)
+
+	if err != nil {
+		return CorrelationMatrix{}, err
+	}
+
+	matrix.Instruments = instruments
+
+	return matrix, nil
+}
+
+// alignedReturns returns the returns of the given series between the
+// consecutive times at which all of them have a non-zero close, by series
+func alignedReturns(closes []map[int64]float64, members []int, logReturns bool) map[int][]float64 {
+	times := []int64{}
+
+	for time, close := range closes[members[0]] {
+		if close == 0 {
+			continue
+		}
+
+		common := true
+
+		for _, member := range members[1:] {
+			if other, found := closes[member][time]; !found || other == 0 {
+				common = false
+				break
+			}
+		}
+
+		if common {
+			times = append(times, time)
+		}
+	}
+
+	sort.Slice(times, func(i, j int) bool {
+		return times[i] < times[j]
+	})
+
+	returns := map[int][]float64{}
+
+	for _, member := range members {
+		returns[member] = []float64{}
+
+		for index := 1; index < len(times); index++ {
+			previous, current := closes[member][times[index-1]], closes[member][times[index]]
+
+			if logReturns {
+				returns[member] = append(returns[member], math.Log(current/previous))
+			} else {
+				returns[member] = append(returns[member], current/previous-1)
+			}
+		}
+	}
+
+	return returns
+}
+
+// covarianceAndCorrelation returns the sample covariance and the Pearson
+// correlation of two series of the same length, NaN for fewer than 2 values
+// and a NaN correlation if a series does not vary
+func covarianceAndCorrelation(a []float64, b []float64) (float64, float64) {
+	if len(a) < 2 || len(a) != len(b) {
+		return math.NaN(), math.NaN()
+	}
+
+	meanA, meanB := mean(a), mean(b)
+	covariance, varianceA, varianceB := 0.0, 0.0, 0.0
+
+	for index := range a {
+		covariance += (a[index] - meanA) * (b[index] - meanB)
+		varianceA += (a[index] - meanA) * (a[index] - meanA)
+		varianceB += (b[index] - meanB) * (b[index] - meanB)
+	}
+
+	if varianceA == 0 || varianceB == 0 {
+		return covariance / float64(len(a)-1), math.NaN()
+	}
+
+	return covariance / float64(len(a)-1), covariance / math.Sqrt(varianceA*varianceB)
+}
+
+// squareMatrix returns a size by size matrix of zero values
+func squareMatrix[T any](size int) [][]T {
+	matrix := make([][]T, size)
+
+	for index := range matrix {
+		matrix[index] = make([]T, size)
+	}
+
+	return matrix
+}
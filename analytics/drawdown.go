@@ -0,0 +1,94 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/dracory/tradingstore"
+)
+
+// DrawdownPeriod is a decline of the closes from a peak
+type DrawdownPeriod struct {
+	// Value is the decline from the peak to the trough, as a negative
+	// fraction of the peak, i.e. -0.25 for a 25% drawdown
+	Value float64
+
+	// Peak is the time of the peak close
+	Peak time.Time
+
+	// Trough is the time of the lowest close after the peak
+	Trough time.Time
+
+	// Recovery is the time the close got back to the peak,
+	// the zero time if it did not
+	Recovery time.Time
+}
+
+// IsRecovered returns true if the closes got back to the peak
+func (period DrawdownPeriod) IsRecovered() bool {
+	return !period.Recovery.IsZero()
+}
+
+// Drawdowns returns the drawdown of each close from the running peak of the
+// closes, as a negative fraction of the peak, 0 at a new peak
+//
+// Parameters:
+// - prices: the prices, in ascending time order
+//
+// Returns:
+// - Series: one drawdown per price
+func Drawdowns(prices []tradingstore.PriceInterface) Series {
+	series := Series{}
+	peak := 0.0
+
+	for _, price := range prices {
+		close := price.CloseFloat()
+		peak = max(peak, close)
+
+		drawdown := 0.0
+
+		if peak > 0 {
+			drawdown = close/peak - 1
+		}
+
+		series = append(series, Point{Time: priceTime(price), Value: drawdown})
+	}
+
+	return series
+}
+
+// MaxDrawdown returns the largest drawdown of the closes, with the times of
+// its peak, trough and recovery. The zero DrawdownPeriod is returned if the
+// closes never decline.
+//
+// Parameters:
+// - prices: the prices, in ascending time order
+//
+// Returns:
+// - DrawdownPeriod: the largest drawdown
+func MaxDrawdown(prices []tradingstore.PriceInterface) DrawdownPeriod {
+	largest := DrawdownPeriod{}
+	peak, peakTime := 0.0, time.Time{}
+
+	for _, price := range prices {
+		close := price.CloseFloat()
+
+		if close >= peak {
+			// the first close back at the peak of the largest drawdown
+			if largest.Value < 0 && largest.Recovery.IsZero() && largest.Peak.Equal(peakTime) {
+				largest.Recovery = priceTime(price)
+			}
+
+			if close > peak {
+				peak, peakTime = close, priceTime(price)
+			}
+
+			continue
+		}
+
+		if drawdown := close/peak - 1; drawdown < largest.Value {
+			largest = DrawdownPeriod{Value: drawdown, Peak: peakTime, Trough: priceTime(price)}
+		}
+	}
+
+	return largest
+}
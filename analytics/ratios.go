@@ -0,0 +1,63 @@
+package analytics
+
+import (
+	"math"
+)
+
+// Sharpe returns the annualised Sharpe ratio of the returns, the mean
+// return in excess of the risk-free rate divided by the standard deviation
+// of the returns. It is 0 if the returns do not vary.
+//
+// Parameters:
+// - returns: the returns, i.e. from SimpleReturns
+// - riskFreeRate: the annual risk-free rate, i.e. 0.02 for 2%
+// - barsPerYear: the number of bars per year
+//
+// Returns:
+// - float64: the annualised Sharpe ratio
+func Sharpe(returns Series, riskFreeRate float64, barsPerYear float64) float64 {
+	deviation := standardDeviation(returns.Values())
+
+	if deviation == 0 || barsPerYear <= 0 {
+		return 0
+	}
+
+	excess := mean(returns.Values()) - riskFreeRate/barsPerYear
+
+	return excess / deviation * math.Sqrt(barsPerYear)
+}
+
+// Sortino returns the annualised Sortino ratio of the returns, the mean
+// return in excess of the risk-free rate divided by the downside deviation,
+// the root mean square of the returns below the risk-free rate. It is 0 if
+// no return is below the risk-free rate.
+//
+// Parameters:
+// - returns: the returns, i.e. from SimpleReturns
+// - riskFreeRate: the annual risk-free rate, i.e. 0.02 for 2%
+// - barsPerYear: the number of bars per year
+//
+// Returns:
+// - float64: the annualised Sortino ratio
+func Sortino(returns Series, riskFreeRate float64, barsPerYear float64) float64 {
+	if len(returns) < 1 || barsPerYear <= 0 {
+		return 0
+	}
+
+	target := riskFreeRate / barsPerYear
+	sum := 0.0
+
+	for _, point := range returns {
+		if shortfall := point.Value - target; shortfall < 0 {
+			sum += shortfall * shortfall
+		}
+	}
+
+	downside := math.Sqrt(sum / float64(len(returns)))
+
+	if downside == 0 {
+		return 0
+	}
+
+	return (mean(returns.Values()) - target) / downside * math.Sqrt(barsPerYear)
+}
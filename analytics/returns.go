@@ -0,0 +1,63 @@
+package analytics
+
+import (
+	"math"
+	"time"
+
+	"github.com/dracory/tradingstore"
+)
+
+// SimpleReturns returns the simple returns of the closes, close / previous
+// close - 1, at the time of each price but the first. Prices following a
+// zero close are skipped.
+//
+// Parameters:
+// - prices: the prices, in ascending time order
+//
+// Returns:
+// - Series: one return per price but the first
+func SimpleReturns(prices []tradingstore.PriceInterface) Series {
+	return returnsOf(prices, func(previous float64, current float64) float64 {
+		return current/previous - 1
+	})
+}
+
+// LogReturns returns the log returns of the closes, ln(close / previous
+// close), at the time of each price but the first. Prices following a zero
+// close are skipped.
+//
+// Parameters:
+// - prices: the prices, in ascending time order
+//
+// Returns:
+// - Series: one return per price but the first
+func LogReturns(prices []tradingstore.PriceInterface) Series {
+	return returnsOf(prices, func(previous float64, current float64) float64 {
+		return math.Log(current / previous)
+	})
+}
+
+// returnsOf returns the series of the returns between consecutive closes
+func returnsOf(prices []tradingstore.PriceInterface, returnOf func(previous float64, current float64) float64) Series {
+	series := Series{}
+
+	for index := 1; index < len(prices); index++ {
+		previous := prices[index-1].CloseFloat()
+
+		if previous == 0 {
+			continue
+		}
+
+		series = append(series, Point{
+			Time:  priceTime(prices[index]),
+			Value: returnOf(previous, prices[index].CloseFloat()),
+		})
+	}
+
+	return series
+}
+
+// priceTime returns the time of the price in UTC
+func priceTime(price tradingstore.PriceInterface) time.Time {
+	return price.TimeCarbon().StdTime().UTC()
+}
@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"errors"
+	"math"
+)
+
+// Volatility returns the annualised volatility of the returns, the sample
+// standard deviation times the square root of the bars per year. It is 0
+// for fewer than 2 returns.
+//
+// Parameters:
+// - returns: the returns, i.e. from SimpleReturns or LogReturns
+// - barsPerYear: the number of bars per year, 1 to not annualise
+//
+// Returns:
+// - float64: the annualised volatility
+func Volatility(returns Series, barsPerYear float64) float64 {
+	return standardDeviation(returns.Values()) * math.Sqrt(barsPerYear)
+}
+
+// RollingVolatility returns the annualised volatility over a rolling window
+// of returns, at the time of each return from the window-th one
+//
+// Parameters:
+// - returns: the returns, in ascending time order
+// - window: the number of returns of each volatility, at least 2
+// - barsPerYear: the number of bars per year, 1 to not annualise
+//
+// Returns:
+// - Series: one volatility per full window
+// - error: if the window is less than 2
+func RollingVolatility(returns Series, window int, barsPerYear float64) (Series, error) {
+	if window < 2 {
+		return nil, errors.New("analytics: volatility window must be at least 2")
+	}
+
+	series := Series{}
+	values := returns.Values()
+
+	for index := window - 1; index < len(values); index++ {
+		series = append(series, Point{
+			Time:  returns[index].Time,
+			Value: standardDeviation(values[index-window+1:index+1]) * math.Sqrt(barsPerYear),
+		})
+	}
+
+	return series, nil
+}
+
+// mean returns the average of the values, 0 if there are none
+func mean(values []float64) float64 {
+	if len(values) < 1 {
+		return 0
+	}
+
+	sum := 0.0
+
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum / float64(len(values))
+}
+
+// standardDeviation returns the sample standard deviation of the values,
+// 0 for fewer than 2 values
+func standardDeviation(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	average := mean(values)
+	sum := 0.0
+
+	for _, value := range values {
+		sum += (value - average) * (value - average)
+	}
+
+	return math.Sqrt(sum / float64(len(values)-1))
+}
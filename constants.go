@@ -16,25 +16,155 @@ const ASSET_CLASS_STOCK = "STOCK"           // Stocks
 const ASSET_CLASS_UNKNOWN = "UNKNOWN"       // Unknown
 
 // Column names
+const COLUMN_ACTION_TYPE = "action_type"
+const COLUMN_AMOUNT = "amount"
+const COLUMN_ASK = "ask"
+const COLUMN_ASK_CLOSE = "ask_close"
+const COLUMN_ASKS = "asks"
+const COLUMN_ASK_SIZE = "ask_size"
 const COLUMN_ASSET_CLASS = "asset_class"
+const COLUMN_ADJUSTMENT_METHOD = "adjustment_method"
+const COLUMN_BASE_ASSET = "base_asset"
+const COLUMN_BID = "bid"
+const COLUMN_BID_CLOSE = "bid_close"
+const COLUMN_BIDS = "bids"
+const COLUMN_BID_SIZE = "bid_size"
 const COLUMN_CLOSE = "close"
+const COLUMN_CONTRACTS = "contracts"
 const COLUMN_CREATED_AT = "created_at"
+const COLUMN_DAY_ANCHOR = "day_anchor"
 const COLUMN_DESCRIPTION = "description"
+const COLUMN_EX_DATE = "ex_date"
+const COLUMN_EXPIRY = "expiry"
 const COLUMN_EXCHANGE = "exchange"
+const COLUMN_HALF_DAYS = "half_days"
 const COLUMN_ID = "id"
+const COLUMN_INDICATOR_VALUES = "indicator_values"
+const COLUMN_INSTRUMENT_ID = "instrument_id"
 const COLUMN_HIGH = "high"
+const COLUMN_HOLIDAYS = "holidays"
+const COLUMN_LOT_SIZE = "lot_size"
 const COLUMN_LOW = "low"
 const COLUMN_MEMO = "memo"
 const COLUMN_NAME = "name"
+const COLUMN_NEW_SYMBOL = "new_symbol"
+const COLUMN_OLD_SYMBOL = "old_symbol"
 const COLUMN_METAS = "metas"
+const COLUMN_MIN_NOTIONAL = "min_notional"
+const COLUMN_MULTIPLIER = "multiplier"
 const COLUMN_OPEN = "open"
+const COLUMN_OPEN_INTEREST = "open_interest"
+const COLUMN_OPTION_TYPE = "option_type"
+const COLUMN_PARAMS_HASH = "params_hash"
+const COLUMN_PRICE = "price"
+const COLUMN_PRICE_FIELDS = "price_fields"
+const COLUMN_PRICE_PRECISION = "price_precision"
+const COLUMN_QUOTE_CURRENCY = "quote_currency"
+const COLUMN_RATIO = "ratio"
+const COLUMN_ROLL_RULE = "roll_rule"
+const COLUMN_SESSIONS = "sessions"
+const COLUMN_SETTLEMENT_TYPE = "settlement_type"
+const COLUMN_SIDE = "side"
+const COLUMN_SIZE = "size"
 const COLUMN_SOFT_DELETED_AT = "soft_deleted_at"
+const COLUMN_SOURCE_TIMEFRAME = "source_timeframe"
 const COLUMN_STATUS = "status"
+const COLUMN_STRIKE = "strike"
 const COLUMN_SYMBOL = "symbol"
+const COLUMN_SYNTHETIC = "synthetic"
+const COLUMN_TAKER_BUY_VOLUME = "taker_buy_volume"
+const COLUMN_TICK_SIZE = "tick_size"
 const COLUMN_TIME = "time"
 const COLUMN_TIMEFRAMES = "timeframes"
+const COLUMN_TIMEZONE = "timezone"
+const COLUMN_TRADES = "trades"
+const COLUMN_TRADE_ID = "trade_id"
+const COLUMN_UNDERLYING_ID = "underlying_id"
 const COLUMN_UPDATED_AT = "updated_at"
 const COLUMN_VOLUME = "volume"
+const COLUMN_VOLUME_PRECISION = "volume_precision"
+const COLUMN_VWAP = "vwap"
+
+// Corporate action types
+const CORPORATE_ACTION_TYPE_CASH_DIVIDEND = "CASH_DIVIDEND"   // Cash paid per share, Amount is the cash per share
+const CORPORATE_ACTION_TYPE_SPLIT = "SPLIT"                   // Stock split, Ratio is the new shares per old share (2 for a 2-for-1 split)
+const CORPORATE_ACTION_TYPE_STOCK_DIVIDEND = "STOCK_DIVIDEND" // Shares paid per share, Ratio is the additional shares per share (0.05 for 5%)
+const CORPORATE_ACTION_TYPE_SYMBOL_CHANGE = "SYMBOL_CHANGE"   // Ticker change, from OldSymbol to NewSymbol
+
+// Price adjustments, combined as flags (ADJUST_SPLITS | ADJUST_DIVIDENDS)
+const ADJUST_NONE = 0
+const ADJUST_SPLITS = 1 << 0    // Back-adjust for splits and stock dividends
+const ADJUST_DIVIDENDS = 1 << 1 // Back-adjust for cash dividends
+
+// Bar types, how the bars built from ticks or transformed from prices are closed
+const BAR_TYPE_DOLLAR = "DOLLAR"           // Closed when the traded value (price times size) reaches the threshold
+const BAR_TYPE_HEIKIN_ASHI = "HEIKIN_ASHI" // Heikin-Ashi bars, one per price, smoothed with the previous bar
+const BAR_TYPE_RANGE = "RANGE"             // Closed when the high to low range reaches the range size
+const BAR_TYPE_RENKO = "RENKO"             // Bricks closed when the close moves a brick size beyond the last brick
+const BAR_TYPE_TICK = "TICK"               // Closed after the threshold number of ticks
+const BAR_TYPE_TIME = "TIME"               // Closed at the end of each timeframe bucket
+const BAR_TYPE_VOLUME = "VOLUME"           // Closed when the traded size reaches the threshold
+
+// Price panel joins, the times of the rows of a price panel
+const PRICE_PANEL_JOIN_INNER = "INNER" // The times at which every instrument has a price
+const PRICE_PANEL_JOIN_OUTER = "OUTER" // The times at which any instrument has a price
+
+// Price panel fills, how the missing prices of a price panel are filled
+const PRICE_PANEL_FILL_FORWARD = "FORWARD" // A flat price at the last close of the instrument, with no volume
+const PRICE_PANEL_FILL_NONE = "NONE"       // Left nil
+
+// Quote bar sources, the quote price the bars built from quotes are made of
+const QUOTE_BAR_SOURCE_ASK = "ASK" // The ask price
+const QUOTE_BAR_SOURCE_BID = "BID" // The bid price
+const QUOTE_BAR_SOURCE_MID = "MID" // The midpoint of the bid and the ask
+
+// Continuous contract roll rules
+const ROLL_RULE_DATE = "DATE"                   // Roll at the roll date of each contract
+const ROLL_RULE_OPEN_INTEREST = "OPEN_INTEREST" // Roll when the next contract open interest exceeds the current one
+const ROLL_RULE_VOLUME = "VOLUME"               // Roll when the next contract volume exceeds the current one
+
+// Continuous contract back-adjustment methods
+const ROLL_ADJUSTMENT_DIFFERENCE = "DIFFERENCE" // Shift the earlier contracts by the price gap at each roll
+const ROLL_ADJUSTMENT_NONE = "NONE"             // Stitch the raw prices
+const ROLL_ADJUSTMENT_RATIO = "RATIO"           // Scale the earlier contracts by the price ratio at each roll
+
+// Synthetic instrument operations, how the prices of the legs are combined
+const SYNTHETIC_OPERATION_DIFFERENCE = "DIFFERENCE"     // The first leg minus the other legs, i.e. a calendar spread
+const SYNTHETIC_OPERATION_RATIO = "RATIO"               // The first leg divided by the second leg, i.e. a pair ratio
+const SYNTHETIC_OPERATION_WEIGHTED_SUM = "WEIGHTED_SUM" // The sum of the legs, i.e. a basket or an index
+
+// Exchanges with built-in calendars
+const EXCHANGE_ASX = "ASX"       // Australian Securities Exchange
+const EXCHANGE_CRYPTO = "CRYPTO" // 24/7 cryptocurrency venues
+const EXCHANGE_FOREX = "FOREX"   // Foreign exchange, Sunday 17:00 to Friday 17:00 New York
+const EXCHANGE_LSE = "LSE"       // London Stock Exchange
+const EXCHANGE_NASDAQ = "NASDAQ" // Nasdaq Stock Market
+const EXCHANGE_NYSE = "NYSE"     // New York Stock Exchange
+const EXCHANGE_TSE = "TSE"       // Tokyo Stock Exchange
+
+// Option types
+const OPTION_TYPE_CALL = "CALL"
+const OPTION_TYPE_PUT = "PUT"
+
+// Settlement types
+const SETTLEMENT_TYPE_CASH = "CASH"         // Settled in cash at expiry
+const SETTLEMENT_TYPE_PHYSICAL = "PHYSICAL" // Settled by delivery of the underlying
+
+// Tick sides, the side of the aggressor of a trade
+const TICK_SIDE_BUY = "BUY"   // Bought by a taker, lifting the ask
+const TICK_SIDE_SELL = "SELL" // Sold by a taker, hitting the bid
+
+// Price time storage, the format of the time column of the price tables
+const PRICE_TIME_STORAGE_DATETIME = "datetime"         // UTC datetime, i.e. 2024-01-02 15:04:05 (default)
+const PRICE_TIME_STORAGE_EPOCH_MILLIS = "epoch_millis" // Integer milliseconds since the Unix epoch
+
+// Price precision, the number of decimals of the instrument prices
+const PRICE_PRECISION_DEFAULT = 8
+const PRICE_PRECISION_MAX = 18
+
+// Volume precision, the number of decimals of the instrument volumes
+const VOLUME_PRECISION_DEFAULT = 8
+const VOLUME_PRECISION_MAX = 18
 
 // Nil float
 const NIL_FLOAT = -0.0000000001
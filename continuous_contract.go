@@ -0,0 +1,210 @@
+package tradingstore
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/dracory/dataobject"
+	"github.com/dracory/uid"
+	"github.com/dromara/carbon/v2"
+)
+
+// == TYPES ====================================================================
+
+// ContinuousContractLeg is a contract in the chain of a continuous contract
+type ContinuousContractLeg struct {
+	// InstrumentID is the ID of the contract instrument
+	InstrumentID string `json:"instrument_id"`
+
+	// RollDate is the date to roll to the next contract, formatted as
+	// YYYY-MM-DD. Required by the date roll rule, except for the last contract.
+	// Used as the fallback of the volume and open interest roll rules.
+	RollDate string `json:"roll_date,omitempty"`
+}
+
+// ContinuousContractRoll is a roll between two contracts of a stitched series
+type ContinuousContractRoll struct {
+	// Time is the time of the first bar of the new contract
+	Time time.Time
+
+	// FromInstrumentID is the ID of the contract rolled from
+	FromInstrumentID string
+
+	// ToInstrumentID is the ID of the contract rolled to
+	ToInstrumentID string
+
+	// Gap is the close of the new contract minus the close of the old
+	// contract, on the last bar before the roll
+	Gap float64
+
+	// Ratio is the close of the new contract divided by the close of the
+	// old contract, on the last bar before the roll
+	Ratio float64
+}
+
+// == CLASS ====================================================================
+
+// continuousContractImplementation represents a continuous futures contract,
+// stitched from a chain of contract instruments
+type continuousContractImplementation struct {
+	dataobject.DataObject
+}
+
+// == CONSTRUCTORS =============================================================
+
+func NewContinuousContract() ContinuousContractInterface {
+	o := (&continuousContractImplementation{}).
+		SetID(uid.HumanUid())
+
+	// Default values
+	o.SetAdjustmentMethod(ROLL_ADJUSTMENT_NONE)
+	o.SetExchange("")
+	o.SetMemo("")
+	o.SetName("")
+	o.SetRollRule(ROLL_RULE_DATE)
+	o.SetSymbol("")
+	_ = o.SetContracts([]ContinuousContractLeg{})
+	o.SetCreatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+	o.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+
+	return o
+}
+
+func NewContinuousContractFromExistingData(data map[string]string) ContinuousContractInterface {
+	o := &continuousContractImplementation{}
+	o.Hydrate(data)
+	return o
+}
+
+var _ ContinuousContractInterface = (*continuousContractImplementation)(nil)
+
+// == SETTERS & GETTERS ========================================================
+
+func (contract *continuousContractImplementation) ID() string {
+	return contract.Get(COLUMN_ID)
+}
+
+func (contract *continuousContractImplementation) SetID(id string) ContinuousContractInterface {
+	contract.Set(COLUMN_ID, id)
+	return contract
+}
+
+func (contract *continuousContractImplementation) AdjustmentMethod() string {
+	return contract.Get(COLUMN_ADJUSTMENT_METHOD)
+}
+
+func (contract *continuousContractImplementation) SetAdjustmentMethod(adjustmentMethod string) ContinuousContractInterface {
+	contract.Set(COLUMN_ADJUSTMENT_METHOD, adjustmentMethod)
+	return contract
+}
+
+func (contract *continuousContractImplementation) Contracts() ([]ContinuousContractLeg, error) {
+	contractsStr := contract.Get(COLUMN_CONTRACTS)
+	if contractsStr == "" {
+		return []ContinuousContractLeg{}, nil
+	}
+
+	var contracts []ContinuousContractLeg
+	err := json.Unmarshal([]byte(contractsStr), &contracts)
+	if err != nil {
+		return []ContinuousContractLeg{}, err
+	}
+
+	return contracts, nil
+}
+
+func (contract *continuousContractImplementation) SetContracts(contracts []ContinuousContractLeg) error {
+	for _, leg := range contracts {
+		if leg.InstrumentID == "" {
+			return errors.New("continuous contract: contract instrument id cannot be empty")
+		}
+
+		if leg.RollDate == "" {
+			continue
+		}
+
+		if _, err := time.Parse(time.DateOnly, leg.RollDate); err != nil {
+			return errors.New("continuous contract: roll date must be formatted as YYYY-MM-DD: " + leg.RollDate)
+		}
+	}
+
+	contractsJson, err := json.Marshal(contracts)
+	if err != nil {
+		return err
+	}
+
+	contract.Set(COLUMN_CONTRACTS, string(contractsJson))
+	return nil
+}
+
+func (contract *continuousContractImplementation) Exchange() string {
+	return contract.Get(COLUMN_EXCHANGE)
+}
+
+func (contract *continuousContractImplementation) SetExchange(exchange string) ContinuousContractInterface {
+	contract.Set(COLUMN_EXCHANGE, exchange)
+	return contract
+}
+
+func (contract *continuousContractImplementation) Memo() string {
+	return contract.Get(COLUMN_MEMO)
+}
+
+func (contract *continuousContractImplementation) SetMemo(memo string) ContinuousContractInterface {
+	contract.Set(COLUMN_MEMO, memo)
+	return contract
+}
+
+func (contract *continuousContractImplementation) Name() string {
+	return contract.Get(COLUMN_NAME)
+}
+
+func (contract *continuousContractImplementation) SetName(name string) ContinuousContractInterface {
+	contract.Set(COLUMN_NAME, name)
+	return contract
+}
+
+func (contract *continuousContractImplementation) RollRule() string {
+	return contract.Get(COLUMN_ROLL_RULE)
+}
+
+func (contract *continuousContractImplementation) SetRollRule(rollRule string) ContinuousContractInterface {
+	contract.Set(COLUMN_ROLL_RULE, rollRule)
+	return contract
+}
+
+func (contract *continuousContractImplementation) Symbol() string {
+	return contract.Get(COLUMN_SYMBOL)
+}
+
+func (contract *continuousContractImplementation) SetSymbol(symbol string) ContinuousContractInterface {
+	contract.Set(COLUMN_SYMBOL, symbol)
+	return contract
+}
+
+func (contract *continuousContractImplementation) CreatedAt() string {
+	return contract.Get(COLUMN_CREATED_AT)
+}
+
+func (contract *continuousContractImplementation) CreatedAtCarbon() *carbon.Carbon {
+	return carbon.Parse(contract.CreatedAt(), carbon.UTC)
+}
+
+func (contract *continuousContractImplementation) SetCreatedAt(createdAt string) ContinuousContractInterface {
+	contract.Set(COLUMN_CREATED_AT, createdAt)
+	return contract
+}
+
+func (contract *continuousContractImplementation) UpdatedAt() string {
+	return contract.Get(COLUMN_UPDATED_AT)
+}
+
+func (contract *continuousContractImplementation) UpdatedAtCarbon() *carbon.Carbon {
+	return carbon.Parse(contract.UpdatedAt(), carbon.UTC)
+}
+
+func (contract *continuousContractImplementation) SetUpdatedAt(updatedAt string) ContinuousContractInterface {
+	contract.Set(COLUMN_UPDATED_AT, updatedAt)
+	return contract
+}
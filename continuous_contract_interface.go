@@ -0,0 +1,46 @@
+package tradingstore
+
+import (
+	"github.com/dromara/carbon/v2"
+)
+
+type ContinuousContractInterface interface {
+	// from dataobject
+	Data() map[string]string
+	DataChanged() map[string]string
+	MarkAsNotDirty()
+
+	// setters and getters
+
+	ID() string
+	SetID(id string) ContinuousContractInterface
+
+	AdjustmentMethod() string
+	SetAdjustmentMethod(adjustmentMethod string) ContinuousContractInterface
+
+	Contracts() ([]ContinuousContractLeg, error)
+	SetContracts(contracts []ContinuousContractLeg) error
+
+	Exchange() string
+	SetExchange(exchange string) ContinuousContractInterface
+
+	Memo() string
+	SetMemo(memo string) ContinuousContractInterface
+
+	Name() string
+	SetName(name string) ContinuousContractInterface
+
+	RollRule() string
+	SetRollRule(rollRule string) ContinuousContractInterface
+
+	Symbol() string
+	SetSymbol(symbol string) ContinuousContractInterface
+
+	CreatedAt() string
+	CreatedAtCarbon() *carbon.Carbon
+	SetCreatedAt(createdAt string) ContinuousContractInterface
+
+	UpdatedAt() string
+	UpdatedAtCarbon() *carbon.Carbon
+	SetUpdatedAt(updatedAt string) ContinuousContractInterface
+}
@@ -0,0 +1,220 @@
+package tradingstore
+
+import "errors"
+
+// ContinuousContractQuery is a shortcut for NewContinuousContractQuery
+func ContinuousContractQuery() ContinuousContractQueryInterface {
+	return NewContinuousContractQuery()
+}
+
+// NewContinuousContractQuery creates a new continuous contract query
+func NewContinuousContractQuery() ContinuousContractQueryInterface {
+	return &continuousContractQueryImplementation{
+		properties: make(map[string]any),
+	}
+}
+
+type continuousContractQueryImplementation struct {
+	properties map[string]any
+}
+
+var _ ContinuousContractQueryInterface = (*continuousContractQueryImplementation)(nil) // verify interface is implemented
+
+func (c *continuousContractQueryImplementation) hasProperty(name string) bool {
+	_, ok := c.properties[name]
+	return ok
+}
+
+func (c *continuousContractQueryImplementation) Validate() error {
+	if c.IsExchangeSet() && c.Exchange() == "" {
+		return errors.New("continuous contract query. exchange cannot be empty")
+	}
+
+	if c.IsIDSet() && c.ID() == "" {
+		return errors.New("continuous contract query. id cannot be empty")
+	}
+
+	if c.IsSymbolSet() && c.Symbol() == "" {
+		return errors.New("continuous contract query. symbol cannot be empty")
+	}
+
+	if c.IsOrderBySet() && c.OrderBy() == "" {
+		return errors.New("continuous contract query. order_by cannot be empty")
+	}
+
+	if c.IsOrderDirectionSet() && c.OrderDirection() == "" {
+		return errors.New("continuous contract query. order_direction cannot be empty")
+	}
+
+	if c.IsLimitSet() && c.Limit() <= 0 {
+		return errors.New("continuous contract query. limit must be greater than 0")
+	}
+
+	if c.IsOffsetSet() && c.Offset() < 0 {
+		return errors.New("continuous contract query. offset must be greater than or equal to 0")
+	}
+
+	return nil
+}
+
+func (c *continuousContractQueryImplementation) IsColumnsSet() bool {
+	return c.hasProperty("columns")
+}
+
+func (c *continuousContractQueryImplementation) Columns() []string {
+	if !c.IsColumnsSet() {
+		return []string{}
+	}
+
+	return c.properties["columns"].([]string)
+}
+
+func (c *continuousContractQueryImplementation) SetColumns(columns []string) ContinuousContractQueryInterface {
+	c.properties["columns"] = columns
+
+	return c
+}
+
+func (c *continuousContractQueryImplementation) IsCountOnlySet() bool {
+	return c.hasProperty("count_only")
+}
+
+func (c *continuousContractQueryImplementation) IsCountOnly() bool {
+	if !c.IsCountOnlySet() {
+		return false
+	}
+
+	return c.properties["count_only"].(bool)
+}
+
+func (c *continuousContractQueryImplementation) SetCountOnly(countOnly bool) ContinuousContractQueryInterface {
+	c.properties["count_only"] = countOnly
+
+	return c
+}
+
+func (c *continuousContractQueryImplementation) IsExchangeSet() bool {
+	return c.hasProperty("exchange")
+}
+
+func (c *continuousContractQueryImplementation) Exchange() string {
+	if !c.IsExchangeSet() {
+		return ""
+	}
+
+	return c.properties["exchange"].(string)
+}
+
+func (c *continuousContractQueryImplementation) SetExchange(exchange string) ContinuousContractQueryInterface {
+	c.properties["exchange"] = exchange
+
+	return c
+}
+
+func (c *continuousContractQueryImplementation) IsIDSet() bool {
+	return c.hasProperty("id")
+}
+
+func (c *continuousContractQueryImplementation) ID() string {
+	if !c.IsIDSet() {
+		return ""
+	}
+
+	return c.properties["id"].(string)
+}
+
+func (c *continuousContractQueryImplementation) SetID(id string) ContinuousContractQueryInterface {
+	c.properties["id"] = id
+
+	return c
+}
+
+func (c *continuousContractQueryImplementation) IsSymbolSet() bool {
+	return c.hasProperty("symbol")
+}
+
+func (c *continuousContractQueryImplementation) Symbol() string {
+	if !c.IsSymbolSet() {
+		return ""
+	}
+
+	return c.properties["symbol"].(string)
+}
+
+func (c *continuousContractQueryImplementation) SetSymbol(symbol string) ContinuousContractQueryInterface {
+	c.properties["symbol"] = symbol
+
+	return c
+}
+
+func (c *continuousContractQueryImplementation) IsLimitSet() bool {
+	return c.hasProperty("limit")
+}
+
+func (c *continuousContractQueryImplementation) Limit() int {
+	if !c.IsLimitSet() {
+		return 0
+	}
+
+	return c.properties["limit"].(int)
+}
+
+func (c *continuousContractQueryImplementation) SetLimit(limit int) ContinuousContractQueryInterface {
+	c.properties["limit"] = limit
+
+	return c
+}
+
+func (c *continuousContractQueryImplementation) IsOffsetSet() bool {
+	return c.hasProperty("offset")
+}
+
+func (c *continuousContractQueryImplementation) Offset() int {
+	if !c.IsOffsetSet() {
+		return 0
+	}
+
+	return c.properties["offset"].(int)
+}
+
+func (c *continuousContractQueryImplementation) SetOffset(offset int) ContinuousContractQueryInterface {
+	c.properties["offset"] = offset
+
+	return c
+}
+
+func (c *continuousContractQueryImplementation) IsOrderBySet() bool {
+	return c.hasProperty("order_by")
+}
+
+func (c *continuousContractQueryImplementation) OrderBy() string {
+	if !c.IsOrderBySet() {
+		return ""
+	}
+
+	return c.properties["order_by"].(string)
+}
+
+func (c *continuousContractQueryImplementation) SetOrderBy(orderBy string) ContinuousContractQueryInterface {
+	c.properties["order_by"] = orderBy
+
+	return c
+}
+
+func (c *continuousContractQueryImplementation) IsOrderDirectionSet() bool {
+	return c.hasProperty("order_direction")
+}
+
+func (c *continuousContractQueryImplementation) OrderDirection() string {
+	if !c.IsOrderDirectionSet() {
+		return ""
+	}
+
+	return c.properties["order_direction"].(string)
+}
+
+func (c *continuousContractQueryImplementation) SetOrderDirection(orderDirection string) ContinuousContractQueryInterface {
+	c.properties["order_direction"] = orderDirection
+
+	return c
+}
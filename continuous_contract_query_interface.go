@@ -0,0 +1,41 @@
+package tradingstore
+
+type ContinuousContractQueryInterface interface {
+	Validate() error
+
+	IsColumnsSet() bool
+	Columns() []string
+	SetColumns(columns []string) ContinuousContractQueryInterface
+
+	IsCountOnlySet() bool
+	IsCountOnly() bool
+	SetCountOnly(countOnly bool) ContinuousContractQueryInterface
+
+	IsExchangeSet() bool
+	Exchange() string
+	SetExchange(exchange string) ContinuousContractQueryInterface
+
+	IsIDSet() bool
+	ID() string
+	SetID(id string) ContinuousContractQueryInterface
+
+	IsSymbolSet() bool
+	Symbol() string
+	SetSymbol(symbol string) ContinuousContractQueryInterface
+
+	IsLimitSet() bool
+	Limit() int
+	SetLimit(limit int) ContinuousContractQueryInterface
+
+	IsOffsetSet() bool
+	Offset() int
+	SetOffset(offset int) ContinuousContractQueryInterface
+
+	IsOrderBySet() bool
+	OrderBy() string
+	SetOrderBy(orderBy string) ContinuousContractQueryInterface
+
+	IsOrderDirectionSet() bool
+	OrderDirection() string
+	SetOrderDirection(orderDirection string) ContinuousContractQueryInterface
+}
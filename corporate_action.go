@@ -0,0 +1,205 @@
+package tradingstore
+
+import (
+	"github.com/dracory/dataobject"
+	"github.com/dracory/uid"
+	"github.com/dromara/carbon/v2"
+	"github.com/spf13/cast"
+)
+
+// == CLASS ====================================================================
+
+// corporateActionImplementation represents a corporate action of an
+// instrument, i.e. a split, a dividend or a symbol change
+type corporateActionImplementation struct {
+	dataobject.DataObject
+}
+
+// == CONSTRUCTORS =============================================================
+
+func NewCorporateAction() CorporateActionInterface {
+	o := (&corporateActionImplementation{}).
+		SetID(uid.HumanUid())
+
+	// Default values
+	o.SetActionType("")
+	o.SetAmount("0")
+	o.SetExDate("")
+	o.SetInstrumentID("")
+	o.SetMemo("")
+	o.SetNewSymbol("")
+	o.SetOldSymbol("")
+	o.SetRatio("1")
+	o.SetCreatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+	o.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+
+	return o
+}
+
+func NewCorporateActionFromExistingData(data map[string]string) CorporateActionInterface {
+	o := &corporateActionImplementation{}
+	o.Hydrate(data)
+	return o
+}
+
+var _ CorporateActionInterface = (*corporateActionImplementation)(nil)
+
+// == METHODS ==================================================================
+
+// DividendFactor returns the factor the prices before the ex-date are
+// multiplied by to adjust for a cash dividend, given the close before
+// the ex-date. Returns 1 for the other action types.
+func (action *corporateActionImplementation) DividendFactor(previousClose float64) float64 {
+	if action.ActionType() != CORPORATE_ACTION_TYPE_CASH_DIVIDEND {
+		return 1
+	}
+
+	amount := action.AmountFloat()
+
+	if amount <= 0 || previousClose <= amount {
+		return 1
+	}
+
+	return 1 - amount/previousClose
+}
+
+// SplitFactor returns the number of shares after the action per share
+// before it. The prices before the ex-date are divided by the factor, and
+// the volumes multiplied by it. Returns 1 for the other action types.
+func (action *corporateActionImplementation) SplitFactor() float64 {
+	ratio := action.RatioFloat()
+
+	switch action.ActionType() {
+	case CORPORATE_ACTION_TYPE_SPLIT:
+		if ratio > 0 {
+			return ratio
+		}
+	case CORPORATE_ACTION_TYPE_STOCK_DIVIDEND:
+		if ratio > 0 {
+			return 1 + ratio
+		}
+	}
+
+	return 1
+}
+
+// == SETTERS & GETTERS ========================================================
+
+func (action *corporateActionImplementation) ID() string {
+	return action.Get(COLUMN_ID)
+}
+
+func (action *corporateActionImplementation) SetID(id string) CorporateActionInterface {
+	action.Set(COLUMN_ID, id)
+	return action
+}
+
+func (action *corporateActionImplementation) ActionType() string {
+	return action.Get(COLUMN_ACTION_TYPE)
+}
+
+func (action *corporateActionImplementation) SetActionType(actionType string) CorporateActionInterface {
+	action.Set(COLUMN_ACTION_TYPE, actionType)
+	return action
+}
+
+func (action *corporateActionImplementation) Amount() string {
+	return action.Get(COLUMN_AMOUNT)
+}
+
+func (action *corporateActionImplementation) AmountFloat() float64 {
+	return cast.ToFloat64(action.Amount())
+}
+
+func (action *corporateActionImplementation) SetAmount(amount string) CorporateActionInterface {
+	action.Set(COLUMN_AMOUNT, amount)
+	return action
+}
+
+func (action *corporateActionImplementation) ExDate() string {
+	return action.Get(COLUMN_EX_DATE)
+}
+
+func (action *corporateActionImplementation) ExDateCarbon() *carbon.Carbon {
+	return carbon.Parse(action.ExDate(), carbon.UTC)
+}
+
+func (action *corporateActionImplementation) SetExDate(exDate string) CorporateActionInterface {
+	action.Set(COLUMN_EX_DATE, exDate)
+	return action
+}
+
+func (action *corporateActionImplementation) InstrumentID() string {
+	return action.Get(COLUMN_INSTRUMENT_ID)
+}
+
+func (action *corporateActionImplementation) SetInstrumentID(instrumentID string) CorporateActionInterface {
+	action.Set(COLUMN_INSTRUMENT_ID, instrumentID)
+	return action
+}
+
+func (action *corporateActionImplementation) Memo() string {
+	return action.Get(COLUMN_MEMO)
+}
+
+func (action *corporateActionImplementation) SetMemo(memo string) CorporateActionInterface {
+	action.Set(COLUMN_MEMO, memo)
+	return action
+}
+
+func (action *corporateActionImplementation) NewSymbol() string {
+	return action.Get(COLUMN_NEW_SYMBOL)
+}
+
+func (action *corporateActionImplementation) SetNewSymbol(newSymbol string) CorporateActionInterface {
+	action.Set(COLUMN_NEW_SYMBOL, newSymbol)
+	return action
+}
+
+func (action *corporateActionImplementation) OldSymbol() string {
+	return action.Get(COLUMN_OLD_SYMBOL)
+}
+
+func (action *corporateActionImplementation) SetOldSymbol(oldSymbol string) CorporateActionInterface {
+	action.Set(COLUMN_OLD_SYMBOL, oldSymbol)
+	return action
+}
+
+func (action *corporateActionImplementation) Ratio() string {
+	return action.Get(COLUMN_RATIO)
+}
+
+func (action *corporateActionImplementation) RatioFloat() float64 {
+	return cast.ToFloat64(action.Ratio())
+}
+
+func (action *corporateActionImplementation) SetRatio(ratio string) CorporateActionInterface {
+	action.Set(COLUMN_RATIO, ratio)
+	return action
+}
+
+func (action *corporateActionImplementation) CreatedAt() string {
+	return action.Get(COLUMN_CREATED_AT)
+}
+
+func (action *corporateActionImplementation) CreatedAtCarbon() *carbon.Carbon {
+	return carbon.Parse(action.CreatedAt(), carbon.UTC)
+}
+
+func (action *corporateActionImplementation) SetCreatedAt(createdAt string) CorporateActionInterface {
+	action.Set(COLUMN_CREATED_AT, createdAt)
+	return action
+}
+
+func (action *corporateActionImplementation) UpdatedAt() string {
+	return action.Get(COLUMN_UPDATED_AT)
+}
+
+func (action *corporateActionImplementation) UpdatedAtCarbon() *carbon.Carbon {
+	return carbon.Parse(action.UpdatedAt(), carbon.UTC)
+}
+
+func (action *corporateActionImplementation) SetUpdatedAt(updatedAt string) CorporateActionInterface {
+	action.Set(COLUMN_UPDATED_AT, updatedAt)
+	return action
+}
@@ -0,0 +1,57 @@
+package tradingstore
+
+import (
+	"github.com/dromara/carbon/v2"
+)
+
+type CorporateActionInterface interface {
+	// from dataobject
+	Data() map[string]string
+	DataChanged() map[string]string
+	MarkAsNotDirty()
+
+	// methods
+
+	DividendFactor(previousClose float64) float64
+	SplitFactor() float64
+
+	// setters and getters
+
+	ID() string
+	SetID(id string) CorporateActionInterface
+
+	ActionType() string
+	SetActionType(actionType string) CorporateActionInterface
+
+	Amount() string
+	AmountFloat() float64
+	SetAmount(amount string) CorporateActionInterface
+
+	ExDate() string
+	ExDateCarbon() *carbon.Carbon
+	SetExDate(exDate string) CorporateActionInterface
+
+	InstrumentID() string
+	SetInstrumentID(instrumentID string) CorporateActionInterface
+
+	Memo() string
+	SetMemo(memo string) CorporateActionInterface
+
+	NewSymbol() string
+	SetNewSymbol(newSymbol string) CorporateActionInterface
+
+	OldSymbol() string
+	SetOldSymbol(oldSymbol string) CorporateActionInterface
+
+	Ratio() string
+	RatioFloat() float64
+	SetRatio(ratio string) CorporateActionInterface
+
+	CreatedAt() string
+	CreatedAtCarbon() *carbon.Carbon
+	SetCreatedAt(createdAt string) CorporateActionInterface
+
+	UpdatedAt() string
+	UpdatedAtCarbon() *carbon.Carbon
+	SetUpdatedAt(updatedAt string) CorporateActionInterface
+}
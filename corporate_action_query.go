@@ -0,0 +1,286 @@
+package tradingstore
+
+import "errors"
+
+// CorporateActionQuery is a shortcut for NewCorporateActionQuery
+func CorporateActionQuery() CorporateActionQueryInterface {
+	return NewCorporateActionQuery()
+}
+
+// NewCorporateActionQuery creates a new corporate action query
+func NewCorporateActionQuery() CorporateActionQueryInterface {
+	return &corporateActionQueryImplementation{
+		properties: make(map[string]any),
+	}
+}
+
+type corporateActionQueryImplementation struct {
+	properties map[string]any
+}
+
+var _ CorporateActionQueryInterface = (*corporateActionQueryImplementation)(nil) // verify interface is implemented
+
+func (c *corporateActionQueryImplementation) hasProperty(name string) bool {
+	_, ok := c.properties[name]
+	return ok
+}
+
+func (c *corporateActionQueryImplementation) Validate() error {
+	if c.IsActionTypeSet() && c.ActionType() == "" {
+		return errors.New("corporate action query. action_type cannot be empty")
+	}
+
+	if c.IsExDateGteSet() && c.ExDateGte() == "" {
+		return errors.New("corporate action query. ex_date_gte cannot be empty")
+	}
+
+	if c.IsExDateLteSet() && c.ExDateLte() == "" {
+		return errors.New("corporate action query. ex_date_lte cannot be empty")
+	}
+
+	if c.IsIDSet() && c.ID() == "" {
+		return errors.New("corporate action query. id cannot be empty")
+	}
+
+	if c.IsInstrumentIDSet() && c.InstrumentID() == "" {
+		return errors.New("corporate action query. instrument_id cannot be empty")
+	}
+
+	if c.IsOrderBySet() && c.OrderBy() == "" {
+		return errors.New("corporate action query. order_by cannot be empty")
+	}
+
+	if c.IsOrderDirectionSet() && c.OrderDirection() == "" {
+		return errors.New("corporate action query. order_direction cannot be empty")
+	}
+
+	if c.IsActionTypeInSet() && len(c.ActionTypeIn()) < 1 {
+		return errors.New("corporate action query. action_type_in cannot be empty")
+	}
+
+	if c.IsLimitSet() && c.Limit() <= 0 {
+		return errors.New("corporate action query. limit must be greater than 0")
+	}
+
+	if c.IsOffsetSet() && c.Offset() < 0 {
+		return errors.New("corporate action query. offset must be greater than or equal to 0")
+	}
+
+	return nil
+}
+
+func (c *corporateActionQueryImplementation) IsActionTypeSet() bool {
+	return c.hasProperty("action_type")
+}
+
+func (c *corporateActionQueryImplementation) ActionType() string {
+	if !c.IsActionTypeSet() {
+		return ""
+	}
+
+	return c.properties["action_type"].(string)
+}
+
+func (c *corporateActionQueryImplementation) SetActionType(actionType string) CorporateActionQueryInterface {
+	c.properties["action_type"] = actionType
+
+	return c
+}
+
+func (c *corporateActionQueryImplementation) IsActionTypeInSet() bool {
+	return c.hasProperty("action_type_in")
+}
+
+func (c *corporateActionQueryImplementation) ActionTypeIn() []string {
+	if !c.IsActionTypeInSet() {
+		return []string{}
+	}
+
+	return c.properties["action_type_in"].([]string)
+}
+
+func (c *corporateActionQueryImplementation) SetActionTypeIn(actionTypeIn []string) CorporateActionQueryInterface {
+	c.properties["action_type_in"] = actionTypeIn
+
+	return c
+}
+
+func (c *corporateActionQueryImplementation) IsColumnsSet() bool {
+	return c.hasProperty("columns")
+}
+
+func (c *corporateActionQueryImplementation) Columns() []string {
+	if !c.IsColumnsSet() {
+		return []string{}
+	}
+
+	return c.properties["columns"].([]string)
+}
+
+func (c *corporateActionQueryImplementation) SetColumns(columns []string) CorporateActionQueryInterface {
+	c.properties["columns"] = columns
+
+	return c
+}
+
+func (c *corporateActionQueryImplementation) IsCountOnlySet() bool {
+	return c.hasProperty("count_only")
+}
+
+func (c *corporateActionQueryImplementation) IsCountOnly() bool {
+	if !c.IsCountOnlySet() {
+		return false
+	}
+
+	return c.properties["count_only"].(bool)
+}
+
+func (c *corporateActionQueryImplementation) SetCountOnly(countOnly bool) CorporateActionQueryInterface {
+	c.properties["count_only"] = countOnly
+
+	return c
+}
+
+func (c *corporateActionQueryImplementation) IsExDateGteSet() bool {
+	return c.hasProperty("ex_date_gte")
+}
+
+func (c *corporateActionQueryImplementation) ExDateGte() string {
+	if !c.IsExDateGteSet() {
+		return ""
+	}
+
+	return c.properties["ex_date_gte"].(string)
+}
+
+func (c *corporateActionQueryImplementation) SetExDateGte(exDateGte string) CorporateActionQueryInterface {
+	c.properties["ex_date_gte"] = exDateGte
+
+	return c
+}
+
+func (c *corporateActionQueryImplementation) IsExDateLteSet() bool {
+	return c.hasProperty("ex_date_lte")
+}
+
+func (c *corporateActionQueryImplementation) ExDateLte() string {
+	if !c.IsExDateLteSet() {
+		return ""
+	}
+
+	return c.properties["ex_date_lte"].(string)
+}
+
+func (c *corporateActionQueryImplementation) SetExDateLte(exDateLte string) CorporateActionQueryInterface {
+	c.properties["ex_date_lte"] = exDateLte
+
+	return c
+}
+
+func (c *corporateActionQueryImplementation) IsIDSet() bool {
+	return c.hasProperty("id")
+}
+
+func (c *corporateActionQueryImplementation) ID() string {
+	if !c.IsIDSet() {
+		return ""
+	}
+
+	return c.properties["id"].(string)
+}
+
+func (c *corporateActionQueryImplementation) SetID(id string) CorporateActionQueryInterface {
+	c.properties["id"] = id
+
+	return c
+}
+
+func (c *corporateActionQueryImplementation) IsInstrumentIDSet() bool {
+	return c.hasProperty("instrument_id")
+}
+
+func (c *corporateActionQueryImplementation) InstrumentID() string {
+	if !c.IsInstrumentIDSet() {
+		return ""
+	}
+
+	return c.properties["instrument_id"].(string)
+}
+
+func (c *corporateActionQueryImplementation) SetInstrumentID(instrumentID string) CorporateActionQueryInterface {
+	c.properties["instrument_id"] = instrumentID
+
+	return c
+}
+
+func (c *corporateActionQueryImplementation) IsLimitSet() bool {
+	return c.hasProperty("limit")
+}
+
+func (c *corporateActionQueryImplementation) Limit() int {
+	if !c.IsLimitSet() {
+		return 0
+	}
+
+	return c.properties["limit"].(int)
+}
+
+func (c *corporateActionQueryImplementation) SetLimit(limit int) CorporateActionQueryInterface {
+	c.properties["limit"] = limit
+
+	return c
+}
+
+func (c *corporateActionQueryImplementation) IsOffsetSet() bool {
+	return c.hasProperty("offset")
+}
+
+func (c *corporateActionQueryImplementation) Offset() int {
+	if !c.IsOffsetSet() {
+		return 0
+	}
+
+	return c.properties["offset"].(int)
+}
+
+func (c *corporateActionQueryImplementation) SetOffset(offset int) CorporateActionQueryInterface {
+	c.properties["offset"] = offset
+
+	return c
+}
+
+func (c *corporateActionQueryImplementation) IsOrderBySet() bool {
+	return c.hasProperty("order_by")
+}
+
+func (c *corporateActionQueryImplementation) OrderBy() string {
+	if !c.IsOrderBySet() {
+		return ""
+	}
+
+	return c.properties["order_by"].(string)
+}
+
+func (c *corporateActionQueryImplementation) SetOrderBy(orderBy string) CorporateActionQueryInterface {
+	c.properties["order_by"] = orderBy
+
+	return c
+}
+
+func (c *corporateActionQueryImplementation) IsOrderDirectionSet() bool {
+	return c.hasProperty("order_direction")
+}
+
+func (c *corporateActionQueryImplementation) OrderDirection() string {
+	if !c.IsOrderDirectionSet() {
+		return ""
+	}
+
+	return c.properties["order_direction"].(string)
+}
+
+func (c *corporateActionQueryImplementation) SetOrderDirection(orderDirection string) CorporateActionQueryInterface {
+	c.properties["order_direction"] = orderDirection
+
+	return c
+}
@@ -0,0 +1,53 @@
+package tradingstore
+
+type CorporateActionQueryInterface interface {
+	Validate() error
+
+	IsActionTypeSet() bool
+	ActionType() string
+	SetActionType(actionType string) CorporateActionQueryInterface
+
+	IsActionTypeInSet() bool
+	ActionTypeIn() []string
+	SetActionTypeIn(actionTypeIn []string) CorporateActionQueryInterface
+
+	IsColumnsSet() bool
+	Columns() []string
+	SetColumns(columns []string) CorporateActionQueryInterface
+
+	IsCountOnlySet() bool
+	IsCountOnly() bool
+	SetCountOnly(countOnly bool) CorporateActionQueryInterface
+
+	IsExDateGteSet() bool
+	ExDateGte() string
+	SetExDateGte(exDateGte string) CorporateActionQueryInterface
+
+	IsExDateLteSet() bool
+	ExDateLte() string
+	SetExDateLte(exDateLte string) CorporateActionQueryInterface
+
+	IsIDSet() bool
+	ID() string
+	SetID(id string) CorporateActionQueryInterface
+
+	IsInstrumentIDSet() bool
+	InstrumentID() string
+	SetInstrumentID(instrumentID string) CorporateActionQueryInterface
+
+	IsLimitSet() bool
+	Limit() int
+	SetLimit(limit int) CorporateActionQueryInterface
+
+	IsOffsetSet() bool
+	Offset() int
+	SetOffset(offset int) CorporateActionQueryInterface
+
+	IsOrderBySet() bool
+	OrderBy() string
+	SetOrderBy(orderBy string) CorporateActionQueryInterface
+
+	IsOrderDirectionSet() bool
+	OrderDirection() string
+	SetOrderDirection(orderDirection string) CorporateActionQueryInterface
+}
@@ -0,0 +1,158 @@
+package tradingstore
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// currencyConversionPivot is the currency converted through when no FX pair
+// converts directly between two currencies
+const currencyConversionPivot = "USD"
+
+// currencyConversionColumns are the price columns converted into another
+// currency, the volume is left unchanged
+var currencyConversionColumns = []string{
+	COLUMN_OPEN,
+	COLUMN_HIGH,
+	COLUMN_LOW,
+	COLUMN_CLOSE,
+	COLUMN_VWAP,
+	COLUMN_BID_CLOSE,
+	COLUMN_ASK_CLOSE,
+}
+
+// currencyConversionLeg is an FX pair of a conversion path. The close of the
+// pair is the number of quote currency units per base currency unit, so the
+// prices are multiplied by it when converting from the base currency, and
+// divided by it (inverse) when converting from the quote currency
+type currencyConversionLeg struct {
+	pair    InstrumentInterface
+	inverse bool
+}
+
+// PriceConvert converts prices into another currency with the closes of an
+// FX rate series.
+//
+// Each price is converted with the last rate at or before its time (as-of
+// join), so rates of a coarser timeframe, i.e. daily rates for hourly
+// prices, can be used. Prices before the first rate are dropped. The open,
+// high, low, close, VWAP and bid/ask closes are converted, the volume is
+// unchanged. The converted prices are new objects.
+//
+// Parameters:
+// - prices: the prices to convert, in any order
+// - rates: the FX rates, in any order
+// - inverse: true to divide the prices by the rates instead of multiplying them
+// - decimals: the number of decimals of the converted prices
+//
+// Returns:
+// - []PriceInterface: the converted prices, in the order of the prices
+// - error: if a price or rate has no time
+func PriceConvert(prices []PriceInterface, rates []PriceInterface, inverse bool, decimals int32) ([]PriceInterface, error) {
+	validRates := []PriceInterface{}
+
+	for _, rate := range rates {
+		if rate == nil || rate.Time() == "" {
+			return nil, errors.New("price convert: rates must include the time column")
+		}
+
+		// a zero rate can not convert a price
+		if rate.CloseDecimal().IsZero() {
+			continue
+		}
+
+		validRates = append(validRates, rate)
+	}
+
+	sort.SliceStable(validRates, func(i, j int) bool {
+		return validRates[i].TimeCarbon().Lt(validRates[j].TimeCarbon())
+	})
+
+	converted := make([]PriceInterface, 0, len(prices))
+
+	for _, price := range prices {
+		if price == nil || price.Time() == "" {
+			return nil, errors.New("price convert: prices must include the time column")
+		}
+
+		priceTime := price.TimeCarbon()
+
+		// index of the first rate after the price
+		index := sort.Search(len(validRates), func(i int) bool {
+			return validRates[i].TimeCarbon().Gt(priceTime)
+		})
+
+		if index == 0 {
+			continue
+		}
+
+		rate := validRates[index-1].CloseDecimal()
+
+		data := lo.Assign(price.Data())
+
+		for _, column := range currencyConversionColumns {
+			value, ok := data[column]
+
+			if !ok || value == "" {
+				continue
+			}
+
+			decimal, err := NewDecimalFromString(value)
+
+			if err != nil {
+				return nil, errors.New("price convert: invalid " + column + ": " + value)
+			}
+
+			if inverse {
+				data[column] = decimal.Div(rate, decimals).String()
+			} else {
+				data[column] = decimal.Mul(rate).Round(decimals).String()
+			}
+		}
+
+		converted = append(converted, NewPriceFromExistingData(data))
+	}
+
+	return converted, nil
+}
+
+// currencyConversionPath returns the FX pairs converting from one currency
+// into another, a direct pair if there is one, otherwise two pairs through
+// the pivot currency
+func currencyConversionPath(pairs []InstrumentInterface, from string, to string) ([]currencyConversionLeg, error) {
+	if leg, found := currencyConversionPair(pairs, from, to); found {
+		return []currencyConversionLeg{leg}, nil
+	}
+
+	if !strings.EqualFold(from, currencyConversionPivot) && !strings.EqualFold(to, currencyConversionPivot) {
+		first, foundFirst := currencyConversionPair(pairs, from, currencyConversionPivot)
+		second, foundSecond := currencyConversionPair(pairs, currencyConversionPivot, to)
+
+		if foundFirst && foundSecond {
+			return []currencyConversionLeg{first, second}, nil
+		}
+	}
+
+	return nil, errors.New("currency conversion: no FX pair converts " + from + " into " + to)
+}
+
+// currencyConversionPair returns the FX pair converting from one currency
+// into another, quoted either way
+func currencyConversionPair(pairs []InstrumentInterface, from string, to string) (currencyConversionLeg, bool) {
+	for _, pair := range pairs {
+		if strings.EqualFold(pair.BaseAsset(), from) && strings.EqualFold(pair.QuoteCurrency(), to) {
+			return currencyConversionLeg{pair: pair}, true
+		}
+	}
+
+	for _, pair := range pairs {
+		if strings.EqualFold(pair.BaseAsset(), to) && strings.EqualFold(pair.QuoteCurrency(), from) {
+			return currencyConversionLeg{pair: pair, inverse: true}, true
+		}
+	}
+
+	return currencyConversionLeg{}, false
+}
@@ -0,0 +1,76 @@
+package tradingstore
+
+import (
+	"testing"
+)
+
+func TestPriceConvert(t *testing.T) {
+	prices := []PriceInterface{
+		NewPrice().SetTime("2024-01-01 12:00:00").SetOpen("100").SetHigh("110").SetLow("90").SetClose("105").SetVolume("7"),
+		NewPrice().SetTime("2024-01-02 12:00:00").SetOpen("105").SetHigh("105").SetLow("105").SetClose("105").SetVolume("7"),
+		NewPrice().SetTime("2024-01-03 12:00:00").SetOpen("100").SetHigh("100").SetLow("100").SetClose("100").SetVolume("7"),
+	}
+
+	rates := []PriceInterface{
+		NewPrice().SetTime("2024-01-03 00:00:00").SetClose("1.5"),
+		NewPrice().SetTime("2024-01-02 00:00:00").SetClose("2"),
+	}
+
+	converted, err := PriceConvert(prices, rates, false, 2)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(converted) != 2 {
+		t.Fatal("Prices before the first rate MUST be dropped, got", len(converted))
+	}
+
+	if converted[0].Close() != "210.00" || converted[1].Close() != "150.00" || converted[1].Volume() != "7" {
+		t.Fatal("Unexpected converted prices:", converted[0].Data(), converted[1].Data())
+	}
+
+	if prices[1].Close() != "105" {
+		t.Fatal("The given prices MUST NOT be modified")
+	}
+
+	inverse, err := PriceConvert(prices[2:], rates, true, 4)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if inverse[0].Close() != "66.6667" {
+		t.Fatal("Expected 100 / 1.5 = 66.6667, got", inverse[0].Close())
+	}
+}
+
+func TestCurrencyConversionPath(t *testing.T) {
+	eurusd := NewInstrument().SetSymbol("EURUSD").SetBaseAsset("EUR").SetQuoteCurrency("USD")
+	usdjpy := NewInstrument().SetSymbol("USDJPY").SetBaseAsset("USD").SetQuoteCurrency("JPY")
+	pairs := []InstrumentInterface{eurusd, usdjpy}
+
+	path, err := currencyConversionPath(pairs, "USD", "EUR")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(path) != 1 || path[0].pair.Symbol() != "EURUSD" || !path[0].inverse {
+		t.Fatal("Expected the inverse EURUSD pair")
+	}
+
+	path, err = currencyConversionPath(pairs, "EUR", "JPY")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(path) != 2 || path[0].inverse || path[1].pair.Symbol() != "USDJPY" || path[1].inverse {
+		t.Fatal("Expected EURUSD then USDJPY")
+	}
+
+	if _, err := currencyConversionPath(pairs, "EUR", "GBP"); err == nil {
+		t.Fatal("A conversion without FX pairs MUST fail")
+	}
+}
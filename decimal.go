@@ -0,0 +1,270 @@
+package tradingstore
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// == CLASS ====================================================================
+
+// Decimal is an exact decimal number, used where float64 would lose precision,
+// i.e. when summing volumes or reconciling P&L with a broker.
+//
+// A Decimal is the unscaled value times 10^-scale, so "20.50" is 2050 with
+// a scale of 2. Decimals are immutable, the arithmetic methods return a new
+// Decimal. The zero value is 0.
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+// decimalMaxExponent bounds the exponent of the parsed strings, as the
+// digits of i.e. "1e999999999" would not fit in memory
+const decimalMaxExponent = 1000
+
+// == CONSTRUCTORS =============================================================
+
+// NewDecimal returns the decimal value times 10^-scale,
+// i.e. NewDecimal(2050, 2) is 20.50
+func NewDecimal(value int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{value: new(big.Int).Mul(big.NewInt(value), decimalPow10(-scale))}
+	}
+
+	return Decimal{value: big.NewInt(value), scale: scale}
+}
+
+// NewDecimalFromFloat returns the decimal with the shortest representation
+// of the float, i.e. 0.1 is 0.1 and not 0.1000000000000000055511151231257827
+func NewDecimalFromFloat(value float64) Decimal {
+	decimal, err := NewDecimalFromString(strconv.FormatFloat(value, 'f', -1, 64))
+
+	if err != nil {
+		// only NaN and infinities can not be formatted as a decimal
+		return Decimal{}
+	}
+
+	return decimal
+}
+
+// NewDecimalFromString parses a decimal string, i.e. "20.50", "-0.001" or "1.5e-7".
+// The scale of the decimal is the number of decimals of the string, so
+// the string is returned unchanged by String()
+func NewDecimalFromString(value string) (Decimal, error) {
+	str := strings.TrimSpace(value)
+
+	if str == "" {
+		return Decimal{}, errors.New("decimal: empty string")
+	}
+
+	exponent := int64(0)
+
+	if index := strings.IndexAny(str, "eE"); index >= 0 {
+		parsed, err := strconv.ParseInt(str[index+1:], 10, 32)
+
+		if err != nil {
+			return Decimal{}, errors.New("decimal: invalid exponent in " + value)
+		}
+
+		if parsed > decimalMaxExponent || parsed < -decimalMaxExponent {
+			return Decimal{}, errors.New("decimal: exponent out of range in " + value)
+		}
+
+		exponent = parsed
+		str = str[:index]
+	}
+
+	integerPart, fractionalPart, _ := strings.Cut(str, ".")
+
+	digits := integerPart + fractionalPart
+
+	if strings.TrimLeft(digits, "+-") == "" || strings.ContainsAny(strings.TrimLeft(digits, "+-"), "+-") {
+		return Decimal{}, errors.New("decimal: invalid number " + value)
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+
+	if !ok {
+		return Decimal{}, errors.New("decimal: invalid number " + value)
+	}
+
+	scale := int64(len(fractionalPart)) - exponent
+
+	if scale < 0 {
+		return Decimal{value: unscaled.Mul(unscaled, decimalPow10(int32(-scale)))}, nil
+	}
+
+	return Decimal{value: unscaled, scale: int32(scale)}, nil
+}
+
+// == METHODS ==================================================================
+
+// Abs returns the absolute value
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: new(big.Int).Abs(d.unscaled()), scale: d.scale}
+}
+
+// Add returns d + other
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := decimalAlign(d, other)
+	return Decimal{value: a.Add(a, b), scale: scale}
+}
+
+// Cmp compares the decimals, returning -1 if d < other, 0 if d == other
+// and +1 if d > other
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := decimalAlign(d, other)
+	return a.Cmp(b)
+}
+
+// Div returns d / other rounded half away from zero to the given number of
+// decimals. As with big.Int, dividing by zero panics
+func (d Decimal) Div(other Decimal, decimals int32) Decimal {
+	if other.IsZero() {
+		panic("decimal: division by zero")
+	}
+
+	decimals = max(decimals, 0)
+
+	// d / other = (dv / 10^ds) / (ov / 10^os), scaled by 10^decimals
+	numerator := new(big.Int).Mul(d.unscaled(), decimalPow10(other.scale+decimals+1))
+	denominator := new(big.Int).Mul(other.unscaled(), decimalPow10(d.scale))
+
+	// one extra digit is computed for the rounding
+	quotient := new(big.Int).Quo(numerator, denominator)
+
+	return Decimal{value: quotient, scale: decimals + 1}.Round(decimals)
+}
+
+// Equal returns true if the decimals have the same value, regardless of scale
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// Float64 returns the nearest float64 value
+func (d Decimal) Float64() float64 {
+	value, _ := strconv.ParseFloat(d.String(), 64)
+	return value
+}
+
+// IsZero returns true if the decimal is 0
+func (d Decimal) IsZero() bool {
+	return d.unscaled().Sign() == 0
+}
+
+// Max returns the largest of d and other
+func (d Decimal) Max(other Decimal) Decimal {
+	if other.Cmp(d) > 0 {
+		return other
+	}
+	return d
+}
+
+// Min returns the smallest of d and other
+func (d Decimal) Min(other Decimal) Decimal {
+	if other.Cmp(d) < 0 {
+		return other
+	}
+	return d
+}
+
+// Mul returns d * other, with the sum of the scales of d and other
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{value: new(big.Int).Mul(d.unscaled(), other.unscaled()), scale: d.scale + other.scale}
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.unscaled()), scale: d.scale}
+}
+
+// Round returns the decimal rounded half away from zero to the given number
+// of decimals. A decimal with fewer decimals is padded with zeros
+func (d Decimal) Round(decimals int32) Decimal {
+	decimals = max(decimals, 0)
+
+	if decimals >= d.scale {
+		return Decimal{value: new(big.Int).Mul(d.unscaled(), decimalPow10(decimals-d.scale)), scale: decimals}
+	}
+
+	divisor := decimalPow10(d.scale - decimals)
+	quotient, remainder := new(big.Int).QuoRem(d.unscaled(), divisor, new(big.Int))
+
+	// round half away from zero
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(d.unscaled().Sign())))
+	}
+
+	return Decimal{value: quotient, scale: decimals}
+}
+
+// Scale returns the number of decimals
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0 and +1 if d > 0
+func (d Decimal) Sign() int {
+	return d.unscaled().Sign()
+}
+
+// String returns the decimal with its scale, i.e. "20.50"
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.unscaled()).String()
+
+	if d.scale > 0 {
+		if len(digits) <= int(d.scale) {
+			digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+		}
+
+		point := len(digits) - int(d.scale)
+		digits = digits[:point] + "." + digits[point:]
+	}
+
+	if d.unscaled().Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+// StringFixed returns the decimal rounded to the given number of decimals,
+// i.e. StringFixed(2) of 20.5 is "20.50"
+func (d Decimal) StringFixed(decimals int32) string {
+	return d.Round(decimals).String()
+}
+
+// Sub returns d - other
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := decimalAlign(d, other)
+	return Decimal{value: a.Sub(a, b), scale: scale}
+}
+
+// unscaled returns the unscaled value, 0 for the zero Decimal
+func (d Decimal) unscaled() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+// == PRIVATE FUNCTIONS ========================================================
+
+// decimalAlign returns copies of the unscaled values of both decimals
+// with the largest of their scales
+func decimalAlign(a Decimal, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := max(a.scale, b.scale)
+
+	aligned := func(d Decimal) *big.Int {
+		return new(big.Int).Mul(d.unscaled(), decimalPow10(scale-d.scale))
+	}
+
+	return aligned(a), aligned(b), scale
+}
+
+// decimalPow10 returns 10^exponent
+func decimalPow10(exponent int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
@@ -0,0 +1,108 @@
+package tradingstore
+
+import (
+	"testing"
+)
+
+func mustDecimal(t *testing.T, value string) Decimal {
+	t.Helper()
+	decimal, err := NewDecimalFromString(value)
+	if err != nil {
+		t.Fatalf("NewDecimalFromString(%q) returned an unexpected error: %v", value, err)
+	}
+	return decimal
+}
+
+func TestDecimalStringRoundTrip(t *testing.T) {
+	values := []string{"0", "20.50", "-0.001", "123456789012345678901234567890.123456789", "0.00000001"}
+
+	for _, value := range values {
+		if got := mustDecimal(t, value).String(); got != value {
+			t.Errorf("String() of %q: expected %q, got %q", value, value, got)
+		}
+	}
+
+	if got := mustDecimal(t, "1.5e-7").String(); got != "0.00000015" {
+		t.Errorf("String() of 1.5e-7: expected %q, got %q", "0.00000015", got)
+	}
+
+	if got := mustDecimal(t, "2e3").String(); got != "2000" {
+		t.Errorf("String() of 2e3: expected %q, got %q", "2000", got)
+	}
+
+	if got := (Decimal{}).String(); got != "0" {
+		t.Errorf("String() of the zero Decimal: expected %q, got %q", "0", got)
+	}
+
+	for _, invalid := range []string{"", "abc", "1.2.3", "--1", "-", "1e", "1e999999999", "1e-999999999"} {
+		if _, err := NewDecimalFromString(invalid); err == nil {
+			t.Errorf("NewDecimalFromString(%q) should return an error", invalid)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	sum := mustDecimal(t, "0.1").Add(mustDecimal(t, "0.2"))
+	if got := sum.String(); got != "0.3" {
+		t.Errorf("0.1 + 0.2: expected %q, got %q", "0.3", got)
+	}
+
+	if got := mustDecimal(t, "10").Sub(mustDecimal(t, "0.25")).String(); got != "9.75" {
+		t.Errorf("10 - 0.25: expected %q, got %q", "9.75", got)
+	}
+
+	if got := mustDecimal(t, "1.5").Mul(mustDecimal(t, "-0.2")).String(); got != "-0.30" {
+		t.Errorf("1.5 * -0.2: expected %q, got %q", "-0.30", got)
+	}
+
+	if got := mustDecimal(t, "2").Div(mustDecimal(t, "3"), 4).String(); got != "0.6667" {
+		t.Errorf("2 / 3: expected %q, got %q", "0.6667", got)
+	}
+
+	if got := mustDecimal(t, "-1").Div(mustDecimal(t, "8"), 2).String(); got != "-0.13" {
+		t.Errorf("-1 / 8: expected %q, got %q", "-0.13", got)
+	}
+
+	if got := mustDecimal(t, "2.345").Round(2).String(); got != "2.35" {
+		t.Errorf("Round(2) of 2.345: expected %q, got %q", "2.35", got)
+	}
+
+	if got := mustDecimal(t, "2.5").StringFixed(3); got != "2.500" {
+		t.Errorf("StringFixed(3) of 2.5: expected %q, got %q", "2.500", got)
+	}
+
+	if !mustDecimal(t, "1.50").Equal(mustDecimal(t, "1.5")) {
+		t.Error("1.50 should equal 1.5")
+	}
+
+	if mustDecimal(t, "1.5").Max(mustDecimal(t, "1.49")).String() != "1.5" || mustDecimal(t, "1.5").Min(mustDecimal(t, "1.49")).String() != "1.49" {
+		t.Error("Max/Min returned the wrong decimal")
+	}
+
+	if got := NewDecimal(2050, 2).String(); got != "20.50" {
+		t.Errorf("NewDecimal(2050, 2): expected %q, got %q", "20.50", got)
+	}
+
+	if got := NewDecimalFromFloat(0.1).String(); got != "0.1" {
+		t.Errorf("NewDecimalFromFloat(0.1): expected %q, got %q", "0.1", got)
+	}
+}
+
+func TestPriceDecimalAccessors(t *testing.T) {
+	price := NewPrice().
+		SetOpen("1.10").
+		SetVolume("not a number").
+		SetCloseDecimal(mustDecimal(t, "1.123456789012345678"))
+
+	if got := price.Close(); got != "1.123456789012345678" {
+		t.Errorf("SetCloseDecimal MUST keep every decimal, got %q", got)
+	}
+
+	if got := price.OpenDecimal().String(); got != "1.10" {
+		t.Errorf("OpenDecimal: expected %q, got %q", "1.10", got)
+	}
+
+	if !price.VolumeDecimal().IsZero() {
+		t.Errorf("VolumeDecimal of an invalid volume MUST be 0, got %q", price.VolumeDecimal().String())
+	}
+}
@@ -0,0 +1,458 @@
+package tradingstore
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/dracory/dataobject"
+	"github.com/dracory/uid"
+	"github.com/dromara/carbon/v2"
+)
+
+// == TYPES ====================================================================
+
+// TradingSession defines a regular trading session of an exchange
+// for one day of the week, in the exchange local time
+type TradingSession struct {
+	// Weekday is the day of the week the session opens on
+	Weekday time.Weekday `json:"weekday"`
+
+	// Open is the local opening time, formatted as HH:MM
+	Open string `json:"open"`
+
+	// Close is the local closing time, formatted as HH:MM
+	// a close at or before the open means the session ends on the next day
+	Close string `json:"close"`
+}
+
+// SessionWindow is a trading session resolved to absolute times
+type SessionWindow struct {
+	Open  time.Time
+	Close time.Time
+}
+
+// Contains returns true if the time falls within the session window
+func (window SessionWindow) Contains(t time.Time) bool {
+	return !t.Before(window.Open) && t.Before(window.Close)
+}
+
+// == CLASS ====================================================================
+
+// exchangeCalendarImplementation represents the trading calendar of an exchange
+type exchangeCalendarImplementation struct {
+	dataobject.DataObject
+}
+
+// == CONSTRUCTORS =============================================================
+
+func NewExchangeCalendar() ExchangeCalendarInterface {
+	o := (&exchangeCalendarImplementation{}).
+		SetID(uid.HumanUid())
+
+	// Default values
+	o.SetExchange("")
+	o.SetName("")
+	o.SetTimezone("UTC")
+	o.SetDayAnchor("")
+	o.SetMemo("")
+	_ = o.SetSessions([]TradingSession{})
+	_ = o.SetHolidays([]string{})
+	_ = o.SetHalfDays(map[string]string{})
+	o.SetCreatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+	o.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+
+	return o
+}
+
+func NewExchangeCalendarFromExistingData(data map[string]string) ExchangeCalendarInterface {
+	o := &exchangeCalendarImplementation{}
+	o.Hydrate(data)
+	return o
+}
+
+var _ ExchangeCalendarInterface = (*exchangeCalendarImplementation)(nil)
+
+// == METHODS ==================================================================
+
+// Location returns the time zone of the exchange
+func (calendar *exchangeCalendarImplementation) Location() (*time.Location, error) {
+	return time.LoadLocation(calendar.Timezone())
+}
+
+// BucketOptions returns the options to align daily and larger bars to the
+// exchange time zone and trading day. The day anchor is the configured
+// DayAnchor, or the earliest session open if none is configured.
+func (calendar *exchangeCalendarImplementation) BucketOptions() (TimeframeBucketOptions, error) {
+	location, err := calendar.Location()
+
+	if err != nil {
+		return TimeframeBucketOptions{}, err
+	}
+
+	anchor := calendar.DayAnchor()
+
+	if anchor == "" {
+		sessions, err := calendar.Sessions()
+
+		if err != nil {
+			return TimeframeBucketOptions{}, err
+		}
+
+		for _, session := range sessions {
+			if anchor == "" || session.Open < anchor {
+				anchor = session.Open
+			}
+		}
+	}
+
+	return TimeframeBucketOptions{
+		Location:  location,
+		DayAnchor: anchor,
+	}, nil
+}
+
+// IsHoliday returns true if the exchange is closed for the whole local date of the given time
+func (calendar *exchangeCalendarImplementation) IsHoliday(t time.Time) (bool, error) {
+	location, err := calendar.Location()
+
+	if err != nil {
+		return false, err
+	}
+
+	holidays, err := calendar.Holidays()
+
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(holidays, t.In(location).Format(time.DateOnly)), nil
+}
+
+// IsOpen returns true if the exchange is in a trading session at the given time
+func (calendar *exchangeCalendarImplementation) IsOpen(t time.Time) (bool, error) {
+	_, found, err := calendar.SessionAt(t)
+	return found, err
+}
+
+// SessionAt returns the trading session the given time falls in.
+// Overnight sessions opened on the previous local date are taken into account.
+func (calendar *exchangeCalendarImplementation) SessionAt(t time.Time) (SessionWindow, bool, error) {
+	location, err := calendar.Location()
+
+	if err != nil {
+		return SessionWindow{}, false, err
+	}
+
+	local := t.In(location)
+
+	for _, date := range []time.Time{local.AddDate(0, 0, -1), local} {
+		windows, err := calendar.SessionsOn(date)
+
+		if err != nil {
+			return SessionWindow{}, false, err
+		}
+
+		for _, window := range windows {
+			if window.Contains(t) {
+				return window, true, nil
+			}
+		}
+	}
+
+	return SessionWindow{}, false, nil
+}
+
+// SessionsOn returns the trading sessions which open on the local date of the given time,
+// taking holidays and half days into account
+func (calendar *exchangeCalendarImplementation) SessionsOn(date time.Time) ([]SessionWindow, error) {
+	location, err := calendar.Location()
+
+	if err != nil {
+		return nil, err
+	}
+
+	local := date.In(location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+
+	isHoliday, err := calendar.IsHoliday(day)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if isHoliday {
+		return []SessionWindow{}, nil
+	}
+
+	sessions, err := calendar.Sessions()
+
+	if err != nil {
+		return nil, err
+	}
+
+	halfDays, err := calendar.HalfDays()
+
+	if err != nil {
+		return nil, err
+	}
+
+	earlyClose, isHalfDay := halfDays[day.Format(time.DateOnly)]
+
+	windows := []SessionWindow{}
+
+	for _, session := range sessions {
+		if session.Weekday != day.Weekday() {
+			continue
+		}
+
+		open, err := exchangeCalendarClockTime(day, session.Open)
+
+		if err != nil {
+			return nil, err
+		}
+
+		close, err := exchangeCalendarClockTime(day, session.Close)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !close.After(open) {
+			close = exchangeCalendarNextDay(close)
+		}
+
+		if isHalfDay {
+			halfDayClose, err := exchangeCalendarClockTime(day, earlyClose)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if !halfDayClose.After(open) {
+				continue // session starts after the early close
+			}
+
+			if halfDayClose.Before(close) {
+				close = halfDayClose
+			}
+		}
+
+		windows = append(windows, SessionWindow{Open: open, Close: close})
+	}
+
+	slices.SortFunc(windows, func(a, b SessionWindow) int {
+		return a.Open.Compare(b.Open)
+	})
+
+	return windows, nil
+}
+
+// == SETTERS & GETTERS ========================================================
+
+func (calendar *exchangeCalendarImplementation) ID() string {
+	return calendar.Get(COLUMN_ID)
+}
+
+func (calendar *exchangeCalendarImplementation) SetID(id string) ExchangeCalendarInterface {
+	calendar.Set(COLUMN_ID, id)
+	return calendar
+}
+
+// DayAnchor returns the local time of day (HH:MM) the trading day starts at,
+// used to align daily bars. Empty means the earliest session open.
+func (calendar *exchangeCalendarImplementation) DayAnchor() string {
+	return calendar.Get(COLUMN_DAY_ANCHOR)
+}
+
+func (calendar *exchangeCalendarImplementation) SetDayAnchor(dayAnchor string) ExchangeCalendarInterface {
+	calendar.Set(COLUMN_DAY_ANCHOR, dayAnchor)
+	return calendar
+}
+
+// Exchange returns the exchange code the calendar applies to, i.e. NYSE
+func (calendar *exchangeCalendarImplementation) Exchange() string {
+	return calendar.Get(COLUMN_EXCHANGE)
+}
+
+func (calendar *exchangeCalendarImplementation) SetExchange(exchange string) ExchangeCalendarInterface {
+	calendar.Set(COLUMN_EXCHANGE, exchange)
+	return calendar
+}
+
+// HalfDays returns the early closing times (HH:MM) keyed by local date (YYYY-MM-DD)
+func (calendar *exchangeCalendarImplementation) HalfDays() (map[string]string, error) {
+	halfDaysStr := calendar.Get(COLUMN_HALF_DAYS)
+	if halfDaysStr == "" {
+		return map[string]string{}, nil
+	}
+
+	var halfDays map[string]string
+	err := json.Unmarshal([]byte(halfDaysStr), &halfDays)
+	if err != nil {
+		return map[string]string{}, err
+	}
+
+	return halfDays, nil
+}
+
+func (calendar *exchangeCalendarImplementation) SetHalfDays(halfDays map[string]string) error {
+	for date, close := range halfDays {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return errors.New("exchange calendar: half day must be formatted as YYYY-MM-DD: " + date)
+		}
+
+		if _, err := time.Parse("15:04", close); err != nil {
+			return errors.New("exchange calendar: half day close must be formatted as HH:MM: " + close)
+		}
+	}
+
+	halfDaysBytes, err := json.Marshal(halfDays)
+	if err != nil {
+		return err
+	}
+
+	calendar.Set(COLUMN_HALF_DAYS, string(halfDaysBytes))
+	return nil
+}
+
+// Holidays returns the local dates (YYYY-MM-DD) the exchange is closed on
+func (calendar *exchangeCalendarImplementation) Holidays() ([]string, error) {
+	holidaysStr := calendar.Get(COLUMN_HOLIDAYS)
+	if holidaysStr == "" {
+		return []string{}, nil
+	}
+
+	var holidays []string
+	err := json.Unmarshal([]byte(holidaysStr), &holidays)
+	if err != nil {
+		return []string{}, err
+	}
+
+	return holidays, nil
+}
+
+func (calendar *exchangeCalendarImplementation) SetHolidays(holidays []string) error {
+	for _, holiday := range holidays {
+		if _, err := time.Parse(time.DateOnly, holiday); err != nil {
+			return errors.New("exchange calendar: holiday must be formatted as YYYY-MM-DD: " + holiday)
+		}
+	}
+
+	holidaysBytes, err := json.Marshal(holidays)
+	if err != nil {
+		return err
+	}
+
+	calendar.Set(COLUMN_HOLIDAYS, string(holidaysBytes))
+	return nil
+}
+
+func (calendar *exchangeCalendarImplementation) Memo() string {
+	return calendar.Get(COLUMN_MEMO)
+}
+
+func (calendar *exchangeCalendarImplementation) SetMemo(memo string) ExchangeCalendarInterface {
+	calendar.Set(COLUMN_MEMO, memo)
+	return calendar
+}
+
+func (calendar *exchangeCalendarImplementation) Name() string {
+	return calendar.Get(COLUMN_NAME)
+}
+
+func (calendar *exchangeCalendarImplementation) SetName(name string) ExchangeCalendarInterface {
+	calendar.Set(COLUMN_NAME, name)
+	return calendar
+}
+
+// Sessions returns the regular weekly trading sessions
+func (calendar *exchangeCalendarImplementation) Sessions() ([]TradingSession, error) {
+	sessionsStr := calendar.Get(COLUMN_SESSIONS)
+	if sessionsStr == "" {
+		return []TradingSession{}, nil
+	}
+
+	var sessions []TradingSession
+	err := json.Unmarshal([]byte(sessionsStr), &sessions)
+	if err != nil {
+		return []TradingSession{}, err
+	}
+
+	return sessions, nil
+}
+
+func (calendar *exchangeCalendarImplementation) SetSessions(sessions []TradingSession) error {
+	for _, session := range sessions {
+		if _, err := time.Parse("15:04", session.Open); err != nil {
+			return errors.New("exchange calendar: session open must be formatted as HH:MM: " + session.Open)
+		}
+
+		if _, err := time.Parse("15:04", session.Close); err != nil {
+			return errors.New("exchange calendar: session close must be formatted as HH:MM: " + session.Close)
+		}
+	}
+
+	sessionsBytes, err := json.Marshal(sessions)
+	if err != nil {
+		return err
+	}
+
+	calendar.Set(COLUMN_SESSIONS, string(sessionsBytes))
+	return nil
+}
+
+// Timezone returns the IANA time zone name of the exchange, i.e. America/New_York
+func (calendar *exchangeCalendarImplementation) Timezone() string {
+	return calendar.Get(COLUMN_TIMEZONE)
+}
+
+func (calendar *exchangeCalendarImplementation) SetTimezone(timezone string) ExchangeCalendarInterface {
+	calendar.Set(COLUMN_TIMEZONE, timezone)
+	return calendar
+}
+
+func (calendar *exchangeCalendarImplementation) CreatedAt() string {
+	return calendar.Get(COLUMN_CREATED_AT)
+}
+
+func (calendar *exchangeCalendarImplementation) CreatedAtCarbon() *carbon.Carbon {
+	return carbon.Parse(calendar.CreatedAt(), carbon.UTC)
+}
+
+func (calendar *exchangeCalendarImplementation) SetCreatedAt(createdAt string) ExchangeCalendarInterface {
+	calendar.Set(COLUMN_CREATED_AT, createdAt)
+	return calendar
+}
+
+func (calendar *exchangeCalendarImplementation) UpdatedAt() string {
+	return calendar.Get(COLUMN_UPDATED_AT)
+}
+
+func (calendar *exchangeCalendarImplementation) UpdatedAtCarbon() *carbon.Carbon {
+	return carbon.Parse(calendar.UpdatedAt(), carbon.UTC)
+}
+
+func (calendar *exchangeCalendarImplementation) SetUpdatedAt(updatedAt string) ExchangeCalendarInterface {
+	calendar.Set(COLUMN_UPDATED_AT, updatedAt)
+	return calendar
+}
+
+// == PRIVATE FUNCTIONS ========================================================
+
+// exchangeCalendarClockTime returns the given local day at the HH:MM clock time
+func exchangeCalendarClockTime(day time.Time, clock string) (time.Time, error) {
+	parsed, err := time.Parse("15:04", clock)
+
+	if err != nil {
+		return time.Time{}, errors.New("exchange calendar: time must be formatted as HH:MM: " + clock)
+	}
+
+	return time.Date(day.Year(), day.Month(), day.Day(), parsed.Hour(), parsed.Minute(), 0, 0, day.Location()), nil
+}
+
+// exchangeCalendarNextDay returns the same clock time on the next local day
+func exchangeCalendarNextDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+1, t.Hour(), t.Minute(), 0, 0, t.Location())
+}
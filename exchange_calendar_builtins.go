@@ -0,0 +1,105 @@
+package tradingstore
+
+import (
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dracory/uid"
+	"github.com/samber/lo"
+)
+
+// builtinExchangeCalendarsData holds the data of the built-in calendars,
+// which are built once. The calendars are returned as copies, as they can be
+// changed by the caller.
+var builtinExchangeCalendarsData = sync.OnceValue(func() []map[string]string {
+	calendars := []ExchangeCalendarInterface{
+		builtinExchangeCalendar(EXCHANGE_NYSE, "New York Stock Exchange", "America/New_York", exchangeCalendarWeekdays([][2]string{{"09:30", "16:00"}})),
+		builtinExchangeCalendar(EXCHANGE_NASDAQ, "Nasdaq Stock Market", "America/New_York", exchangeCalendarWeekdays([][2]string{{"09:30", "16:00"}})),
+		builtinExchangeCalendar(EXCHANGE_LSE, "London Stock Exchange", "Europe/London", exchangeCalendarWeekdays([][2]string{{"08:00", "16:30"}})),
+		builtinExchangeCalendar(EXCHANGE_TSE, "Tokyo Stock Exchange", "Asia/Tokyo", exchangeCalendarWeekdays([][2]string{{"09:00", "11:30"}, {"12:30", "15:30"}})),
+		builtinExchangeCalendar(EXCHANGE_ASX, "Australian Securities Exchange", "Australia/Sydney", exchangeCalendarWeekdays([][2]string{{"10:00", "16:00"}})),
+		builtinExchangeCalendar(EXCHANGE_CRYPTO, "Cryptocurrency (24/7)", "UTC", exchangeCalendarDays([]time.Weekday{
+			time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+		}, "00:00", "00:00")),
+		builtinExchangeCalendar(EXCHANGE_FOREX, "Foreign Exchange (24/5)", "America/New_York", exchangeCalendarDays([]time.Weekday{
+			time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+		}, "17:00", "17:00")),
+	}
+
+	return lo.Map(calendars, func(calendar ExchangeCalendarInterface, _ int) map[string]string {
+		return calendar.Data()
+	})
+})
+
+// BuiltinExchangeCalendars returns the built-in calendars of a few major
+// venues, plus the 24/7 crypto and the 24/5 forex calendars.
+//
+// The built-in calendars define the regular sessions and time zones only,
+// holidays and half days should be added before persisting them.
+func BuiltinExchangeCalendars() []ExchangeCalendarInterface {
+	return lo.Map(builtinExchangeCalendarsData(), func(data map[string]string, _ int) ExchangeCalendarInterface {
+		return builtinExchangeCalendarCopy(data)
+	})
+}
+
+// BuiltinExchangeCalendar returns the built-in calendar for an exchange,
+// or nil if there is no built-in calendar for it
+func BuiltinExchangeCalendar(exchange string) ExchangeCalendarInterface {
+	for _, data := range builtinExchangeCalendarsData() {
+		if strings.EqualFold(data[COLUMN_EXCHANGE], exchange) {
+			return builtinExchangeCalendarCopy(data)
+		}
+	}
+
+	return nil
+}
+
+// builtinExchangeCalendarCopy returns a new calendar with the data of a
+// built-in calendar, with its own ID so each copy can be persisted
+func builtinExchangeCalendarCopy(data map[string]string) ExchangeCalendarInterface {
+	return NewExchangeCalendarFromExistingData(maps.Clone(data)).
+		SetID(uid.HumanUid())
+}
+
+// builtinExchangeCalendar creates a built-in calendar
+func builtinExchangeCalendar(exchange string, name string, timezone string, sessions []TradingSession) ExchangeCalendarInterface {
+	calendar := NewExchangeCalendar().
+		SetExchange(exchange).
+		SetName(name).
+		SetTimezone(timezone)
+
+	// the built-in sessions are well formed
+	_ = calendar.SetSessions(sessions)
+
+	return calendar
+}
+
+// exchangeCalendarWeekdays returns the sessions repeated Monday to Friday
+func exchangeCalendarWeekdays(sessions [][2]string) []TradingSession {
+	result := []TradingSession{}
+
+	for _, session := range sessions {
+		result = append(result, exchangeCalendarDays([]time.Weekday{
+			time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+		}, session[0], session[1])...)
+	}
+
+	return result
+}
+
+// exchangeCalendarDays returns one session per given weekday
+func exchangeCalendarDays(weekdays []time.Weekday, open string, close string) []TradingSession {
+	sessions := []TradingSession{}
+
+	for _, weekday := range weekdays {
+		sessions = append(sessions, TradingSession{
+			Weekday: weekday,
+			Open:    open,
+			Close:   close,
+		})
+	}
+
+	return sessions
+}
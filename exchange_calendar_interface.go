@@ -0,0 +1,60 @@
+package tradingstore
+
+import (
+	"time"
+
+	"github.com/dromara/carbon/v2"
+)
+
+type ExchangeCalendarInterface interface {
+	// from dataobject
+	Data() map[string]string
+	DataChanged() map[string]string
+	MarkAsNotDirty()
+
+	// methods
+
+	BucketOptions() (TimeframeBucketOptions, error)
+	Location() (*time.Location, error)
+	IsHoliday(t time.Time) (bool, error)
+	IsOpen(t time.Time) (bool, error)
+	SessionAt(t time.Time) (SessionWindow, bool, error)
+	SessionsOn(date time.Time) ([]SessionWindow, error)
+
+	// setters and getters
+
+	ID() string
+	SetID(id string) ExchangeCalendarInterface
+
+	DayAnchor() string
+	SetDayAnchor(dayAnchor string) ExchangeCalendarInterface
+
+	Exchange() string
+	SetExchange(exchange string) ExchangeCalendarInterface
+
+	HalfDays() (map[string]string, error)
+	SetHalfDays(halfDays map[string]string) error
+
+	Holidays() ([]string, error)
+	SetHolidays(holidays []string) error
+
+	Memo() string
+	SetMemo(memo string) ExchangeCalendarInterface
+
+	Name() string
+	SetName(name string) ExchangeCalendarInterface
+
+	Sessions() ([]TradingSession, error)
+	SetSessions(sessions []TradingSession) error
+
+	Timezone() string
+	SetTimezone(timezone string) ExchangeCalendarInterface
+
+	CreatedAt() string
+	CreatedAtCarbon() *carbon.Carbon
+	SetCreatedAt(createdAt string) ExchangeCalendarInterface
+
+	UpdatedAt() string
+	UpdatedAtCarbon() *carbon.Carbon
+	SetUpdatedAt(updatedAt string) ExchangeCalendarInterface
+}
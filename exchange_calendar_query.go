@@ -0,0 +1,198 @@
+package tradingstore
+
+import "errors"
+
+// ExchangeCalendarQuery is a shortcut for NewExchangeCalendarQuery
+func ExchangeCalendarQuery() ExchangeCalendarQueryInterface {
+	return NewExchangeCalendarQuery()
+}
+
+// NewExchangeCalendarQuery creates a new exchange calendar query
+func NewExchangeCalendarQuery() ExchangeCalendarQueryInterface {
+	return &exchangeCalendarQueryImplementation{
+		properties: make(map[string]any),
+	}
+}
+
+type exchangeCalendarQueryImplementation struct {
+	properties map[string]any
+}
+
+var _ ExchangeCalendarQueryInterface = (*exchangeCalendarQueryImplementation)(nil) // verify interface is implemented
+
+func (c *exchangeCalendarQueryImplementation) hasProperty(name string) bool {
+	_, ok := c.properties[name]
+	return ok
+}
+
+func (c *exchangeCalendarQueryImplementation) Validate() error {
+	if c.IsExchangeSet() && c.Exchange() == "" {
+		return errors.New("exchange calendar query. exchange cannot be empty")
+	}
+
+	if c.IsIDSet() && c.ID() == "" {
+		return errors.New("exchange calendar query. id cannot be empty")
+	}
+
+	if c.IsOrderBySet() && c.OrderBy() == "" {
+		return errors.New("exchange calendar query. order_by cannot be empty")
+	}
+
+	if c.IsOrderDirectionSet() && c.OrderDirection() == "" {
+		return errors.New("exchange calendar query. order_direction cannot be empty")
+	}
+
+	if c.IsLimitSet() && c.Limit() <= 0 {
+		return errors.New("exchange calendar query. limit must be greater than 0")
+	}
+
+	if c.IsOffsetSet() && c.Offset() < 0 {
+		return errors.New("exchange calendar query. offset must be greater than or equal to 0")
+	}
+
+	return nil
+}
+
+func (c *exchangeCalendarQueryImplementation) IsColumnsSet() bool {
+	return c.hasProperty("columns")
+}
+
+func (c *exchangeCalendarQueryImplementation) Columns() []string {
+	if !c.hasProperty("columns") {
+		return []string{}
+	}
+
+	return c.properties["columns"].([]string)
+}
+
+func (c *exchangeCalendarQueryImplementation) SetColumns(columns []string) ExchangeCalendarQueryInterface {
+	c.properties["columns"] = columns
+
+	return c
+}
+
+func (c *exchangeCalendarQueryImplementation) IsCountOnlySet() bool {
+	return c.hasProperty("count_only")
+}
+
+func (c *exchangeCalendarQueryImplementation) IsCountOnly() bool {
+	if !c.IsCountOnlySet() {
+		return false
+	}
+
+	return c.properties["count_only"].(bool)
+}
+
+func (c *exchangeCalendarQueryImplementation) SetCountOnly(countOnly bool) ExchangeCalendarQueryInterface {
+	c.properties["count_only"] = countOnly
+
+	return c
+}
+
+func (c *exchangeCalendarQueryImplementation) IsExchangeSet() bool {
+	return c.hasProperty("exchange")
+}
+
+func (c *exchangeCalendarQueryImplementation) Exchange() string {
+	if !c.IsExchangeSet() {
+		return ""
+	}
+
+	return c.properties["exchange"].(string)
+}
+
+func (c *exchangeCalendarQueryImplementation) SetExchange(exchange string) ExchangeCalendarQueryInterface {
+	c.properties["exchange"] = exchange
+
+	return c
+}
+
+func (c *exchangeCalendarQueryImplementation) IsIDSet() bool {
+	return c.hasProperty("id")
+}
+
+func (c *exchangeCalendarQueryImplementation) ID() string {
+	if !c.IsIDSet() {
+		return ""
+	}
+
+	return c.properties["id"].(string)
+}
+
+func (c *exchangeCalendarQueryImplementation) SetID(id string) ExchangeCalendarQueryInterface {
+	c.properties["id"] = id
+
+	return c
+}
+
+func (c *exchangeCalendarQueryImplementation) IsLimitSet() bool {
+	return c.hasProperty("limit")
+}
+
+func (c *exchangeCalendarQueryImplementation) Limit() int {
+	if !c.IsLimitSet() {
+		return 0
+	}
+
+	return c.properties["limit"].(int)
+}
+
+func (c *exchangeCalendarQueryImplementation) SetLimit(limit int) ExchangeCalendarQueryInterface {
+	c.properties["limit"] = limit
+
+	return c
+}
+
+func (c *exchangeCalendarQueryImplementation) IsOffsetSet() bool {
+	return c.hasProperty("offset")
+}
+
+func (c *exchangeCalendarQueryImplementation) Offset() int {
+	if !c.IsOffsetSet() {
+		return 0
+	}
+
+	return c.properties["offset"].(int)
+}
+
+func (c *exchangeCalendarQueryImplementation) SetOffset(offset int) ExchangeCalendarQueryInterface {
+	c.properties["offset"] = offset
+
+	return c
+}
+
+func (c *exchangeCalendarQueryImplementation) IsOrderBySet() bool {
+	return c.hasProperty("order_by")
+}
+
+func (c *exchangeCalendarQueryImplementation) OrderBy() string {
+	if !c.IsOrderBySet() {
+		return ""
+	}
+
+	return c.properties["order_by"].(string)
+}
+
+func (c *exchangeCalendarQueryImplementation) SetOrderBy(orderBy string) ExchangeCalendarQueryInterface {
+	c.properties["order_by"] = orderBy
+
+	return c
+}
+
+func (c *exchangeCalendarQueryImplementation) IsOrderDirectionSet() bool {
+	return c.hasProperty("order_direction")
+}
+
+func (c *exchangeCalendarQueryImplementation) OrderDirection() string {
+	if !c.IsOrderDirectionSet() {
+		return ""
+	}
+
+	return c.properties["order_direction"].(string)
+}
+
+func (c *exchangeCalendarQueryImplementation) SetOrderDirection(orderDirection string) ExchangeCalendarQueryInterface {
+	c.properties["order_direction"] = orderDirection
+
+	return c
+}
@@ -0,0 +1,37 @@
+package tradingstore
+
+type ExchangeCalendarQueryInterface interface {
+	Validate() error
+
+	IsColumnsSet() bool
+	Columns() []string
+	SetColumns(columns []string) ExchangeCalendarQueryInterface
+
+	IsCountOnlySet() bool
+	IsCountOnly() bool
+	SetCountOnly(countOnly bool) ExchangeCalendarQueryInterface
+
+	IsExchangeSet() bool
+	Exchange() string
+	SetExchange(exchange string) ExchangeCalendarQueryInterface
+
+	IsIDSet() bool
+	ID() string
+	SetID(id string) ExchangeCalendarQueryInterface
+
+	IsLimitSet() bool
+	Limit() int
+	SetLimit(limit int) ExchangeCalendarQueryInterface
+
+	IsOffsetSet() bool
+	Offset() int
+	SetOffset(offset int) ExchangeCalendarQueryInterface
+
+	IsOrderBySet() bool
+	OrderBy() string
+	SetOrderBy(orderBy string) ExchangeCalendarQueryInterface
+
+	IsOrderDirectionSet() bool
+	OrderDirection() string
+	SetOrderDirection(orderDirection string) ExchangeCalendarQueryInterface
+}
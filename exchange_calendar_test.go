@@ -0,0 +1,158 @@
+package tradingstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExchangeCalendarIsOpen(t *testing.T) {
+	calendar := BuiltinExchangeCalendar(EXCHANGE_NYSE)
+
+	if calendar == nil {
+		t.Fatal("NYSE calendar MUST NOT be nil")
+	}
+
+	newYork, err := calendar.Location()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	cases := []struct {
+		time     time.Time
+		expected bool
+	}{
+		{time.Date(2024, time.March, 13, 9, 29, 0, 0, newYork), false},
+		{time.Date(2024, time.March, 13, 9, 30, 0, 0, newYork), true},
+		{time.Date(2024, time.March, 13, 15, 59, 0, 0, newYork), true},
+		{time.Date(2024, time.March, 13, 16, 0, 0, 0, newYork), false},
+		{time.Date(2024, time.March, 16, 12, 0, 0, 0, newYork), false}, // Saturday
+	}
+
+	for _, c := range cases {
+		isOpen, err := calendar.IsOpen(c.time)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if isOpen != c.expected {
+			t.Fatal("IsOpen at", c.time, "MUST BE", c.expected, ", found:", isOpen)
+		}
+	}
+}
+
+func TestExchangeCalendarHolidaysAndHalfDays(t *testing.T) {
+	calendar := BuiltinExchangeCalendar(EXCHANGE_NYSE)
+
+	if err := calendar.SetHolidays([]string{"2024-12-25"}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := calendar.SetHalfDays(map[string]string{"2024-12-24": "13:00"}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	newYork, _ := calendar.Location()
+
+	sessions, err := calendar.SessionsOn(time.Date(2024, time.December, 25, 12, 0, 0, 0, newYork))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(sessions) != 0 {
+		t.Fatal("Sessions on a holiday MUST BE empty, found:", sessions)
+	}
+
+	sessions, err = calendar.SessionsOn(time.Date(2024, time.December, 24, 12, 0, 0, 0, newYork))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(sessions) != 1 {
+		t.Fatal("Sessions count MUST BE 1, found:", len(sessions))
+	}
+
+	if !sessions[0].Close.Equal(time.Date(2024, time.December, 24, 13, 0, 0, 0, newYork)) {
+		t.Fatal("Half day close MUST BE 13:00, found:", sessions[0].Close)
+	}
+
+	if err := calendar.SetHolidays([]string{"25/12/2024"}); err == nil {
+		t.Fatal("Error MUST NOT be nil for a malformed holiday")
+	}
+
+	if err := calendar.SetHalfDays(map[string]string{"24/12/2024": "13:00"}); err == nil {
+		t.Fatal("Error MUST NOT be nil for a malformed half day date")
+	}
+
+	if err := calendar.SetHalfDays(map[string]string{"2024-12-24": "1pm"}); err == nil {
+		t.Fatal("Error MUST NOT be nil for a malformed half day close")
+	}
+
+	// the built-in calendars are copies, changing one MUST NOT change the others
+	other := BuiltinExchangeCalendar(EXCHANGE_NYSE)
+
+	if holidays, _ := other.Holidays(); len(holidays) != 0 {
+		t.Fatal("A built-in calendar MUST NOT share the changes of another copy, found holidays:", holidays)
+	}
+
+	if other.ID() == calendar.ID() {
+		t.Fatal("Each built-in calendar copy MUST have its own ID")
+	}
+}
+
+func TestExchangeCalendarOvernightAndBreaks(t *testing.T) {
+	forex := BuiltinExchangeCalendar(EXCHANGE_FOREX)
+	newYork, _ := forex.Location()
+
+	cases := []struct {
+		time     time.Time
+		expected bool
+	}{
+		{time.Date(2024, time.March, 10, 16, 59, 0, 0, newYork), false}, // Sunday before the open
+		{time.Date(2024, time.March, 10, 17, 0, 0, 0, newYork), true},   // Sunday open
+		{time.Date(2024, time.March, 13, 3, 0, 0, 0, newYork), true},    // Wednesday night
+		{time.Date(2024, time.March, 15, 16, 59, 0, 0, newYork), true},  // Friday before the close
+		{time.Date(2024, time.March, 15, 17, 0, 0, 0, newYork), false},  // Friday close
+		{time.Date(2024, time.March, 16, 12, 0, 0, 0, newYork), false},  // Saturday
+	}
+
+	for _, c := range cases {
+		isOpen, err := forex.IsOpen(c.time)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if isOpen != c.expected {
+			t.Fatal("Forex IsOpen at", c.time, "MUST BE", c.expected, ", found:", isOpen)
+		}
+	}
+
+	tokyo := BuiltinExchangeCalendar(EXCHANGE_TSE)
+	tokyoLocation, _ := tokyo.Location()
+
+	isOpen, err := tokyo.IsOpen(time.Date(2024, time.March, 13, 12, 0, 0, 0, tokyoLocation))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if isOpen {
+		t.Fatal("Tokyo MUST be closed during the lunch break")
+	}
+
+	crypto := BuiltinExchangeCalendar(EXCHANGE_CRYPTO)
+
+	isOpen, err = crypto.IsOpen(time.Date(2024, time.March, 16, 23, 59, 0, 0, time.UTC))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !isOpen {
+		t.Fatal("Crypto MUST be open on a Saturday night")
+	}
+}
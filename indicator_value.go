@@ -0,0 +1,141 @@
+package tradingstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+
+	"github.com/dracory/dataobject"
+	"github.com/dracory/uid"
+	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+)
+
+// == CLASS ====================================================================
+
+// indicatorValueImplementation represents a persisted indicator value
+// of a price series, at the time of a price
+type indicatorValueImplementation struct {
+	dataobject.DataObject
+}
+
+// == CONSTRUCTORS =============================================================
+
+func NewIndicatorValue() IndicatorValueInterface {
+	o := (&indicatorValueImplementation{}).
+		SetID(uid.HumanUid())
+
+	// Default values
+	o.SetName("")
+	o.SetParamsHash("")
+	_ = o.SetValues(map[string]float64{})
+	o.SetCreatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+
+	return o
+}
+
+func NewIndicatorValueFromExistingData(data map[string]string) IndicatorValueInterface {
+	o := &indicatorValueImplementation{}
+	o.Hydrate(data)
+	return o
+}
+
+var _ IndicatorValueInterface = (*indicatorValueImplementation)(nil)
+
+// == FUNCTIONS ================================================================
+
+// IndicatorParamsHash returns a stable hash of indicator parameters, so values
+// of the same indicator with different parameters are stored apart
+func IndicatorParamsHash(params map[string]string) string {
+	keys := lo.Keys(params)
+	slices.Sort(keys)
+
+	hash := sha256.New()
+
+	for _, key := range keys {
+		hash.Write([]byte(key + "=" + params[key] + "\n"))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// == SETTERS & GETTERS ========================================================
+
+func (value *indicatorValueImplementation) ID() string {
+	return value.Get(COLUMN_ID)
+}
+
+func (value *indicatorValueImplementation) SetID(id string) IndicatorValueInterface {
+	value.Set(COLUMN_ID, id)
+	return value
+}
+
+func (value *indicatorValueImplementation) Name() string {
+	return value.Get(COLUMN_NAME)
+}
+
+func (value *indicatorValueImplementation) SetName(name string) IndicatorValueInterface {
+	value.Set(COLUMN_NAME, name)
+	return value
+}
+
+func (value *indicatorValueImplementation) ParamsHash() string {
+	return value.Get(COLUMN_PARAMS_HASH)
+}
+
+func (value *indicatorValueImplementation) SetParamsHash(paramsHash string) IndicatorValueInterface {
+	value.Set(COLUMN_PARAMS_HASH, paramsHash)
+	return value
+}
+
+func (value *indicatorValueImplementation) Time() string {
+	return value.Get(COLUMN_TIME)
+}
+
+func (value *indicatorValueImplementation) TimeCarbon() *carbon.Carbon {
+	return carbon.Parse(value.Time(), carbon.UTC)
+}
+
+func (value *indicatorValueImplementation) SetTime(time string) IndicatorValueInterface {
+	value.Set(COLUMN_TIME, time)
+	return value
+}
+
+func (value *indicatorValueImplementation) Values() (map[string]float64, error) {
+	valuesStr := value.Get(COLUMN_INDICATOR_VALUES)
+	if valuesStr == "" {
+		return map[string]float64{}, nil
+	}
+
+	var values map[string]float64
+	err := json.Unmarshal([]byte(valuesStr), &values)
+	if err != nil {
+		return map[string]float64{}, err
+	}
+
+	return values, nil
+}
+
+func (value *indicatorValueImplementation) SetValues(values map[string]float64) error {
+	valuesJson, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	value.Set(COLUMN_INDICATOR_VALUES, string(valuesJson))
+	return nil
+}
+
+func (value *indicatorValueImplementation) CreatedAt() string {
+	return value.Get(COLUMN_CREATED_AT)
+}
+
+func (value *indicatorValueImplementation) CreatedAtCarbon() *carbon.Carbon {
+	return carbon.Parse(value.CreatedAt(), carbon.UTC)
+}
+
+func (value *indicatorValueImplementation) SetCreatedAt(createdAt string) IndicatorValueInterface {
+	value.Set(COLUMN_CREATED_AT, createdAt)
+	return value
+}
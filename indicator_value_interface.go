@@ -0,0 +1,34 @@
+package tradingstore
+
+import (
+	"github.com/dromara/carbon/v2"
+)
+
+type IndicatorValueInterface interface {
+	// from dataobject
+	Data() map[string]string
+	DataChanged() map[string]string
+	MarkAsNotDirty()
+
+	// setters and getters
+
+	ID() string
+	SetID(id string) IndicatorValueInterface
+
+	Name() string
+	SetName(name string) IndicatorValueInterface
+
+	ParamsHash() string
+	SetParamsHash(paramsHash string) IndicatorValueInterface
+
+	Time() string
+	TimeCarbon() *carbon.Carbon
+	SetTime(time string) IndicatorValueInterface
+
+	Values() (map[string]float64, error)
+	SetValues(values map[string]float64) error
+
+	CreatedAt() string
+	CreatedAtCarbon() *carbon.Carbon
+	SetCreatedAt(createdAt string) IndicatorValueInterface
+}
@@ -0,0 +1,254 @@
+package tradingstore
+
+import (
+	"errors"
+
+	"github.com/dromara/carbon/v2"
+)
+
+// IndicatorValueQuery is a shortcut for NewIndicatorValueQuery
+func IndicatorValueQuery() IndicatorValueQueryInterface {
+	return NewIndicatorValueQuery()
+}
+
+// NewIndicatorValueQuery creates a new indicator value query
+func NewIndicatorValueQuery() IndicatorValueQueryInterface {
+	return &indicatorValueQueryImplementation{
+		properties: make(map[string]any),
+	}
+}
+
+type indicatorValueQueryImplementation struct {
+	properties map[string]any
+}
+
+var _ IndicatorValueQueryInterface = (*indicatorValueQueryImplementation)(nil) // verify interface is implemented
+
+func (c *indicatorValueQueryImplementation) hasProperty(name string) bool {
+	_, ok := c.properties[name]
+	return ok
+}
+
+func (c *indicatorValueQueryImplementation) Validate() error {
+	if c.IsNameSet() && c.Name() == "" {
+		return errors.New("indicator value query. name cannot be empty")
+	}
+
+	if c.IsParamsHashSet() && c.ParamsHash() == "" {
+		return errors.New("indicator value query. params_hash cannot be empty")
+	}
+
+	if c.IsTimeSet() && c.Time() == "" {
+		return errors.New("indicator value query. time cannot be empty")
+	}
+
+	if c.IsTimeGteSet() && c.TimeGte() == "" {
+		return errors.New("indicator value query. time_gte cannot be empty")
+	}
+
+	if c.IsTimeLteSet() && c.TimeLte() == "" {
+		return errors.New("indicator value query. time_lte cannot be empty")
+	}
+
+	times := map[string]string{"time": c.Time(), "time_gte": c.TimeGte(), "time_lte": c.TimeLte()}
+
+	for name, value := range times {
+		if value != "" && carbon.Parse(value, carbon.UTC).Error != nil {
+			return errors.New("indicator value query. " + name + " is not a valid time: " + value)
+		}
+	}
+
+	if c.IsOrderDirectionSet() && c.OrderDirection() == "" {
+		return errors.New("indicator value query. order_direction cannot be empty")
+	}
+
+	if c.IsLimitSet() && c.Limit() <= 0 {
+		return errors.New("indicator value query. limit must be greater than 0")
+	}
+
+	if c.IsOffsetSet() && c.Offset() < 0 {
+		return errors.New("indicator value query. offset must be greater than or equal to 0")
+	}
+
+	return nil
+}
+
+func (c *indicatorValueQueryImplementation) IsColumnsSet() bool {
+	return c.hasProperty("columns")
+}
+
+func (c *indicatorValueQueryImplementation) Columns() []string {
+	if !c.IsColumnsSet() {
+		return []string{}
+	}
+
+	return c.properties["columns"].([]string)
+}
+
+func (c *indicatorValueQueryImplementation) SetColumns(columns []string) IndicatorValueQueryInterface {
+	c.properties["columns"] = columns
+
+	return c
+}
+
+func (c *indicatorValueQueryImplementation) IsCountOnlySet() bool {
+	return c.hasProperty("count_only")
+}
+
+func (c *indicatorValueQueryImplementation) IsCountOnly() bool {
+	if !c.IsCountOnlySet() {
+		return false
+	}
+
+	return c.properties["count_only"].(bool)
+}
+
+func (c *indicatorValueQueryImplementation) SetCountOnly(countOnly bool) IndicatorValueQueryInterface {
+	c.properties["count_only"] = countOnly
+
+	return c
+}
+
+func (c *indicatorValueQueryImplementation) IsNameSet() bool {
+	return c.hasProperty("name")
+}
+
+func (c *indicatorValueQueryImplementation) Name() string {
+	if !c.IsNameSet() {
+		return ""
+	}
+
+	return c.properties["name"].(string)
+}
+
+func (c *indicatorValueQueryImplementation) SetName(name string) IndicatorValueQueryInterface {
+	c.properties["name"] = name
+
+	return c
+}
+
+func (c *indicatorValueQueryImplementation) IsParamsHashSet() bool {
+	return c.hasProperty("params_hash")
+}
+
+func (c *indicatorValueQueryImplementation) ParamsHash() string {
+	if !c.IsParamsHashSet() {
+		return ""
+	}
+
+	return c.properties["params_hash"].(string)
+}
+
+func (c *indicatorValueQueryImplementation) SetParamsHash(paramsHash string) IndicatorValueQueryInterface {
+	c.properties["params_hash"] = paramsHash
+
+	return c
+}
+
+func (c *indicatorValueQueryImplementation) IsTimeSet() bool {
+	return c.hasProperty("time")
+}
+
+func (c *indicatorValueQueryImplementation) Time() string {
+	if !c.IsTimeSet() {
+		return ""
+	}
+
+	return c.properties["time"].(string)
+}
+
+func (c *indicatorValueQueryImplementation) SetTime(time string) IndicatorValueQueryInterface {
+	c.properties["time"] = time
+
+	return c
+}
+
+func (c *indicatorValueQueryImplementation) IsTimeGteSet() bool {
+	return c.hasProperty("time_gte")
+}
+
+func (c *indicatorValueQueryImplementation) TimeGte() string {
+	if !c.IsTimeGteSet() {
+		return ""
+	}
+
+	return c.properties["time_gte"].(string)
+}
+
+func (c *indicatorValueQueryImplementation) SetTimeGte(timeGte string) IndicatorValueQueryInterface {
+	c.properties["time_gte"] = timeGte
+
+	return c
+}
+
+func (c *indicatorValueQueryImplementation) IsTimeLteSet() bool {
+	return c.hasProperty("time_lte")
+}
+
+func (c *indicatorValueQueryImplementation) TimeLte() string {
+	if !c.IsTimeLteSet() {
+		return ""
+	}
+
+	return c.properties["time_lte"].(string)
+}
+
+func (c *indicatorValueQueryImplementation) SetTimeLte(timeLte string) IndicatorValueQueryInterface {
+	c.properties["time_lte"] = timeLte
+
+	return c
+}
+
+func (c *indicatorValueQueryImplementation) IsLimitSet() bool {
+	return c.hasProperty("limit")
+}
+
+func (c *indicatorValueQueryImplementation) Limit() int {
+	if !c.IsLimitSet() {
+		return 0
+	}
+
+	return c.properties["limit"].(int)
+}
+
+func (c *indicatorValueQueryImplementation) SetLimit(limit int) IndicatorValueQueryInterface {
+	c.properties["limit"] = limit
+
+	return c
+}
+
+func (c *indicatorValueQueryImplementation) IsOffsetSet() bool {
+	return c.hasProperty("offset")
+}
+
+func (c *indicatorValueQueryImplementation) Offset() int {
+	if !c.IsOffsetSet() {
+		return 0
+	}
+
+	return c.properties["offset"].(int)
+}
+
+func (c *indicatorValueQueryImplementation) SetOffset(offset int) IndicatorValueQueryInterface {
+	c.properties["offset"] = offset
+
+	return c
+}
+
+func (c *indicatorValueQueryImplementation) IsOrderDirectionSet() bool {
+	return c.hasProperty("order_direction")
+}
+
+func (c *indicatorValueQueryImplementation) OrderDirection() string {
+	if !c.IsOrderDirectionSet() {
+		return ""
+	}
+
+	return c.properties["order_direction"].(string)
+}
+
+func (c *indicatorValueQueryImplementation) SetOrderDirection(orderDirection string) IndicatorValueQueryInterface {
+	c.properties["order_direction"] = orderDirection
+
+	return c
+}
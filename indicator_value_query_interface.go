@@ -0,0 +1,45 @@
+package tradingstore
+
+type IndicatorValueQueryInterface interface {
+	Validate() error
+
+	IsColumnsSet() bool
+	Columns() []string
+	SetColumns(columns []string) IndicatorValueQueryInterface
+
+	IsCountOnlySet() bool
+	IsCountOnly() bool
+	SetCountOnly(countOnly bool) IndicatorValueQueryInterface
+
+	IsNameSet() bool
+	Name() string
+	SetName(name string) IndicatorValueQueryInterface
+
+	IsParamsHashSet() bool
+	ParamsHash() string
+	SetParamsHash(paramsHash string) IndicatorValueQueryInterface
+
+	IsTimeSet() bool
+	Time() string
+	SetTime(time string) IndicatorValueQueryInterface
+
+	IsTimeGteSet() bool
+	TimeGte() string
+	SetTimeGte(timeGte string) IndicatorValueQueryInterface
+
+	IsTimeLteSet() bool
+	TimeLte() string
+	SetTimeLte(timeLte string) IndicatorValueQueryInterface
+
+	IsLimitSet() bool
+	Limit() int
+	SetLimit(limit int) IndicatorValueQueryInterface
+
+	IsOffsetSet() bool
+	Offset() int
+	SetOffset(offset int) IndicatorValueQueryInterface
+
+	IsOrderDirectionSet() bool
+	OrderDirection() string
+	SetOrderDirection(orderDirection string) IndicatorValueQueryInterface
+}
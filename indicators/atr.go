@@ -0,0 +1,67 @@
+package indicators
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/dracory/tradingstore"
+)
+
+// atr is the average true range, with Wilder smoothing
+type atr struct {
+	period    int
+	state     *emaState
+	lastClose float64
+	started   bool
+}
+
+var _ IndicatorInterface = (*atr)(nil) // verify interface is implemented
+
+// NewATR returns the average true range, with Wilder smoothing.
+// The true range needs the previous close, so the first price only seeds it.
+// The output is named "value".
+func NewATR(period int) (IndicatorInterface, error) {
+	if err := validatePeriod("atr period", period); err != nil {
+		return nil, err
+	}
+
+	i := &atr{period: period}
+	i.Reset()
+
+	return i, nil
+}
+
+func (i *atr) Name() string {
+	return "atr"
+}
+
+func (i *atr) Params() map[string]string {
+	return map[string]string{"period": strconv.Itoa(i.period)}
+}
+
+func (i *atr) WarmupPeriod() int {
+	return i.period
+}
+
+func (i *atr) Update(price tradingstore.PriceInterface) Value {
+	high, low, close := price.HighFloat(), price.LowFloat(), price.CloseFloat()
+
+	if !i.started {
+		i.started = true
+		i.lastClose = close
+		return newValue(price, false, nil)
+	}
+
+	trueRange := math.Max(high-low, math.Max(math.Abs(high-i.lastClose), math.Abs(low-i.lastClose)))
+	i.lastClose = close
+
+	value, ready := i.state.update(trueRange)
+
+	return newValue(price, ready, map[string]float64{"value": value})
+}
+
+func (i *atr) Reset() {
+	i.state = newEMAState(i.period, true)
+	i.lastClose = 0
+	i.started = false
+}
@@ -0,0 +1,72 @@
+package indicators
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dracory/tradingstore"
+)
+
+// bollinger are the Bollinger bands of the close prices
+type bollinger struct {
+	period     int
+	multiplier float64
+	state      *smaState
+}
+
+var _ IndicatorInterface = (*bollinger)(nil) // verify interface is implemented
+
+// NewBollinger returns the Bollinger bands of the close prices, usually with
+// period 20 and multiplier 2. The bands are the simple moving average plus
+// and minus the multiplier times the population standard deviation.
+// The outputs are named "upper", "middle" and "lower".
+func NewBollinger(period int, multiplier float64) (IndicatorInterface, error) {
+	if err := validatePeriod("bollinger period", period); err != nil {
+		return nil, err
+	}
+
+	if multiplier <= 0 {
+		return nil, errors.New("indicators: bollinger multiplier must be greater than zero")
+	}
+
+	return &bollinger{
+		period:     period,
+		multiplier: multiplier,
+		state:      newSMAState(period),
+	}, nil
+}
+
+func (i *bollinger) Name() string {
+	return "bollinger"
+}
+
+func (i *bollinger) Params() map[string]string {
+	return map[string]string{
+		"period":     strconv.Itoa(i.period),
+		"multiplier": strconv.FormatFloat(i.multiplier, 'f', -1, 64),
+	}
+}
+
+func (i *bollinger) WarmupPeriod() int {
+	return i.period - 1
+}
+
+func (i *bollinger) Update(price tradingstore.PriceInterface) Value {
+	middle, ready := i.state.update(price.CloseFloat())
+
+	if !ready {
+		return newValue(price, false, nil)
+	}
+
+	deviation := i.multiplier * i.state.stddev(middle)
+
+	return newValue(price, true, map[string]float64{
+		"upper":  middle + deviation,
+		"middle": middle,
+		"lower":  middle - deviation,
+	})
+}
+
+func (i *bollinger) Reset() {
+	i.state.reset()
+}
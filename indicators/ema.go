@@ -0,0 +1,47 @@
+package indicators
+
+import (
+	"strconv"
+
+	"github.com/dracory/tradingstore"
+)
+
+// ema is the exponential moving average of the close prices
+type ema struct {
+	period int
+	state  *emaState
+}
+
+var _ IndicatorInterface = (*ema)(nil) // verify interface is implemented
+
+// NewEMA returns an exponential moving average of the close prices,
+// seeded with the simple average of the first period prices.
+// The output is named "value".
+func NewEMA(period int) (IndicatorInterface, error) {
+	if err := validatePeriod("ema period", period); err != nil {
+		return nil, err
+	}
+
+	return &ema{period: period, state: newEMAState(period, false)}, nil
+}
+
+func (i *ema) Name() string {
+	return "ema"
+}
+
+func (i *ema) Params() map[string]string {
+	return map[string]string{"period": strconv.Itoa(i.period)}
+}
+
+func (i *ema) WarmupPeriod() int {
+	return i.period - 1
+}
+
+func (i *ema) Update(price tradingstore.PriceInterface) Value {
+	value, ready := i.state.update(price.CloseFloat())
+	return newValue(price, ready, map[string]float64{"value": value})
+}
+
+func (i *ema) Reset() {
+	i.state.reset()
+}
@@ -0,0 +1,161 @@
+// Package indicators computes technical indicators over tradingstore price
+// series. Indicators are updated one price at a time, so they work the same
+// over a loaded []PriceInterface, a streaming price iterator, or live prices.
+package indicators
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dracory/tradingstore"
+)
+
+// IndicatorInterface is a technical indicator updated one price at a time
+type IndicatorInterface interface {
+	// Name returns the name of the indicator, i.e. "sma"
+	Name() string
+
+	// Params returns the parameters of the indicator, i.e. {"period": "20"}
+	Params() map[string]string
+
+	// WarmupPeriod returns the number of prices consumed before the
+	// indicator returns its first ready value
+	WarmupPeriod() int
+
+	// Update adds the next price, in ascending time order, and returns the
+	// indicator value at that price
+	Update(price tradingstore.PriceInterface) Value
+
+	// Reset clears the state of the indicator
+	Reset()
+}
+
+// Value is the value of an indicator at the time of a price
+type Value struct {
+	// Time is the time of the price
+	Time time.Time
+
+	// Ready is false while the indicator is warming up, the values
+	// are not meaningful until then
+	Ready bool
+
+	// Values holds the outputs of the indicator by name,
+	// i.e. "value" for SMA, or "upper", "middle" and "lower" for Bollinger
+	Values map[string]float64
+}
+
+// Compute resets the indicator, and returns its values over the prices
+//
+// Parameters:
+// - indicator: the indicator to compute
+// - prices: the prices, in ascending time order
+//
+// Returns:
+// - []Value: one value per price
+func Compute(indicator IndicatorInterface, prices []tradingstore.PriceInterface) []Value {
+	indicator.Reset()
+
+	values := make([]Value, 0, len(prices))
+
+	for _, price := range prices {
+		values = append(values, indicator.Update(price))
+	}
+
+	return values
+}
+
+// ComputeIterator resets the indicator, and returns its values over
+// the prices of the iterator. The iterator is not closed.
+//
+// Parameters:
+// - indicator: the indicator to compute
+// - iterator: the price iterator
+//
+// Returns:
+// - []Value: one value per price
+// - error: if the iterator failed
+func ComputeIterator(indicator IndicatorInterface, iterator tradingstore.PriceIteratorInterface) ([]Value, error) {
+	if iterator == nil {
+		return nil, errors.New("indicators: iterator is nil")
+	}
+
+	indicator.Reset()
+
+	values := []Value{}
+
+	for iterator.Next() {
+		values = append(values, indicator.Update(iterator.Price()))
+	}
+
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// ComputeFromStore resets the indicator, and returns its values over the
+// stored prices matching the query options. The prices preceding the range
+// are fetched to warm up the indicator, so the values are ready from the
+// first price of the range when there is enough history.
+//
+// Parameters:
+// - ctx: the context
+// - store: the store to read the prices from
+// - indicator: the indicator to compute
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - timeframe: the timeframe of the prices
+// - options: the query options of the range, ordered by time ascending
+//
+// Returns:
+// - []Value: one value per price of the range
+// - error: if the prices could not be read
+func ComputeFromStore(ctx context.Context, store tradingstore.StoreInterface, indicator IndicatorInterface, symbol string, exchange string, timeframe string, options tradingstore.PriceQueryInterface) ([]Value, error) {
+	if store == nil {
+		return nil, errors.New("indicators: store is nil")
+	}
+
+	warmupPrices, prices, err := store.PriceListWithWarmup(ctx, symbol, exchange, timeframe, indicator.WarmupPeriod(), options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	indicator.Reset()
+
+	for _, price := range warmupPrices {
+		indicator.Update(price)
+	}
+
+	values := make([]Value, 0, len(prices))
+
+	for _, price := range prices {
+		values = append(values, indicator.Update(price))
+	}
+
+	return values, nil
+}
+
+// newValue returns a value at the time of the price
+func newValue(price tradingstore.PriceInterface, ready bool, values map[string]float64) Value {
+	if !ready {
+		values = map[string]float64{}
+	}
+
+	return Value{
+		Time:   price.TimeCarbon().StdTime().UTC(),
+		Ready:  ready,
+		Values: values,
+	}
+}
+
+// validatePeriod returns an error if the period is not positive
+func validatePeriod(name string, period int) error {
+	if period < 1 {
+		return errors.New("indicators: " + name + " must be greater than zero")
+	}
+
+	return nil
+}
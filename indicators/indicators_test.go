@@ -0,0 +1,361 @@
+package indicators
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dracory/tradingstore"
+	"github.com/dromara/carbon/v2"
+	_ "modernc.org/sqlite"
+)
+
+func testPrices(closes ...float64) []tradingstore.PriceInterface {
+	prices := []tradingstore.PriceInterface{}
+	start := carbon.Parse("2020-01-01 00:00:00", carbon.UTC)
+
+	for index, close := range closes {
+		value := strconv.FormatFloat(close, 'f', -1, 64)
+
+		prices = append(prices, tradingstore.NewPrice().
+			SetTime(start.Copy().AddMinutes(index).ToDateTimeString(carbon.UTC)).
+			SetOpen(value).
+			SetHigh(strconv.FormatFloat(close+1, 'f', -1, 64)).
+			SetLow(strconv.FormatFloat(close-1, 'f', -1, 64)).
+			SetClose(value).
+			SetVolume("10"))
+	}
+
+	return prices
+}
+
+func assertValues(t *testing.T, values []Value, output string, expected []float64) {
+	t.Helper()
+
+	if len(values) != len(expected) {
+		t.Fatal("Values count MUST BE", len(expected), ", found:", len(values))
+	}
+
+	for index, value := range values {
+		if math.IsNaN(expected[index]) {
+			if value.Ready {
+				t.Fatal("Value", index, "MUST NOT be ready")
+			}
+
+			continue
+		}
+
+		if !value.Ready {
+			t.Fatal("Value", index, "MUST be ready")
+		}
+
+		if math.Abs(value.Values[output]-expected[index]) > 1e-9 {
+			t.Fatal("Value", index, "MUST BE", expected[index], ", found:", value.Values[output])
+		}
+	}
+}
+
+func TestSMA(t *testing.T) {
+	indicator, err := NewSMA(3)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if indicator.WarmupPeriod() != 2 {
+		t.Fatal("Warmup period MUST BE 2, found:", indicator.WarmupPeriod())
+	}
+
+	nan := math.NaN()
+	values := Compute(indicator, testPrices(1, 2, 3, 4, 5))
+	assertValues(t, values, "value", []float64{nan, nan, 2, 3, 4})
+
+	// compute resets the state
+	values = Compute(indicator, testPrices(1, 2, 3, 4, 5))
+	assertValues(t, values, "value", []float64{nan, nan, 2, 3, 4})
+
+	if _, err := NewSMA(0); err == nil {
+		t.Fatal("Zero period MUST return an error")
+	}
+}
+
+func TestEMA(t *testing.T) {
+	indicator, err := NewEMA(3)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	nan := math.NaN()
+	values := Compute(indicator, testPrices(1, 2, 3, 4, 5))
+	assertValues(t, values, "value", []float64{nan, nan, 2, 3, 4})
+}
+
+func TestRSI(t *testing.T) {
+	indicator, err := NewRSI(3)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if indicator.WarmupPeriod() != 3 {
+		t.Fatal("Warmup period MUST BE 3, found:", indicator.WarmupPeriod())
+	}
+
+	nan := math.NaN()
+
+	values := Compute(indicator, testPrices(1, 2, 3, 4, 5))
+	assertValues(t, values, "value", []float64{nan, nan, nan, 100, 100})
+
+	// gains 1, 1, losses 1: average gain 2/3, average loss 1/3 => rs 2
+	values = Compute(indicator, testPrices(1, 2, 3, 2))
+	assertValues(t, values, "value", []float64{nan, nan, nan, 100 - 100.0/3})
+}
+
+func TestMACD(t *testing.T) {
+	indicator, err := NewMACD(2, 3, 2)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if indicator.WarmupPeriod() != 3 {
+		t.Fatal("Warmup period MUST BE 3, found:", indicator.WarmupPeriod())
+	}
+
+	values := Compute(indicator, testPrices(1, 2, 3, 4, 5, 6))
+
+	for index, value := range values {
+		if value.Ready != (index >= 3) {
+			t.Fatal("Value", index, "ready MUST BE", index >= 3)
+		}
+	}
+
+	last := values[len(values)-1].Values
+
+	if math.Abs(last["histogram"]-(last["macd"]-last["signal"])) > 1e-9 {
+		t.Fatal("Histogram MUST BE macd minus signal")
+	}
+
+	if _, err := NewMACD(3, 2, 2); err == nil {
+		t.Fatal("Fast period above slow period MUST return an error")
+	}
+}
+
+func TestBollinger(t *testing.T) {
+	indicator, err := NewBollinger(2, 2)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	nan := math.NaN()
+	values := Compute(indicator, testPrices(1, 3, 3))
+
+	// window 1, 3: mean 2, stddev 1
+	assertValues(t, values[:2], "upper", []float64{nan, 4})
+	assertValues(t, values[:2], "lower", []float64{nan, 0})
+	assertValues(t, values[2:], "upper", []float64{3})
+	assertValues(t, values[2:], "middle", []float64{3})
+}
+
+func TestATR(t *testing.T) {
+	indicator, err := NewATR(2)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// every high-low range is 2, the gaps between closes are at most 2
+	nan := math.NaN()
+	values := Compute(indicator, testPrices(1, 2, 4, 5))
+	assertValues(t, values, "value", []float64{nan, nan, 2.5, 2.25})
+}
+
+func TestStochastic(t *testing.T) {
+	indicator, err := NewStochastic(2, 2)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// k: (2-0)/(3-0), (3-1)/(4-1), (2-1)/(4-1)
+	nan := math.NaN()
+	values := Compute(indicator, testPrices(1, 2, 3, 2))
+	assertValues(t, values, "k", []float64{nan, nan, 200.0 / 3, 100.0 / 3})
+	assertValues(t, values, "d", []float64{nan, nan, (200.0/3 + 200.0/3) / 2, (200.0/3 + 100.0/3) / 2})
+}
+
+func TestVWAP(t *testing.T) {
+	indicator, err := NewVWAP(nil)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	prices := testPrices(1, 3, 5)
+	prices[2].SetTime("2020-01-02 00:00:00")
+
+	values := Compute(indicator, prices)
+	assertValues(t, values, "value", []float64{1, 2, 5})
+
+	if values[2].Time != time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC) {
+		t.Fatal("Value time MUST BE the price time, found:", values[2].Time)
+	}
+}
+
+func TestComputeFromStore(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err := tradingstore.NewStore(tradingstore.NewStoreOptions{
+		DB:                   db,
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	err = store.InstrumentCreate(ctx, tradingstore.NewInstrument().
+		SetSymbol("AAPL").
+		SetExchange("NASDAQ").
+		SetAssetClass(tradingstore.ASSET_CLASS_STOCK).
+		SetTimeframes([]string{tradingstore.TIMEFRAME_1_MINUTE}))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	err = store.AutoMigratePrices(ctx)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, price := range testPrices(1, 2, 3, 4, 5, 6) {
+		err = store.PriceCreate(ctx, "AAPL", "NASDAQ", tradingstore.TIMEFRAME_1_MINUTE, price)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	indicator, err := NewSMA(3)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	values, err := ComputeFromStore(ctx, store, indicator, "AAPL", "NASDAQ", tradingstore.TIMEFRAME_1_MINUTE, tradingstore.NewPriceQuery().
+		SetTimeGte("2020-01-01 00:03:00"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// the range starts at 4, warmed up with 2 and 3
+	assertValues(t, values, "value", []float64{3, 4, 5})
+
+	iterator, err := store.PriceIterate(ctx, "AAPL", "NASDAQ", tradingstore.TIMEFRAME_1_MINUTE, tradingstore.NewPriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	defer iterator.Close()
+
+	values, err = ComputeIterator(indicator, iterator)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	nan := math.NaN()
+	assertValues(t, values, "value", []float64{nan, nan, 2, 3, 4, 5})
+}
+
+func TestSaveToStoreAndListFromStore(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err := tradingstore.NewStore(tradingstore.NewStoreOptions{
+		DB:                       db,
+		PriceTableNamePrefix:     "price_",
+		InstrumentTableName:      "instrument",
+		IndicatorTableNamePrefix: "indicator_",
+		UseMultipleExchanges:     true,
+		AutomigrateEnabled:       true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	err = store.InstrumentCreate(ctx, tradingstore.NewInstrument().
+		SetSymbol("AAPL").
+		SetExchange("NASDAQ").
+		SetAssetClass(tradingstore.ASSET_CLASS_STOCK).
+		SetTimeframes([]string{tradingstore.TIMEFRAME_1_MINUTE}))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	indicator, err := NewBollinger(2, 2)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	computed := Compute(indicator, testPrices(1, 3, 3))
+
+	err = SaveToStore(ctx, store, indicator, "AAPL", "NASDAQ", tradingstore.TIMEFRAME_1_MINUTE, computed)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	loaded, err := ListFromStore(ctx, store, indicator, "AAPL", "NASDAQ", tradingstore.TIMEFRAME_1_MINUTE, nil)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// the first value is not ready, and not persisted
+	if len(loaded) != 2 {
+		t.Fatal("Values count MUST BE 2, found:", len(loaded))
+	}
+
+	for index, value := range loaded {
+		expected := computed[index+1]
+
+		if !value.Time.Equal(expected.Time) {
+			t.Fatal("Value time MUST BE", expected.Time, ", found:", value.Time)
+		}
+
+		if value.Values["upper"] != expected.Values["upper"] {
+			t.Fatal("Value upper MUST BE", expected.Values["upper"], ", found:", value.Values["upper"])
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package indicators
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dracory/tradingstore"
+)
+
+// macd is the moving average convergence divergence of the close prices
+type macd struct {
+	fastPeriod   int
+	slowPeriod   int
+	signalPeriod int
+	fast         *emaState
+	slow         *emaState
+	signal       *emaState
+}
+
+var _ IndicatorInterface = (*macd)(nil) // verify interface is implemented
+
+// NewMACD returns the moving average convergence divergence of the close
+// prices, usually with periods 12, 26 and 9. The outputs are named
+// "macd", "signal" and "histogram".
+func NewMACD(fastPeriod int, slowPeriod int, signalPeriod int) (IndicatorInterface, error) {
+	if err := validatePeriod("macd fast period", fastPeriod); err != nil {
+		return nil, err
+	}
+
+	if err := validatePeriod("macd slow period", slowPeriod); err != nil {
+		return nil, err
+	}
+
+	if err := validatePeriod("macd signal period", signalPeriod); err != nil {
+		return nil, err
+	}
+
+	if fastPeriod >= slowPeriod {
+		return nil, errors.New("indicators: macd fast period must be less than the slow period")
+	}
+
+	i := &macd{
+		fastPeriod:   fastPeriod,
+		slowPeriod:   slowPeriod,
+		signalPeriod: signalPeriod,
+	}
+	i.Reset()
+
+	return i, nil
+}
+
+func (i *macd) Name() string {
+	return "macd"
+}
+
+func (i *macd) Params() map[string]string {
+	return map[string]string{
+		"fast":   strconv.Itoa(i.fastPeriod),
+		"slow":   strconv.Itoa(i.slowPeriod),
+		"signal": strconv.Itoa(i.signalPeriod),
+	}
+}
+
+func (i *macd) WarmupPeriod() int {
+	return i.slowPeriod - 1 + i.signalPeriod - 1
+}
+
+func (i *macd) Update(price tradingstore.PriceInterface) Value {
+	close := price.CloseFloat()
+
+	fast, _ := i.fast.update(close)
+	slow, ready := i.slow.update(close)
+
+	if !ready {
+		return newValue(price, false, nil)
+	}
+
+	line := fast - slow
+	signal, ready := i.signal.update(line)
+
+	return newValue(price, ready, map[string]float64{
+		"macd":      line,
+		"signal":    signal,
+		"histogram": line - signal,
+	})
+}
+
+func (i *macd) Reset() {
+	i.fast = newEMAState(i.fastPeriod, false)
+	i.slow = newEMAState(i.slowPeriod, false)
+	i.signal = newEMAState(i.signalPeriod, false)
+}
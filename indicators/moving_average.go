@@ -0,0 +1,92 @@
+package indicators
+
+import "math"
+
+// smaState is a simple moving average over a fixed window
+type smaState struct {
+	period int
+	window []float64
+	sum    float64
+}
+
+func newSMAState(period int) *smaState {
+	return &smaState{period: period}
+}
+
+// update adds a value, and returns the average once the window is full
+func (s *smaState) update(value float64) (float64, bool) {
+	s.window = append(s.window, value)
+	s.sum += value
+
+	if len(s.window) > s.period {
+		s.sum -= s.window[0]
+		s.window = s.window[1:]
+	}
+
+	if len(s.window) < s.period {
+		return 0, false
+	}
+
+	return s.sum / float64(s.period), true
+}
+
+// stddev returns the population standard deviation of the window around the mean
+func (s *smaState) stddev(mean float64) float64 {
+	variance := 0.0
+
+	for _, value := range s.window {
+		variance += (value - mean) * (value - mean)
+	}
+
+	return math.Sqrt(variance / float64(len(s.window)))
+}
+
+func (s *smaState) reset() {
+	s.window = nil
+	s.sum = 0
+}
+
+// emaState is an exponential moving average, seeded with the simple
+// average of the first period values. With wilder set the smoothing
+// factor is 1/period instead of 2/(period+1).
+type emaState struct {
+	period int
+	alpha  float64
+	count  int
+	sum    float64
+	value  float64
+}
+
+func newEMAState(period int, wilder bool) *emaState {
+	alpha := 2 / float64(period+1)
+
+	if wilder {
+		alpha = 1 / float64(period)
+	}
+
+	return &emaState{period: period, alpha: alpha}
+}
+
+// update adds a value, and returns the average once seeded
+func (e *emaState) update(value float64) (float64, bool) {
+	if e.count < e.period {
+		e.count++
+		e.sum += value
+
+		if e.count < e.period {
+			return 0, false
+		}
+
+		e.value = e.sum / float64(e.period)
+		return e.value, true
+	}
+
+	e.value = e.alpha*value + (1-e.alpha)*e.value
+	return e.value, true
+}
+
+func (e *emaState) reset() {
+	e.count = 0
+	e.sum = 0
+	e.value = 0
+}
@@ -0,0 +1,89 @@
+package indicators
+
+import (
+	"strconv"
+
+	"github.com/dracory/tradingstore"
+)
+
+// rsi is the relative strength index of the close prices, with Wilder smoothing
+type rsi struct {
+	period    int
+	gains     *emaState
+	losses    *emaState
+	lastClose float64
+	started   bool
+}
+
+var _ IndicatorInterface = (*rsi)(nil) // verify interface is implemented
+
+// NewRSI returns the relative strength index of the close prices,
+// with Wilder smoothing. The output is named "value", from 0 to 100.
+func NewRSI(period int) (IndicatorInterface, error) {
+	if err := validatePeriod("rsi period", period); err != nil {
+		return nil, err
+	}
+
+	i := &rsi{period: period}
+	i.Reset()
+
+	return i, nil
+}
+
+func (i *rsi) Name() string {
+	return "rsi"
+}
+
+func (i *rsi) Params() map[string]string {
+	return map[string]string{"period": strconv.Itoa(i.period)}
+}
+
+func (i *rsi) WarmupPeriod() int {
+	// period changes need period + 1 prices
+	return i.period
+}
+
+func (i *rsi) Update(price tradingstore.PriceInterface) Value {
+	close := price.CloseFloat()
+
+	if !i.started {
+		i.started = true
+		i.lastClose = close
+		return newValue(price, false, nil)
+	}
+
+	change := close - i.lastClose
+	i.lastClose = close
+
+	gain, loss := 0.0, 0.0
+
+	if change > 0 {
+		gain = change
+	} else {
+		loss = -change
+	}
+
+	averageGain, ready := i.gains.update(gain)
+	averageLoss, _ := i.losses.update(loss)
+
+	if !ready {
+		return newValue(price, false, nil)
+	}
+
+	value := 100.0
+
+	if averageLoss != 0 {
+		value = 100 - 100/(1+averageGain/averageLoss)
+	} else if averageGain == 0 {
+		value = 50
+	}
+
+	return newValue(price, true, map[string]float64{"value": value})
+}
+
+func (i *rsi) Reset() {
+	i.gains = newEMAState(i.period, true)
+	i.losses = newEMAState(i.period, true)
+	i.lastClose = 0
+	i.started = false
+}
@@ -0,0 +1,46 @@
+package indicators
+
+import (
+	"strconv"
+
+	"github.com/dracory/tradingstore"
+)
+
+// sma is the simple moving average of the close prices
+type sma struct {
+	period int
+	state  *smaState
+}
+
+var _ IndicatorInterface = (*sma)(nil) // verify interface is implemented
+
+// NewSMA returns a simple moving average of the close prices.
+// The output is named "value".
+func NewSMA(period int) (IndicatorInterface, error) {
+	if err := validatePeriod("sma period", period); err != nil {
+		return nil, err
+	}
+
+	return &sma{period: period, state: newSMAState(period)}, nil
+}
+
+func (i *sma) Name() string {
+	return "sma"
+}
+
+func (i *sma) Params() map[string]string {
+	return map[string]string{"period": strconv.Itoa(i.period)}
+}
+
+func (i *sma) WarmupPeriod() int {
+	return i.period - 1
+}
+
+func (i *sma) Update(price tradingstore.PriceInterface) Value {
+	value, ready := i.state.update(price.CloseFloat())
+	return newValue(price, ready, map[string]float64{"value": value})
+}
+
+func (i *sma) Reset() {
+	i.state.reset()
+}
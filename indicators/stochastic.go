@@ -0,0 +1,90 @@
+package indicators
+
+import (
+	"strconv"
+
+	"github.com/dracory/tradingstore"
+)
+
+// stochastic is the stochastic oscillator
+type stochastic struct {
+	kPeriod int
+	dPeriod int
+	highs   []float64
+	lows    []float64
+	d       *smaState
+}
+
+var _ IndicatorInterface = (*stochastic)(nil) // verify interface is implemented
+
+// NewStochastic returns the stochastic oscillator, usually with periods
+// 14 and 3. %K is the position of the close within the high-low range of
+// the last kPeriod prices, %D is the simple average of the last dPeriod %K.
+// The outputs are named "k" and "d", from 0 to 100.
+func NewStochastic(kPeriod int, dPeriod int) (IndicatorInterface, error) {
+	if err := validatePeriod("stochastic k period", kPeriod); err != nil {
+		return nil, err
+	}
+
+	if err := validatePeriod("stochastic d period", dPeriod); err != nil {
+		return nil, err
+	}
+
+	return &stochastic{
+		kPeriod: kPeriod,
+		dPeriod: dPeriod,
+		d:       newSMAState(dPeriod),
+	}, nil
+}
+
+func (i *stochastic) Name() string {
+	return "stochastic"
+}
+
+func (i *stochastic) Params() map[string]string {
+	return map[string]string{
+		"k": strconv.Itoa(i.kPeriod),
+		"d": strconv.Itoa(i.dPeriod),
+	}
+}
+
+func (i *stochastic) WarmupPeriod() int {
+	return i.kPeriod - 1 + i.dPeriod - 1
+}
+
+func (i *stochastic) Update(price tradingstore.PriceInterface) Value {
+	i.highs = append(i.highs, price.HighFloat())
+	i.lows = append(i.lows, price.LowFloat())
+
+	if len(i.highs) > i.kPeriod {
+		i.highs = i.highs[1:]
+		i.lows = i.lows[1:]
+	}
+
+	if len(i.highs) < i.kPeriod {
+		return newValue(price, false, nil)
+	}
+
+	highest, lowest := i.highs[0], i.lows[0]
+
+	for index := range i.highs {
+		highest = max(highest, i.highs[index])
+		lowest = min(lowest, i.lows[index])
+	}
+
+	k := 50.0
+
+	if highest > lowest {
+		k = 100 * (price.CloseFloat() - lowest) / (highest - lowest)
+	}
+
+	d, ready := i.d.update(k)
+
+	return newValue(price, ready, map[string]float64{"k": k, "d": d})
+}
+
+func (i *stochastic) Reset() {
+	i.highs = nil
+	i.lows = nil
+	i.d.reset()
+}
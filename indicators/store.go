@@ -0,0 +1,105 @@
+package indicators
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dracory/tradingstore"
+)
+
+// SaveToStore persists the ready values of an indicator for a price series,
+// keyed by the indicator name and a hash of its parameters. Values which are
+// not ready are skipped.
+//
+// Parameters:
+// - ctx: the context
+// - store: the store to persist the values to, with indicator persistence enabled
+// - indicator: the indicator the values were computed with
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - timeframe: the timeframe of the price series
+// - values: the values to persist
+//
+// Returns:
+// - error: if the values could not be persisted
+func SaveToStore(ctx context.Context, store tradingstore.StoreInterface, indicator IndicatorInterface, symbol string, exchange string, timeframe string, values []Value) error {
+	if store == nil {
+		return errors.New("indicators: store is nil")
+	}
+
+	paramsHash := tradingstore.IndicatorParamsHash(indicator.Params())
+	indicatorValues := []tradingstore.IndicatorValueInterface{}
+
+	for _, value := range values {
+		if !value.Ready {
+			continue
+		}
+
+		indicatorValue := tradingstore.NewIndicatorValue().
+			SetName(indicator.Name()).
+			SetParamsHash(paramsHash).
+			SetTime(value.Time.UTC().Format(time.RFC3339Nano))
+
+		if err := indicatorValue.SetValues(value.Values); err != nil {
+			return err
+		}
+
+		indicatorValues = append(indicatorValues, indicatorValue)
+	}
+
+	return store.IndicatorSave(ctx, symbol, exchange, timeframe, indicatorValues)
+}
+
+// ListFromStore returns the persisted values of an indicator for a price
+// series. The name and parameters hash of the query are set from the indicator.
+//
+// Parameters:
+// - ctx: the context
+// - store: the store to read the values from, with indicator persistence enabled
+// - indicator: the indicator to read the values of
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - timeframe: the timeframe of the price series
+// - options: the query options, i.e. the time range
+//
+// Returns:
+// - []Value: the persisted values, all ready
+// - error: if the values could not be read
+func ListFromStore(ctx context.Context, store tradingstore.StoreInterface, indicator IndicatorInterface, symbol string, exchange string, timeframe string, options tradingstore.IndicatorValueQueryInterface) ([]Value, error) {
+	if store == nil {
+		return nil, errors.New("indicators: store is nil")
+	}
+
+	if options == nil {
+		options = tradingstore.NewIndicatorValueQuery()
+	}
+
+	options.
+		SetName(indicator.Name()).
+		SetParamsHash(tradingstore.IndicatorParamsHash(indicator.Params()))
+
+	indicatorValues, err := store.IndicatorList(ctx, symbol, exchange, timeframe, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]Value, 0, len(indicatorValues))
+
+	for _, indicatorValue := range indicatorValues {
+		outputs, err := indicatorValue.Values()
+
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, Value{
+			Time:   indicatorValue.TimeCarbon().StdTime().UTC(),
+			Ready:  true,
+			Values: outputs,
+		})
+	}
+
+	return values, nil
+}
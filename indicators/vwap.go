@@ -0,0 +1,70 @@
+package indicators
+
+import (
+	"time"
+
+	"github.com/dracory/tradingstore"
+)
+
+// vwap is the volume weighted average price, reset every day
+type vwap struct {
+	location    *time.Location
+	day         string
+	priceVolume float64
+	volume      float64
+}
+
+var _ IndicatorInterface = (*vwap)(nil) // verify interface is implemented
+
+// NewVWAP returns the volume weighted average of the typical price
+// (high + low + close) / 3, reset at midnight in the given location.
+// A nil location resets at midnight UTC. The output is named "value".
+//
+// The VWAP needs no warm up, but is only meaningful when computed from
+// the first price of the day.
+func NewVWAP(location *time.Location) (IndicatorInterface, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	return &vwap{location: location}, nil
+}
+
+func (i *vwap) Name() string {
+	return "vwap"
+}
+
+func (i *vwap) Params() map[string]string {
+	return map[string]string{"location": i.location.String()}
+}
+
+func (i *vwap) WarmupPeriod() int {
+	return 0
+}
+
+func (i *vwap) Update(price tradingstore.PriceInterface) Value {
+	day := price.TimeCarbon().StdTime().In(i.location).Format(time.DateOnly)
+
+	if day != i.day {
+		i.day = day
+		i.priceVolume = 0
+		i.volume = 0
+	}
+
+	typical := (price.HighFloat() + price.LowFloat() + price.CloseFloat()) / 3
+
+	i.priceVolume += typical * price.VolumeFloat()
+	i.volume += price.VolumeFloat()
+
+	if i.volume == 0 {
+		return newValue(price, true, map[string]float64{"value": typical})
+	}
+
+	return newValue(price, true, map[string]float64{"value": i.priceVolume / i.volume})
+}
+
+func (i *vwap) Reset() {
+	i.day = ""
+	i.priceVolume = 0
+	i.volume = 0
+}
@@ -2,11 +2,14 @@ package tradingstore
 
 import (
 	"encoding/json"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/dracory/dataobject"
 	"github.com/dracory/uid"
 	"github.com/dromara/carbon/v2"
+	"github.com/spf13/cast"
 )
 
 // == CLASS ====================================================================
@@ -31,6 +34,20 @@ func NewInstrument() InstrumentInterface {
 	o.SetMemo("")
 	o.SetMetas(map[string]string{})
 	o.SetTimeframes([]string{})
+	o.SetSourceTimeframe("")
+	o.SetPriceFields([]string{})
+	o.SetUnderlyingID("")
+	o.SetExpiry("")
+	o.SetStrike("0")
+	o.SetOptionType("")
+	o.SetMultiplier("1")
+	o.SetSettlementType("")
+	o.SetBaseAsset("")
+	o.SetQuoteCurrency("")
+	o.SetTickSize("0")
+	o.SetLotSize("0")
+	o.SetMinNotional("0")
+	o.SetSynthetic(SyntheticDefinition{})
 	o.SetCreatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
 	o.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
 	o.SetSoftDeletedAt(carbon.MaxValue().ToDateTimeString())
@@ -46,6 +63,46 @@ func NewInstrumentFromExistingData(data map[string]string) InstrumentInterface {
 
 var _ InstrumentInterface = (*instrumentImplementation)(nil)
 
+// == METHODS ==================================================================
+
+// FormatPrice formats the price with the price precision of the instrument
+func (instrument *instrumentImplementation) FormatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', instrument.PricePrecision(), 64)
+}
+
+// MeetsMinNotional returns true if the notional value (price times quantity)
+// of an order is at least the minimum notional of the instrument
+func (instrument *instrumentImplementation) MeetsMinNotional(price float64, quantity float64) bool {
+	return price*quantity >= instrument.MinNotionalFloat()
+}
+
+// RoundToLot rounds the quantity down to a whole number of lots.
+// The quantity is returned unchanged if the lot size is not set
+func (instrument *instrumentImplementation) RoundToLot(quantity float64) float64 {
+	lotSize := instrument.LotSizeFloat()
+
+	if lotSize <= 0 {
+		return quantity
+	}
+
+	// the epsilon absorbs the float error of quantities already on a lot boundary
+	lots := math.Floor(quantity/lotSize + 1e-9)
+
+	return instrumentRound(lots*lotSize, instrumentDecimalPlaces(instrument.LotSize()))
+}
+
+// RoundToTick rounds the price to the nearest tick.
+// The price is only rounded to the price precision if the tick size is not set
+func (instrument *instrumentImplementation) RoundToTick(price float64) float64 {
+	tickSize := instrument.TickSizeFloat()
+
+	if tickSize > 0 {
+		price = math.Round(price/tickSize) * tickSize
+	}
+
+	return instrumentRound(price, instrument.PricePrecision())
+}
+
 // == SETTERS & GETTERS ========================================================
 
 func (instrument *instrumentImplementation) ID() string {
@@ -93,6 +150,16 @@ func (instrument *instrumentImplementation) SetDescription(description string) I
 	return instrument
 }
 
+// BaseAsset returns the asset being priced, i.e. BTC for BTC/USDT
+func (instrument *instrumentImplementation) BaseAsset() string {
+	return instrument.Get(COLUMN_BASE_ASSET)
+}
+
+func (instrument *instrumentImplementation) SetBaseAsset(baseAsset string) InstrumentInterface {
+	instrument.Set(COLUMN_BASE_ASSET, baseAsset)
+	return instrument
+}
+
 func (instrument *instrumentImplementation) CreatedAt() string {
 	return instrument.Get(COLUMN_CREATED_AT)
 }
@@ -106,6 +173,35 @@ func (instrument *instrumentImplementation) SetCreatedAt(createdAt string) Instr
 	return instrument
 }
 
+// Expiry returns the expiry date of a derivative, formatted as YYYY-MM-DD,
+// or an empty string if the instrument does not expire
+func (instrument *instrumentImplementation) Expiry() string {
+	return instrument.Get(COLUMN_EXPIRY)
+}
+
+func (instrument *instrumentImplementation) ExpiryCarbon() *carbon.Carbon {
+	return carbon.Parse(instrument.Expiry(), carbon.UTC)
+}
+
+func (instrument *instrumentImplementation) SetExpiry(expiry string) InstrumentInterface {
+	instrument.Set(COLUMN_EXPIRY, expiry)
+	return instrument
+}
+
+// LotSize returns the minimum quantity increment of an order
+func (instrument *instrumentImplementation) LotSize() string {
+	return instrument.Get(COLUMN_LOT_SIZE)
+}
+
+func (instrument *instrumentImplementation) LotSizeFloat() float64 {
+	return cast.ToFloat64(instrument.LotSize())
+}
+
+func (instrument *instrumentImplementation) SetLotSize(lotSize string) InstrumentInterface {
+	instrument.Set(COLUMN_LOT_SIZE, lotSize)
+	return instrument
+}
+
 func (instrument *instrumentImplementation) Memo() string {
 	return instrument.Get(COLUMN_MEMO)
 }
@@ -174,6 +270,35 @@ func (instrument *instrumentImplementation) SetMetas(metas map[string]string) er
 	return nil
 }
 
+// MinNotional returns the minimum value (price times quantity) of an order
+func (instrument *instrumentImplementation) MinNotional() string {
+	return instrument.Get(COLUMN_MIN_NOTIONAL)
+}
+
+func (instrument *instrumentImplementation) MinNotionalFloat() float64 {
+	return cast.ToFloat64(instrument.MinNotional())
+}
+
+func (instrument *instrumentImplementation) SetMinNotional(minNotional string) InstrumentInterface {
+	instrument.Set(COLUMN_MIN_NOTIONAL, minNotional)
+	return instrument
+}
+
+// Multiplier returns the contract multiplier, i.e. the number of units
+// of the underlying delivered by one contract
+func (instrument *instrumentImplementation) Multiplier() string {
+	return instrument.Get(COLUMN_MULTIPLIER)
+}
+
+func (instrument *instrumentImplementation) MultiplierFloat() float64 {
+	return cast.ToFloat64(instrument.Multiplier())
+}
+
+func (instrument *instrumentImplementation) SetMultiplier(multiplier string) InstrumentInterface {
+	instrument.Set(COLUMN_MULTIPLIER, multiplier)
+	return instrument
+}
+
 func (instrument *instrumentImplementation) Name() string {
 	return instrument.Get(COLUMN_NAME)
 }
@@ -183,6 +308,80 @@ func (instrument *instrumentImplementation) SetName(name string) InstrumentInter
 	return instrument
 }
 
+// OptionType returns the option type (OPTION_TYPE_CALL or OPTION_TYPE_PUT),
+// or an empty string if the instrument is not an option
+func (instrument *instrumentImplementation) OptionType() string {
+	return instrument.Get(COLUMN_OPTION_TYPE)
+}
+
+func (instrument *instrumentImplementation) SetOptionType(optionType string) InstrumentInterface {
+	instrument.Set(COLUMN_OPTION_TYPE, optionType)
+	return instrument
+}
+
+// PriceFields returns the extended price fields stored for the instrument,
+// in addition to OHLCV
+func (instrument *instrumentImplementation) PriceFields() []string {
+	priceFields := instrument.Get(COLUMN_PRICE_FIELDS)
+	if priceFields == "" {
+		return []string{}
+	}
+	return strings.Split(priceFields, ",")
+}
+
+// SetPriceFields enables extended price fields for the instrument, any of
+// COLUMN_TRADES, COLUMN_VWAP, COLUMN_OPEN_INTEREST, COLUMN_TAKER_BUY_VOLUME,
+// COLUMN_BID_CLOSE and COLUMN_ASK_CLOSE. The columns are added to the price
+// tables by AutoMigratePrices
+func (instrument *instrumentImplementation) SetPriceFields(priceFields []string) InstrumentInterface {
+	instrument.Set(COLUMN_PRICE_FIELDS, strings.Join(priceFields, ","))
+	return instrument
+}
+
+// IsPricePrecisionSet returns true if the price precision is set on the instrument,
+// otherwise the store uses the precision of the asset class
+func (instrument *instrumentImplementation) IsPricePrecisionSet() bool {
+	return instrument.Get(COLUMN_PRICE_PRECISION) != ""
+}
+
+// PricePrecision returns the number of decimals of the instrument prices,
+// PRICE_PRECISION_DEFAULT if not set
+func (instrument *instrumentImplementation) PricePrecision() int {
+	pricePrecision := instrument.Get(COLUMN_PRICE_PRECISION)
+
+	if pricePrecision == "" {
+		return PRICE_PRECISION_DEFAULT
+	}
+
+	return cast.ToInt(pricePrecision)
+}
+
+func (instrument *instrumentImplementation) SetPricePrecision(pricePrecision int) InstrumentInterface {
+	instrument.Set(COLUMN_PRICE_PRECISION, strconv.Itoa(pricePrecision))
+	return instrument
+}
+
+// QuoteCurrency returns the currency the instrument is priced in, i.e. USDT for BTC/USDT
+func (instrument *instrumentImplementation) QuoteCurrency() string {
+	return instrument.Get(COLUMN_QUOTE_CURRENCY)
+}
+
+func (instrument *instrumentImplementation) SetQuoteCurrency(quoteCurrency string) InstrumentInterface {
+	instrument.Set(COLUMN_QUOTE_CURRENCY, quoteCurrency)
+	return instrument
+}
+
+// SettlementType returns the settlement type of a derivative
+// (SETTLEMENT_TYPE_CASH or SETTLEMENT_TYPE_PHYSICAL)
+func (instrument *instrumentImplementation) SettlementType() string {
+	return instrument.Get(COLUMN_SETTLEMENT_TYPE)
+}
+
+func (instrument *instrumentImplementation) SetSettlementType(settlementType string) InstrumentInterface {
+	instrument.Set(COLUMN_SETTLEMENT_TYPE, settlementType)
+	return instrument
+}
+
 func (instrument *instrumentImplementation) SoftDeletedAt() string {
 	return instrument.Get(COLUMN_SOFT_DELETED_AT)
 }
@@ -196,6 +395,18 @@ func (instrument *instrumentImplementation) SetSoftDeletedAt(softDeletedAt strin
 	return instrument
 }
 
+// SourceTimeframe returns the timeframe which is the source of truth for the
+// instrument prices. When set, writing a bar in this timeframe recomputes the
+// affected bars of the larger timeframes listed in Timeframes().
+func (instrument *instrumentImplementation) SourceTimeframe() string {
+	return instrument.Get(COLUMN_SOURCE_TIMEFRAME)
+}
+
+func (instrument *instrumentImplementation) SetSourceTimeframe(sourceTimeframe string) InstrumentInterface {
+	instrument.Set(COLUMN_SOURCE_TIMEFRAME, sourceTimeframe)
+	return instrument
+}
+
 func (instrument *instrumentImplementation) Status() string {
 	return instrument.Get(COLUMN_STATUS)
 }
@@ -205,6 +416,58 @@ func (instrument *instrumentImplementation) SetStatus(status string) InstrumentI
 	return instrument
 }
 
+// Strike returns the strike price of an option
+func (instrument *instrumentImplementation) Strike() string {
+	return instrument.Get(COLUMN_STRIKE)
+}
+
+func (instrument *instrumentImplementation) StrikeFloat() float64 {
+	return cast.ToFloat64(instrument.Strike())
+}
+
+func (instrument *instrumentImplementation) SetStrike(strike string) InstrumentInterface {
+	instrument.Set(COLUMN_STRIKE, strike)
+	return instrument
+}
+
+// IsSynthetic returns true if the prices of the instrument are computed
+// from the prices of other instruments, its legs
+func (instrument *instrumentImplementation) IsSynthetic() bool {
+	return instrument.Get(COLUMN_SYNTHETIC) != ""
+}
+
+// Synthetic returns the definition of a synthetic instrument,
+// an empty definition if the instrument is not synthetic
+func (instrument *instrumentImplementation) Synthetic() (SyntheticDefinition, error) {
+	return syntheticDefinitionParse(instrument.Get(COLUMN_SYNTHETIC))
+}
+
+// SetSynthetic makes the instrument synthetic, its prices are computed
+// from the legs of the definition. An empty definition clears it
+func (instrument *instrumentImplementation) SetSynthetic(definition SyntheticDefinition) error {
+	str, err := syntheticDefinitionFormat(definition)
+	if err != nil {
+		return err
+	}
+
+	instrument.Set(COLUMN_SYNTHETIC, str)
+	return nil
+}
+
+// TickSize returns the minimum price increment
+func (instrument *instrumentImplementation) TickSize() string {
+	return instrument.Get(COLUMN_TICK_SIZE)
+}
+
+func (instrument *instrumentImplementation) TickSizeFloat() float64 {
+	return cast.ToFloat64(instrument.TickSize())
+}
+
+func (instrument *instrumentImplementation) SetTickSize(tickSize string) InstrumentInterface {
+	instrument.Set(COLUMN_TICK_SIZE, tickSize)
+	return instrument
+}
+
 func (instrument *instrumentImplementation) Timeframes() []string {
 	timeframes := instrument.Get(COLUMN_TIMEFRAMES)
 	if timeframes == "" {
@@ -218,6 +481,39 @@ func (instrument *instrumentImplementation) SetTimeframes(timeframes []string) I
 	return instrument
 }
 
+// UnderlyingID returns the ID of the underlying instrument of a derivative
+func (instrument *instrumentImplementation) UnderlyingID() string {
+	return instrument.Get(COLUMN_UNDERLYING_ID)
+}
+
+func (instrument *instrumentImplementation) SetUnderlyingID(underlyingID string) InstrumentInterface {
+	instrument.Set(COLUMN_UNDERLYING_ID, underlyingID)
+	return instrument
+}
+
+// IsVolumePrecisionSet returns true if the volume precision is set on the instrument,
+// otherwise the store uses the precision of the asset class
+func (instrument *instrumentImplementation) IsVolumePrecisionSet() bool {
+	return instrument.Get(COLUMN_VOLUME_PRECISION) != ""
+}
+
+// VolumePrecision returns the number of decimals of the instrument volumes,
+// VOLUME_PRECISION_DEFAULT if not set
+func (instrument *instrumentImplementation) VolumePrecision() int {
+	volumePrecision := instrument.Get(COLUMN_VOLUME_PRECISION)
+
+	if volumePrecision == "" {
+		return VOLUME_PRECISION_DEFAULT
+	}
+
+	return cast.ToInt(volumePrecision)
+}
+
+func (instrument *instrumentImplementation) SetVolumePrecision(volumePrecision int) InstrumentInterface {
+	instrument.Set(COLUMN_VOLUME_PRECISION, strconv.Itoa(volumePrecision))
+	return instrument
+}
+
 func (instrument *instrumentImplementation) UpdatedAt() string {
 	return instrument.Get(COLUMN_UPDATED_AT)
 }
@@ -230,3 +526,29 @@ func (instrument *instrumentImplementation) SetUpdatedAt(updatedAt string) Instr
 	instrument.Set(COLUMN_UPDATED_AT, updatedAt)
 	return instrument
 }
+
+// == PRIVATE FUNCTIONS ========================================================
+
+// instrumentDecimalPlaces returns the number of decimals of a decimal string,
+// ignoring trailing zeros
+func instrumentDecimalPlaces(value string) int {
+	_, decimals, found := strings.Cut(value, ".")
+
+	if !found {
+		return 0
+	}
+
+	return len(strings.TrimRight(decimals, "0"))
+}
+
+// instrumentRound rounds the value to the given number of decimals,
+// removing the float error left by the tick and lot arithmetic
+func instrumentRound(value float64, decimals int) float64 {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(value, 'f', decimals, 64), 64)
+
+	if err != nil {
+		return value
+	}
+
+	return rounded
+}
@@ -10,20 +10,36 @@ type InstrumentInterface interface {
 
 	// methods
 
+	FormatPrice(price float64) string
+	MeetsMinNotional(price float64, quantity float64) bool
+	RoundToLot(quantity float64) float64
+	RoundToTick(price float64) float64
+
 	// setters and getters
 
 	AssetClass() string
 	SetAssetClass(assetClass string) InstrumentInterface
 
+	BaseAsset() string
+	SetBaseAsset(baseAsset string) InstrumentInterface
+
 	Exchange() string
 	SetExchange(exchange string) InstrumentInterface
 
 	Description() string
 	SetDescription(description string) InstrumentInterface
 
+	Expiry() string
+	ExpiryCarbon() *carbon.Carbon
+	SetExpiry(expiry string) InstrumentInterface
+
 	ID() string
 	SetID(id string) InstrumentInterface
 
+	LotSize() string
+	LotSizeFloat() float64
+	SetLotSize(lotSize string) InstrumentInterface
+
 	Meta(key string) (string, error)
 	SetMeta(key string, value string) error
 	DeleteMeta(key string) error
@@ -34,18 +50,64 @@ type InstrumentInterface interface {
 	Memo() string
 	SetMemo(memo string) InstrumentInterface
 
+	MinNotional() string
+	MinNotionalFloat() float64
+	SetMinNotional(minNotional string) InstrumentInterface
+
+	Multiplier() string
+	MultiplierFloat() float64
+	SetMultiplier(multiplier string) InstrumentInterface
+
 	Name() string
 	SetName(name string) InstrumentInterface
 
+	OptionType() string
+	SetOptionType(optionType string) InstrumentInterface
+
+	SettlementType() string
+	SetSettlementType(settlementType string) InstrumentInterface
+
+	PriceFields() []string
+	SetPriceFields(priceFields []string) InstrumentInterface
+
+	IsPricePrecisionSet() bool
+	PricePrecision() int
+	SetPricePrecision(pricePrecision int) InstrumentInterface
+
+	QuoteCurrency() string
+	SetQuoteCurrency(quoteCurrency string) InstrumentInterface
+
+	SourceTimeframe() string
+	SetSourceTimeframe(sourceTimeframe string) InstrumentInterface
+
 	Status() string
 	SetStatus(status string) InstrumentInterface
 
+	Strike() string
+	StrikeFloat() float64
+	SetStrike(strike string) InstrumentInterface
+
 	Symbol() string
 	SetSymbol(symbol string) InstrumentInterface
 
+	IsSynthetic() bool
+	Synthetic() (SyntheticDefinition, error)
+	SetSynthetic(definition SyntheticDefinition) error
+
+	TickSize() string
+	TickSizeFloat() float64
+	SetTickSize(tickSize string) InstrumentInterface
+
 	Timeframes() []string
 	SetTimeframes(timeframes []string) InstrumentInterface
 
+	UnderlyingID() string
+	SetUnderlyingID(underlyingID string) InstrumentInterface
+
+	IsVolumePrecisionSet() bool
+	VolumePrecision() int
+	SetVolumePrecision(volumePrecision int) InstrumentInterface
+
 	CreatedAt() string
 	CreatedAtCarbon() *carbon.Carbon
 	SetCreatedAt(createdAt string) InstrumentInterface
@@ -32,6 +32,11 @@ type instrumentQueryImplementation struct {
 	isExchangeSet bool
 	exchange      string
 
+	// expiry between
+	isExpiryBetweenSet bool
+	expiryFrom         string
+	expiryTo           string
+
 	// id
 	id      string
 	isIDSet bool
@@ -48,6 +53,10 @@ type instrumentQueryImplementation struct {
 	offset      int
 	isOffsetSet bool
 
+	// optionType
+	isOptionTypeSet bool
+	optionType      string
+
 	// orderBy
 	orderBy      string
 	isOrderBySet bool
@@ -60,6 +69,11 @@ type instrumentQueryImplementation struct {
 	isStatusSet bool
 	status      string
 
+	// strike between
+	isStrikeBetweenSet bool
+	strikeMin          float64
+	strikeMax          float64
+
 	// symbol
 	isSymbolSet bool
 	symbol      string
@@ -67,6 +81,10 @@ type instrumentQueryImplementation struct {
 	// symbolLike
 	isSymbolLikeSet bool
 	symbolLike      string
+
+	// underlyingID
+	isUnderlyingIDSet bool
+	underlyingID      string
 }
 
 var _ InstrumentQueryInterface = (*instrumentQueryImplementation)(nil) // verify interface is implemented
@@ -81,6 +99,18 @@ func (q *instrumentQueryImplementation) Validate() error {
 		return errors.New("instrument query. exchange cannot be empty")
 	}
 
+	if q.IsExpiryBetweenSet() {
+		from, to := q.ExpiryBetween()
+
+		if from == "" || to == "" {
+			return errors.New("instrument query. expiry between cannot be empty")
+		}
+
+		if from > to {
+			return errors.New("instrument query. expiry between from cannot be after to")
+		}
+	}
+
 	if q.IsIDSet() && q.ID() == "" {
 		return errors.New("instrument query. id cannot be empty")
 	}
@@ -97,6 +127,10 @@ func (q *instrumentQueryImplementation) Validate() error {
 		return errors.New("instrument query. limit cannot be negative")
 	}
 
+	if q.IsOptionTypeSet() && q.OptionType() != OPTION_TYPE_CALL && q.OptionType() != OPTION_TYPE_PUT {
+		return errors.New("instrument query. option type must be 'CALL' or 'PUT'")
+	}
+
 	if q.IsOrderBySet() && q.OrderBy() == "" {
 		return errors.New("instrument query. order by cannot be empty")
 	}
@@ -109,6 +143,14 @@ func (q *instrumentQueryImplementation) Validate() error {
 		return errors.New("instrument query. status cannot be empty")
 	}
 
+	if q.IsStrikeBetweenSet() {
+		min, max := q.StrikeBetween()
+
+		if min > max {
+			return errors.New("instrument query. strike between min cannot be greater than max")
+		}
+	}
+
 	if q.IsSymbolSet() && q.Symbol() == "" {
 		return errors.New("instrument query. symbol cannot be empty")
 	}
@@ -117,6 +159,10 @@ func (q *instrumentQueryImplementation) Validate() error {
 		return errors.New("instrument query. symbol like cannot be empty")
 	}
 
+	if q.IsUnderlyingIDSet() && q.UnderlyingID() == "" {
+		return errors.New("instrument query. underlying id cannot be empty")
+	}
+
 	return nil
 }
 
@@ -193,6 +239,27 @@ func (iq *instrumentQueryImplementation) SetExchange(exchange string) Instrument
 	return iq
 }
 
+// IsExpiryBetweenSet returns true if the expiry range is set
+func (iq *instrumentQueryImplementation) IsExpiryBetweenSet() bool {
+	return iq.isExpiryBetweenSet
+}
+
+// ExpiryBetween returns the inclusive expiry range, formatted as YYYY-MM-DD
+func (iq *instrumentQueryImplementation) ExpiryBetween() (from string, to string) {
+	if iq.IsExpiryBetweenSet() {
+		return iq.expiryFrom, iq.expiryTo
+	}
+	return "", ""
+}
+
+// SetExpiryBetween sets the inclusive expiry range, formatted as YYYY-MM-DD
+func (iq *instrumentQueryImplementation) SetExpiryBetween(from string, to string) InstrumentQueryInterface {
+	iq.expiryFrom = from
+	iq.expiryTo = to
+	iq.isExpiryBetweenSet = true
+	return iq
+}
+
 // IsIDSet returns true if the id is set
 func (iq *instrumentQueryImplementation) IsIDSet() bool {
 	return iq.isIDSet
@@ -273,6 +340,26 @@ func (iq *instrumentQueryImplementation) SetOffset(offset int) InstrumentQueryIn
 	return iq
 }
 
+// IsOptionTypeSet returns true if the option type is set
+func (iq *instrumentQueryImplementation) IsOptionTypeSet() bool {
+	return iq.isOptionTypeSet
+}
+
+// OptionType returns the option type
+func (iq *instrumentQueryImplementation) OptionType() string {
+	if iq.IsOptionTypeSet() {
+		return iq.optionType
+	}
+	return ""
+}
+
+// SetOptionType sets the option type
+func (iq *instrumentQueryImplementation) SetOptionType(optionType string) InstrumentQueryInterface {
+	iq.optionType = optionType
+	iq.isOptionTypeSet = true
+	return iq
+}
+
 // IsOrderBySet returns true if the order by is set
 func (iq *instrumentQueryImplementation) IsOrderBySet() bool {
 	return iq.isOrderBySet
@@ -333,6 +420,27 @@ func (iq *instrumentQueryImplementation) SetStatus(status string) InstrumentQuer
 	return iq
 }
 
+// IsStrikeBetweenSet returns true if the strike range is set
+func (iq *instrumentQueryImplementation) IsStrikeBetweenSet() bool {
+	return iq.isStrikeBetweenSet
+}
+
+// StrikeBetween returns the inclusive strike range
+func (iq *instrumentQueryImplementation) StrikeBetween() (min float64, max float64) {
+	if iq.IsStrikeBetweenSet() {
+		return iq.strikeMin, iq.strikeMax
+	}
+	return 0, 0
+}
+
+// SetStrikeBetween sets the inclusive strike range
+func (iq *instrumentQueryImplementation) SetStrikeBetween(min float64, max float64) InstrumentQueryInterface {
+	iq.strikeMin = min
+	iq.strikeMax = max
+	iq.isStrikeBetweenSet = true
+	return iq
+}
+
 // IsSymbolSet returns true if the symbol is set
 func (iq *instrumentQueryImplementation) IsSymbolSet() bool {
 	return iq.isSymbolSet
@@ -372,3 +480,23 @@ func (iq *instrumentQueryImplementation) SetSymbolLike(symbolLike string) Instru
 	iq.isSymbolLikeSet = true
 	return iq
 }
+
+// IsUnderlyingIDSet returns true if the underlying id is set
+func (iq *instrumentQueryImplementation) IsUnderlyingIDSet() bool {
+	return iq.isUnderlyingIDSet
+}
+
+// UnderlyingID returns the underlying id
+func (iq *instrumentQueryImplementation) UnderlyingID() string {
+	if iq.IsUnderlyingIDSet() {
+		return iq.underlyingID
+	}
+	return ""
+}
+
+// SetUnderlyingID sets the underlying id
+func (iq *instrumentQueryImplementation) SetUnderlyingID(underlyingID string) InstrumentQueryInterface {
+	iq.underlyingID = underlyingID
+	iq.isUnderlyingIDSet = true
+	return iq
+}
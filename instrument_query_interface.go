@@ -23,6 +23,11 @@ type InstrumentQueryInterface interface {
 	SetCountOnly(countOnly bool) InstrumentQueryInterface
 	IsCountOnly() bool
 
+	// Expiry Between
+	IsExpiryBetweenSet() bool
+	ExpiryBetween() (from string, to string)
+	SetExpiryBetween(from string, to string) InstrumentQueryInterface
+
 	// ID
 	SetID(id string) InstrumentQueryInterface
 	IsIDSet() bool
@@ -42,6 +47,11 @@ type InstrumentQueryInterface interface {
 	Offset() int
 	SetOffset(offset int) InstrumentQueryInterface
 
+	// Option Type
+	IsOptionTypeSet() bool
+	OptionType() string
+	SetOptionType(optionType string) InstrumentQueryInterface
+
 	// Order By
 	IsOrderBySet() bool
 	OrderBy() string
@@ -57,6 +67,11 @@ type InstrumentQueryInterface interface {
 	IsStatusSet() bool
 	Status() string
 
+	// Strike Between
+	IsStrikeBetweenSet() bool
+	StrikeBetween() (min float64, max float64)
+	SetStrikeBetween(min float64, max float64) InstrumentQueryInterface
+
 	// Symbol
 	IsSymbolSet() bool
 	Symbol() string
@@ -66,4 +81,9 @@ type InstrumentQueryInterface interface {
 	IsSymbolLikeSet() bool
 	SymbolLike() string
 	SetSymbolLike(symbolLike string) InstrumentQueryInterface
+
+	// Underlying ID
+	IsUnderlyingIDSet() bool
+	UnderlyingID() string
+	SetUnderlyingID(underlyingID string) InstrumentQueryInterface
 }
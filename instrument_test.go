@@ -373,3 +373,48 @@ func TestInstrumentMetaMethods(t *testing.T) {
 		t.Fatalf("Metas() mismatch after deleting non-existent key: expected %v, got %v", expectedAfterDelete, metas)
 	}
 }
+
+func TestInstrumentTradingSpecification(t *testing.T) {
+	instrument := tradingstore.NewInstrument().
+		SetBaseAsset("BTC").
+		SetQuoteCurrency("USDT").
+		SetTickSize("0.05").
+		SetLotSize("0.001").
+		SetMinNotional("10").
+		SetPricePrecision(2)
+
+	if got := instrument.BaseAsset(); got != "BTC" {
+		t.Errorf("SetBaseAsset/BaseAsset failed: expected %q, got %q", "BTC", got)
+	}
+	if got := instrument.QuoteCurrency(); got != "USDT" {
+		t.Errorf("SetQuoteCurrency/QuoteCurrency failed: expected %q, got %q", "USDT", got)
+	}
+	if got := instrument.PricePrecision(); got != 2 {
+		t.Errorf("SetPricePrecision/PricePrecision failed: expected 2, got %d", got)
+	}
+	if got := tradingstore.NewInstrumentFromExistingData(map[string]string{}).PricePrecision(); got != tradingstore.PRICE_PRECISION_DEFAULT {
+		t.Errorf("PricePrecision should default to %d, got %d", tradingstore.PRICE_PRECISION_DEFAULT, got)
+	}
+
+	if got := instrument.FormatPrice(123.456); got != "123.46" {
+		t.Errorf("FormatPrice failed: expected %q, got %q", "123.46", got)
+	}
+	if got := instrument.RoundToTick(100.12); got != 100.1 {
+		t.Errorf("RoundToTick failed: expected 100.1, got %v", got)
+	}
+	if got := instrument.RoundToTick(100.13); got != 100.15 {
+		t.Errorf("RoundToTick failed: expected 100.15, got %v", got)
+	}
+	if got := instrument.RoundToLot(0.0129); got != 0.012 {
+		t.Errorf("RoundToLot failed: expected 0.012, got %v", got)
+	}
+	if got := instrument.RoundToLot(0.3); got != 0.3 {
+		t.Errorf("RoundToLot failed: expected 0.3, got %v", got)
+	}
+	if instrument.MeetsMinNotional(100, 0.09) {
+		t.Error("MeetsMinNotional should be false for a notional of 9")
+	}
+	if !instrument.MeetsMinNotional(100, 0.1) {
+		t.Error("MeetsMinNotional should be true for a notional of 10")
+	}
+}
@@ -0,0 +1,173 @@
+package tradingstore
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// keysetItem is a stored row which can be iterated with keyset pagination
+type keysetItem interface {
+	ID() string
+	Time() string
+}
+
+// keysetIteratorOptions are the query options checked before iterating
+type keysetIteratorOptions interface {
+	IsColumnsSet() bool
+	Columns() []string
+	IsLimitSet() bool
+	Limit() int
+	IsOffsetSet() bool
+	IsOrderBySet() bool
+	OrderBy() string
+	IsOrderDirectionSet() bool
+	OrderDirection() string
+}
+
+// keysetIteratorOptionsCheck returns an error if the query options can not
+// be iterated, i.e. set an offset or an order other than ascending time.
+// The name is the name of the iterated rows, used in the errors.
+func keysetIteratorOptionsCheck(name string, options keysetIteratorOptions) error {
+	if options.IsOffsetSet() {
+		return errors.New(name + " iterator: offset is not supported")
+	}
+
+	if options.IsOrderBySet() && !strings.EqualFold(options.OrderBy(), COLUMN_TIME) {
+		return errors.New(name + " iterator: only ordering by time is supported")
+	}
+
+	if options.IsOrderDirectionSet() && !strings.EqualFold(options.OrderDirection(), "asc") {
+		return errors.New(name + " iterator: only ascending order is supported")
+	}
+
+	if options.IsColumnsSet() && len(options.Columns()) > 0 {
+		columns := options.Columns()
+
+		if !lo.Contains(columns, COLUMN_ID) || !lo.Contains(columns, COLUMN_TIME) {
+			return errors.New(name + " iterator: columns must include id and time")
+		}
+	}
+
+	return nil
+}
+
+// keysetIterator iterates over stored rows in ascending time order using
+// keyset pagination on the time, so large series are never loaded at once.
+// It is shared by the price, tick and quote iterators.
+type keysetIterator[T keysetItem] struct {
+	// load lists up to limit rows in ascending time order, from timeGte,
+	// or from the start of the query options if timeGte is empty
+	load      func(timeGte string, limit int) ([]T, error)
+	batchSize int
+
+	batch     []T
+	index     int
+	current   T
+	remaining int // -1 for no limit
+	exhausted bool
+	closed    bool
+	err       error
+
+	// lastTime and lastIDs track the keyset position,
+	// lastIDs are the ids already returned at lastTime
+	lastTime string
+	lastIDs  map[string]bool
+}
+
+// newKeysetIterator creates a keyset iterator loading the rows in batches,
+// limit caps the total number of rows, -1 for no limit
+func newKeysetIterator[T keysetItem](batchSize int, limit int, load func(timeGte string, limit int) ([]T, error)) *keysetIterator[T] {
+	return &keysetIterator[T]{
+		load:      load,
+		batchSize: batchSize,
+		remaining: limit,
+		lastIDs:   map[string]bool{},
+	}
+}
+
+func (it *keysetIterator[T]) Next() bool {
+	var zero T
+
+	if it.closed || it.err != nil {
+		return false
+	}
+
+	if it.remaining == 0 {
+		return false
+	}
+
+	for it.index >= len(it.batch) {
+		if it.exhausted {
+			it.current = zero
+			return false
+		}
+
+		if err := it.loadBatch(); err != nil {
+			it.err = err
+			it.current = zero
+			return false
+		}
+	}
+
+	it.current = it.batch[it.index]
+	it.index++
+
+	if it.remaining > 0 {
+		it.remaining--
+	}
+
+	return true
+}
+
+func (it *keysetIterator[T]) Err() error {
+	return it.err
+}
+
+func (it *keysetIterator[T]) Close() error {
+	var zero T
+
+	it.closed = true
+	it.batch = nil
+	it.current = zero
+	return nil
+}
+
+// loadBatch loads the next batch of rows after the keyset position
+func (it *keysetIterator[T]) loadBatch() error {
+	limit := it.batchSize + len(it.lastIDs)
+
+	list, err := it.load(it.lastTime, limit)
+
+	if err != nil {
+		return err
+	}
+
+	if len(list) < limit {
+		it.exhausted = true
+	}
+
+	batch := []T{}
+
+	for _, item := range list {
+		itemTime := item.Time()
+
+		if itemTime == it.lastTime && it.lastIDs[item.ID()] {
+			continue // already returned in a previous batch
+		}
+
+		if itemTime != it.lastTime {
+			it.lastTime = itemTime
+			it.lastIDs = map[string]bool{}
+		}
+
+		it.lastIDs[item.ID()] = true
+		batch = append(batch, item)
+	}
+
+	it.batch = batch
+	it.index = 0
+
+	return nil
+}
@@ -16,6 +16,49 @@ type NewStoreOptions struct {
 	// InstrumentTableName is the name of the instrument table
 	InstrumentTableName string
 
+	// ExchangeCalendarTableName is the name of the exchange calendar table
+	// optional, defaults to "exchange_calendar"
+	ExchangeCalendarTableName string
+
+	// ContinuousContractTableName is the name of the continuous contract table
+	// optional, defaults to "continuous_contract"
+	ContinuousContractTableName string
+
+	// CorporateActionTableName is the name of the corporate action table
+	// optional, defaults to "corporate_action"
+	CorporateActionTableName string
+
+	// IndicatorTableNamePrefix is the prefix of the indicator tables, one per price series
+	// optional, indicator persistence is disabled when empty
+	IndicatorTableNamePrefix string
+
+	// TickTableNamePrefix is the prefix of the tick tables, one per instrument
+	// optional, tick storage is disabled when empty
+	TickTableNamePrefix string
+
+	// OrderBookTableNamePrefix is the prefix of the order book snapshot tables, one per instrument
+	// optional, order book storage is disabled when empty
+	OrderBookTableNamePrefix string
+
+	// OrderBookLevels is the number of top levels kept per side of the stored order book snapshots
+	// optional, all the levels are kept when 0
+	OrderBookLevels int
+
+	// QuoteTableNamePrefix is the prefix of the quote tables, one per instrument
+	// optional, quote storage is disabled when empty
+	QuoteTableNamePrefix string
+
+	// PriceColumnPrecisions are the decimals of the price table columns per asset class,
+	// used for the instruments which do not set their own price or volume precision
+	// optional, defaults to PRICE_PRECISION_DEFAULT and VOLUME_PRECISION_DEFAULT
+	PriceColumnPrecisions map[string]PriceColumnPrecision
+
+	// PriceTimeStorage is the format of the time column of the price tables,
+	// PRICE_TIME_STORAGE_DATETIME or PRICE_TIME_STORAGE_EPOCH_MILLIS. Existing
+	// price tables are converted to a changed format by MigratePriceTables
+	// optional, defaults to PRICE_TIME_STORAGE_DATETIME
+	PriceTimeStorage string
+
 	// UseMultipleExchanges is used to create a new price table for each exchange
 	// if false, the price table will be created without the exchange name as the table name (i.e. price_btc_usdt)
 	// if true, the price table will be created with the exchange name as the table name (i.e. price_btc_binance_usdt)
@@ -35,6 +78,13 @@ type NewStoreOptions struct {
 	DebugEnabled bool
 }
 
+// PriceColumnPrecision defines the number of decimals of the price columns
+// (open, high, low, close) and of the volume column of a price table
+type PriceColumnPrecision struct {
+	Price  int
+	Volume int
+}
+
 // NewStore creates a new trading store
 func NewStore(opts NewStoreOptions) (StoreInterface, error) {
 	if opts.PriceTableNamePrefix == "" {
@@ -49,18 +99,62 @@ func NewStore(opts NewStoreOptions) (StoreInterface, error) {
 		return nil, errors.New("trading store: DB is required")
 	}
 
+	if opts.ExchangeCalendarTableName == "" {
+		opts.ExchangeCalendarTableName = "exchange_calendar"
+	}
+
+	if opts.ContinuousContractTableName == "" {
+		opts.ContinuousContractTableName = "continuous_contract"
+	}
+
+	if opts.CorporateActionTableName == "" {
+		opts.CorporateActionTableName = "corporate_action"
+	}
+
+	if opts.PriceTimeStorage == "" {
+		opts.PriceTimeStorage = PRICE_TIME_STORAGE_DATETIME
+	}
+
+	if opts.PriceTimeStorage != PRICE_TIME_STORAGE_DATETIME && opts.PriceTimeStorage != PRICE_TIME_STORAGE_EPOCH_MILLIS {
+		return nil, errors.New("trading store: PriceTimeStorage is not supported: " + opts.PriceTimeStorage)
+	}
+
+	if opts.OrderBookLevels < 0 {
+		return nil, errors.New("trading store: OrderBookLevels must be greater than or equal to 0")
+	}
+
+	for assetClass, precision := range opts.PriceColumnPrecisions {
+		if precision.Price < 0 || precision.Price > PRICE_PRECISION_MAX {
+			return nil, errors.New("trading store: PriceColumnPrecisions price precision of " + assetClass + " is out of range")
+		}
+
+		if precision.Volume < 0 || precision.Volume > VOLUME_PRECISION_MAX {
+			return nil, errors.New("trading store: PriceColumnPrecisions volume precision of " + assetClass + " is out of range")
+		}
+	}
+
 	if opts.DbDriverName == "" {
 		opts.DbDriverName = sb.DatabaseDriverName(opts.DB)
 	}
 
 	store := &Store{
-		priceTableNamePrefix: opts.PriceTableNamePrefix,
-		instrumentTableName:  opts.InstrumentTableName,
-		useMultipleExchanges: opts.UseMultipleExchanges,
-		automigrateEnabled:   opts.AutomigrateEnabled,
-		db:                   opts.DB,
-		dbDriverName:         opts.DbDriverName,
-		debugEnabled:         opts.DebugEnabled,
+		priceTableNamePrefix:        opts.PriceTableNamePrefix,
+		instrumentTableName:         opts.InstrumentTableName,
+		exchangeCalendarTableName:   opts.ExchangeCalendarTableName,
+		continuousContractTableName: opts.ContinuousContractTableName,
+		corporateActionTableName:    opts.CorporateActionTableName,
+		indicatorTableNamePrefix:    opts.IndicatorTableNamePrefix,
+		tickTableNamePrefix:         opts.TickTableNamePrefix,
+		orderBookTableNamePrefix:    opts.OrderBookTableNamePrefix,
+		quoteTableNamePrefix:        opts.QuoteTableNamePrefix,
+		orderBookLevels:             opts.OrderBookLevels,
+		priceColumnPrecisions:       opts.PriceColumnPrecisions,
+		priceTimeStorage:            opts.PriceTimeStorage,
+		useMultipleExchanges:        opts.UseMultipleExchanges,
+		automigrateEnabled:          opts.AutomigrateEnabled,
+		db:                          opts.DB,
+		dbDriverName:                opts.DbDriverName,
+		debugEnabled:                opts.DebugEnabled,
 	}
 
 	if store.automigrateEnabled {
@@ -69,6 +163,24 @@ func NewStore(opts NewStoreOptions) (StoreInterface, error) {
 		if err != nil {
 			return nil, err
 		}
+
+		err = store.AutoMigrateExchangeCalendars(context.Background())
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = store.AutoMigrateCorporateActions(context.Background())
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = store.AutoMigrateContinuousContracts(context.Background())
+
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return store, nil
@@ -0,0 +1,92 @@
+package tradingstore
+
+// OrderBookSpreadPoint is the top of the book of a snapshot
+type OrderBookSpreadPoint struct {
+	Time    string
+	BestBid Decimal
+	BestAsk Decimal
+	Spread  Decimal
+	Mid     Decimal
+}
+
+// OrderBookDepthPoint is the depth of both sides of a snapshot
+type OrderBookDepthPoint struct {
+	Time     string
+	BidDepth Decimal
+	AskDepth Decimal
+
+	// Imbalance is (bid depth - ask depth) / (bid depth + ask depth),
+	// between -1 and 1, 0 if both sides are empty
+	Imbalance Decimal
+}
+
+// orderBookImbalanceDecimals is the number of decimals of the depth imbalance
+const orderBookImbalanceDecimals = 6
+
+// OrderBookSpreadSeries returns the spread series of the snapshots, in the
+// order of the snapshots. Snapshots with an empty side are skipped.
+//
+// Parameters:
+// - snapshots: the snapshots, usually sorted by time ascending
+//
+// Returns:
+// - []OrderBookSpreadPoint: the best bid, best ask, spread and mid of each snapshot
+func OrderBookSpreadSeries(snapshots []OrderBookSnapshotInterface) []OrderBookSpreadPoint {
+	points := []OrderBookSpreadPoint{}
+
+	for _, snapshot := range snapshots {
+		bid, bidFound := snapshot.BestBid()
+		ask, askFound := snapshot.BestAsk()
+
+		if !bidFound || !askFound {
+			continue
+		}
+
+		spread, _ := snapshot.Spread()
+		mid, _ := snapshot.Mid()
+
+		points = append(points, OrderBookSpreadPoint{
+			Time:    snapshot.Time(),
+			BestBid: bid.Price,
+			BestAsk: ask.Price,
+			Spread:  spread,
+			Mid:     mid,
+		})
+	}
+
+	return points
+}
+
+// OrderBookDepthSeries returns the depth series of the snapshots, in the
+// order of the snapshots.
+//
+// Parameters:
+// - snapshots: the snapshots, usually sorted by time ascending
+// - levels: the number of top levels summed per side, 0 for all the levels
+//
+// Returns:
+// - []OrderBookDepthPoint: the bid depth, ask depth and imbalance of each snapshot
+func OrderBookDepthSeries(snapshots []OrderBookSnapshotInterface, levels int) []OrderBookDepthPoint {
+	points := []OrderBookDepthPoint{}
+
+	for _, snapshot := range snapshots {
+		bidDepth := snapshot.BidDepth(levels)
+		askDepth := snapshot.AskDepth(levels)
+		total := bidDepth.Add(askDepth)
+
+		imbalance := Decimal{}
+
+		if !total.IsZero() {
+			imbalance = bidDepth.Sub(askDepth).Div(total, orderBookImbalanceDecimals)
+		}
+
+		points = append(points, OrderBookDepthPoint{
+			Time:      snapshot.Time(),
+			BidDepth:  bidDepth,
+			AskDepth:  askDepth,
+			Imbalance: imbalance,
+		})
+	}
+
+	return points
+}
@@ -0,0 +1,244 @@
+package tradingstore
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dracory/dataobject"
+	"github.com/dracory/uid"
+	"github.com/dromara/carbon/v2"
+)
+
+// OrderBookLevel is a price level of an order book side
+type OrderBookLevel struct {
+	Price Decimal
+	Size  Decimal
+}
+
+// == CLASS ====================================================================
+
+// orderBookSnapshotImplementation represents the top levels of the order book
+// of an instrument at a point in time
+type orderBookSnapshotImplementation struct {
+	dataobject.DataObject
+}
+
+// == CONSTRUCTORS =============================================================
+
+func NewOrderBookSnapshot() OrderBookSnapshotInterface {
+	o := (&orderBookSnapshotImplementation{}).
+		SetID(uid.HumanUid())
+
+	o.SetBids([]OrderBookLevel{})
+	o.SetAsks([]OrderBookLevel{})
+
+	return o
+}
+
+func NewOrderBookSnapshotFromExistingData(data map[string]string) OrderBookSnapshotInterface {
+	o := &orderBookSnapshotImplementation{}
+	o.Hydrate(data)
+	return o
+}
+
+// == METHODS ==================================================================
+
+// BestAsk returns the lowest ask, false if there are no asks
+func (snapshot *orderBookSnapshotImplementation) BestAsk() (OrderBookLevel, bool) {
+	asks := snapshot.Asks()
+
+	if len(asks) < 1 {
+		return OrderBookLevel{}, false
+	}
+
+	return asks[0], true
+}
+
+// BestBid returns the highest bid, false if there are no bids
+func (snapshot *orderBookSnapshotImplementation) BestBid() (OrderBookLevel, bool) {
+	bids := snapshot.Bids()
+
+	if len(bids) < 1 {
+		return OrderBookLevel{}, false
+	}
+
+	return bids[0], true
+}
+
+// AskDepth returns the total size of the top levels of the asks,
+// all the levels if levels is 0
+func (snapshot *orderBookSnapshotImplementation) AskDepth(levels int) Decimal {
+	return orderBookDepth(snapshot.Asks(), levels)
+}
+
+// BidDepth returns the total size of the top levels of the bids,
+// all the levels if levels is 0
+func (snapshot *orderBookSnapshotImplementation) BidDepth(levels int) Decimal {
+	return orderBookDepth(snapshot.Bids(), levels)
+}
+
+// Mid returns the midpoint of the best bid and the best ask,
+// false if a side is empty
+func (snapshot *orderBookSnapshotImplementation) Mid() (Decimal, bool) {
+	bid, bidFound := snapshot.BestBid()
+	ask, askFound := snapshot.BestAsk()
+
+	if !bidFound || !askFound {
+		return Decimal{}, false
+	}
+
+	scale := max(bid.Price.Scale(), ask.Price.Scale()) + 1
+
+	return bid.Price.Add(ask.Price).Div(NewDecimal(2, 0), scale), true
+}
+
+// Spread returns the best ask minus the best bid, false if a side is empty
+func (snapshot *orderBookSnapshotImplementation) Spread() (Decimal, bool) {
+	bid, bidFound := snapshot.BestBid()
+	ask, askFound := snapshot.BestAsk()
+
+	if !bidFound || !askFound {
+		return Decimal{}, false
+	}
+
+	return ask.Price.Sub(bid.Price), true
+}
+
+// == SETTERS & GETTERS ========================================================
+
+// Asks returns the ask levels, sorted by price ascending
+func (snapshot *orderBookSnapshotImplementation) Asks() []OrderBookLevel {
+	levels, _ := orderBookLevelsParse(snapshot.Get(COLUMN_ASKS))
+	return levels
+}
+
+// SetAsks sets the ask levels, which are sorted by price ascending
+func (snapshot *orderBookSnapshotImplementation) SetAsks(asks []OrderBookLevel) OrderBookSnapshotInterface {
+	asks = append([]OrderBookLevel{}, asks...)
+
+	sort.SliceStable(asks, func(i, j int) bool {
+		return asks[i].Price.Cmp(asks[j].Price) < 0
+	})
+
+	snapshot.Set(COLUMN_ASKS, orderBookLevelsFormat(asks))
+	return snapshot
+}
+
+// Bids returns the bid levels, sorted by price descending
+func (snapshot *orderBookSnapshotImplementation) Bids() []OrderBookLevel {
+	levels, _ := orderBookLevelsParse(snapshot.Get(COLUMN_BIDS))
+	return levels
+}
+
+// SetBids sets the bid levels, which are sorted by price descending
+func (snapshot *orderBookSnapshotImplementation) SetBids(bids []OrderBookLevel) OrderBookSnapshotInterface {
+	bids = append([]OrderBookLevel{}, bids...)
+
+	sort.SliceStable(bids, func(i, j int) bool {
+		return bids[i].Price.Cmp(bids[j].Price) > 0
+	})
+
+	snapshot.Set(COLUMN_BIDS, orderBookLevelsFormat(bids))
+	return snapshot
+}
+
+func (snapshot *orderBookSnapshotImplementation) ID() string {
+	return snapshot.Get(COLUMN_ID)
+}
+
+func (snapshot *orderBookSnapshotImplementation) SetID(id string) OrderBookSnapshotInterface {
+	snapshot.Set(COLUMN_ID, id)
+	return snapshot
+}
+
+// Time returns the time of the snapshot as ISO8601 in UTC, with microseconds
+// if there are any (i.e. 2024-01-02T15:04:05.123456Z)
+func (snapshot *orderBookSnapshotImplementation) Time() string {
+	return snapshot.Get(COLUMN_TIME)
+}
+
+func (snapshot *orderBookSnapshotImplementation) TimeCarbon() *carbon.Carbon {
+	return carbon.Parse(snapshot.Time(), carbon.UTC)
+}
+
+// TimeT returns the time of the snapshot as a time.Time in UTC
+func (snapshot *orderBookSnapshotImplementation) TimeT() time.Time {
+	return snapshot.TimeCarbon().StdTime().UTC()
+}
+
+// SetTime sets the time of the snapshot, must be in UTC.
+// The time is truncated to microseconds.
+func (snapshot *orderBookSnapshotImplementation) SetTime(timeUtc string) OrderBookSnapshotInterface {
+	return snapshot.SetTimeT(carbon.Parse(timeUtc, carbon.UTC).StdTime())
+}
+
+// SetTimeT sets the time of the snapshot from a time.Time in any location.
+// The time is truncated to microseconds and stored in UTC.
+func (snapshot *orderBookSnapshotImplementation) SetTimeT(t time.Time) OrderBookSnapshotInterface {
+	snapshot.Set(COLUMN_TIME, tickTimeFormat(t))
+	return snapshot
+}
+
+// == PRIVATE FUNCTIONS ========================================================
+
+// orderBookDepth returns the total size of the top levels, all if levels is 0
+func orderBookDepth(sideLevels []OrderBookLevel, levels int) Decimal {
+	depth := Decimal{}
+
+	for index, level := range sideLevels {
+		if levels > 0 && index >= levels {
+			break
+		}
+
+		depth = depth.Add(level.Size)
+	}
+
+	return depth
+}
+
+// orderBookLevelsFormat formats levels compactly as price:size pairs
+// separated by commas (i.e. 42000.5:1.2,41999:0.35)
+func orderBookLevelsFormat(levels []OrderBookLevel) string {
+	pairs := make([]string, 0, len(levels))
+
+	for _, level := range levels {
+		pairs = append(pairs, level.Price.String()+":"+level.Size.String())
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// orderBookLevelsParse parses levels formatted by orderBookLevelsFormat
+func orderBookLevelsParse(value string) ([]OrderBookLevel, error) {
+	levels := []OrderBookLevel{}
+
+	if value == "" {
+		return levels, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		priceStr, sizeStr, found := strings.Cut(pair, ":")
+
+		if !found {
+			return levels, errors.New("order book level is not a price:size pair: " + pair)
+		}
+
+		price, err := NewDecimalFromString(priceStr)
+
+		if err != nil {
+			return levels, errors.New("order book level price must be a number: " + priceStr)
+		}
+
+		size, err := NewDecimalFromString(sizeStr)
+
+		if err != nil {
+			return levels, errors.New("order book level size must be a number: " + sizeStr)
+		}
+
+		levels = append(levels, OrderBookLevel{Price: price, Size: size})
+	}
+
+	return levels, nil
+}
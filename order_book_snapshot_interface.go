@@ -0,0 +1,41 @@
+package tradingstore
+
+import (
+	"time"
+
+	"github.com/dromara/carbon/v2"
+)
+
+type OrderBookSnapshotInterface interface {
+	// from dataobject
+
+	Data() map[string]string
+	DataChanged() map[string]string
+	MarkAsNotDirty()
+
+	// methods
+
+	AskDepth(levels int) Decimal
+	BestAsk() (OrderBookLevel, bool)
+	BestBid() (OrderBookLevel, bool)
+	BidDepth(levels int) Decimal
+	Mid() (Decimal, bool)
+	Spread() (Decimal, bool)
+
+	// setters and getters
+
+	Asks() []OrderBookLevel
+	SetAsks(asks []OrderBookLevel) OrderBookSnapshotInterface
+
+	Bids() []OrderBookLevel
+	SetBids(bids []OrderBookLevel) OrderBookSnapshotInterface
+
+	ID() string
+	SetID(id string) OrderBookSnapshotInterface
+
+	Time() string
+	TimeCarbon() *carbon.Carbon
+	TimeT() time.Time
+	SetTime(time string) OrderBookSnapshotInterface
+	SetTimeT(t time.Time) OrderBookSnapshotInterface
+}
@@ -0,0 +1,104 @@
+package tradingstore
+
+import "time"
+
+// OrderBookSnapshotQuery is a shortcut for NewOrderBookSnapshotQuery
+func OrderBookSnapshotQuery() OrderBookSnapshotQueryInterface {
+	return NewOrderBookSnapshotQuery()
+}
+
+// NewOrderBookSnapshotQuery creates a new order book snapshot query
+func NewOrderBookSnapshotQuery() OrderBookSnapshotQueryInterface {
+	return &orderBookSnapshotQueryImplementation{
+		timeRangeQuery: newTimeRangeQuery(),
+	}
+}
+
+type orderBookSnapshotQueryImplementation struct {
+	timeRangeQuery
+}
+
+func (c *orderBookSnapshotQueryImplementation) Validate() error {
+	return c.validate("order book snapshot query")
+}
+
+func (c *orderBookSnapshotQueryImplementation) SetColumns(columns []string) OrderBookSnapshotQueryInterface {
+	c.properties["columns"] = columns
+
+	return c
+}
+
+func (c *orderBookSnapshotQueryImplementation) SetCountOnly(countOnly bool) OrderBookSnapshotQueryInterface {
+	c.properties["count_only"] = countOnly
+
+	return c
+}
+
+func (c *orderBookSnapshotQueryImplementation) SetID(id string) OrderBookSnapshotQueryInterface {
+	c.properties["id"] = id
+
+	return c
+}
+
+func (c *orderBookSnapshotQueryImplementation) SetIDIn(idIn []string) OrderBookSnapshotQueryInterface {
+	c.properties["id_in"] = idIn
+
+	return c
+}
+
+func (c *orderBookSnapshotQueryImplementation) SetLimit(limit int) OrderBookSnapshotQueryInterface {
+	c.properties["limit"] = limit
+
+	return c
+}
+
+func (c *orderBookSnapshotQueryImplementation) SetOffset(offset int) OrderBookSnapshotQueryInterface {
+	c.properties["offset"] = offset
+
+	return c
+}
+
+func (c *orderBookSnapshotQueryImplementation) SetOrderBy(orderBy string) OrderBookSnapshotQueryInterface {
+	c.properties["order_by"] = orderBy
+
+	return c
+}
+
+func (c *orderBookSnapshotQueryImplementation) SetOrderDirection(orderDirection string) OrderBookSnapshotQueryInterface {
+	c.properties["order_direction"] = orderDirection
+
+	return c
+}
+
+func (c *orderBookSnapshotQueryImplementation) SetTime(time string) OrderBookSnapshotQueryInterface {
+	c.properties["time"] = time
+
+	return c
+}
+
+// SetTimeT sets the time to match, as a time.Time in any location
+func (c *orderBookSnapshotQueryImplementation) SetTimeT(t time.Time) OrderBookSnapshotQueryInterface {
+	return c.SetTime(tickTimeFormat(t))
+}
+
+func (c *orderBookSnapshotQueryImplementation) SetTimeGte(timeGte string) OrderBookSnapshotQueryInterface {
+	c.properties["time_gte"] = timeGte
+
+	return c
+}
+
+// SetTimeGteT sets the inclusive lower time bound, as a time.Time in any location
+func (c *orderBookSnapshotQueryImplementation) SetTimeGteT(t time.Time) OrderBookSnapshotQueryInterface {
+	return c.SetTimeGte(tickTimeFormat(t))
+}
+
+func (c *orderBookSnapshotQueryImplementation) SetTimeLte(timeLte string) OrderBookSnapshotQueryInterface {
+	c.properties["time_lte"] = timeLte
+
+	return c
+}
+
+// SetTimeLteT sets the inclusive upper time bound, as a time.Time in any location
+func (c *orderBookSnapshotQueryImplementation) SetTimeLteT(t time.Time) OrderBookSnapshotQueryInterface {
+	return c.SetTimeLte(tickTimeFormat(t))
+}
@@ -0,0 +1,56 @@
+package tradingstore
+
+import "time"
+
+type OrderBookSnapshotQueryInterface interface {
+	Validate() error
+
+	IsColumnsSet() bool
+	Columns() []string
+	SetColumns(columns []string) OrderBookSnapshotQueryInterface
+
+	IsCountOnlySet() bool
+	IsCountOnly() bool
+	SetCountOnly(countOnly bool) OrderBookSnapshotQueryInterface
+
+	IsTimeSet() bool
+	Time() string
+	SetTime(createdAt string) OrderBookSnapshotQueryInterface
+	SetTimeT(t time.Time) OrderBookSnapshotQueryInterface
+
+	IsTimeGteSet() bool
+	TimeGte() string
+	SetTimeGte(createdAtGte string) OrderBookSnapshotQueryInterface
+	SetTimeGteT(t time.Time) OrderBookSnapshotQueryInterface
+
+	IsTimeLteSet() bool
+	TimeLte() string
+	SetTimeLte(createdAtLte string) OrderBookSnapshotQueryInterface
+	SetTimeLteT(t time.Time) OrderBookSnapshotQueryInterface
+
+	IsIDSet() bool
+	ID() string
+	SetID(id string) OrderBookSnapshotQueryInterface
+
+	IsIDInSet() bool
+	IDIn() []string
+	SetIDIn(idIn []string) OrderBookSnapshotQueryInterface
+
+	IsLimitSet() bool
+	Limit() int
+	SetLimit(limit int) OrderBookSnapshotQueryInterface
+
+	IsOffsetSet() bool
+	Offset() int
+	SetOffset(offset int) OrderBookSnapshotQueryInterface
+
+	IsOrderBySet() bool
+	OrderBy() string
+	SetOrderBy(orderBy string) OrderBookSnapshotQueryInterface
+
+	IsOrderDirectionSet() bool
+	OrderDirection() string
+	SetOrderDirection(orderDirection string) OrderBookSnapshotQueryInterface
+
+	hasProperty(name string) bool
+}
@@ -1,6 +1,8 @@
 package tradingstore
 
 import (
+	"time"
+
 	"github.com/dracory/dataobject"
 	"github.com/dracory/uid"
 	"github.com/dromara/carbon/v2"
@@ -33,6 +35,52 @@ func NewPriceFromExistingData(data map[string]string) PriceInterface {
 
 // == SETTERS & GETTERS ========================================================
 
+// AskClose returns the close of the best ask, empty if the bar has none
+func (price *Price) AskClose() string {
+	return price.Get(COLUMN_ASK_CLOSE)
+}
+
+func (price *Price) AskCloseFloat() float64 {
+	return cast.ToFloat64(price.AskClose())
+}
+
+// AskCloseDecimal returns the close of the best ask as an exact decimal, 0 if it is not a number
+func (price *Price) AskCloseDecimal() Decimal {
+	return priceDecimal(price.AskClose())
+}
+
+func (price *Price) SetAskCloseDecimal(askClose Decimal) PriceInterface {
+	return price.SetAskClose(askClose.String())
+}
+
+func (price *Price) SetAskClose(askClose string) PriceInterface {
+	price.Set(COLUMN_ASK_CLOSE, askClose)
+	return price
+}
+
+// BidClose returns the close of the best bid, empty if the bar has none
+func (price *Price) BidClose() string {
+	return price.Get(COLUMN_BID_CLOSE)
+}
+
+func (price *Price) BidCloseFloat() float64 {
+	return cast.ToFloat64(price.BidClose())
+}
+
+// BidCloseDecimal returns the close of the best bid as an exact decimal, 0 if it is not a number
+func (price *Price) BidCloseDecimal() Decimal {
+	return priceDecimal(price.BidClose())
+}
+
+func (price *Price) SetBidCloseDecimal(bidClose Decimal) PriceInterface {
+	return price.SetBidClose(bidClose.String())
+}
+
+func (price *Price) SetBidClose(bidClose string) PriceInterface {
+	price.Set(COLUMN_BID_CLOSE, bidClose)
+	return price
+}
+
 func (price *Price) Close() string {
 	return price.Get(COLUMN_CLOSE)
 }
@@ -41,6 +89,15 @@ func (price *Price) CloseFloat() float64 {
 	return cast.ToFloat64(price.Close())
 }
 
+// CloseDecimal returns the close as an exact decimal, 0 if it is not a number
+func (price *Price) CloseDecimal() Decimal {
+	return priceDecimal(price.Close())
+}
+
+func (price *Price) SetCloseDecimal(close Decimal) PriceInterface {
+	return price.SetClose(close.String())
+}
+
 func (price *Price) SetClose(close string) PriceInterface {
 	price.Set(COLUMN_CLOSE, close)
 	return price
@@ -54,6 +111,15 @@ func (price *Price) HighFloat() float64 {
 	return cast.ToFloat64(price.High())
 }
 
+// HighDecimal returns the high as an exact decimal, 0 if it is not a number
+func (price *Price) HighDecimal() Decimal {
+	return priceDecimal(price.High())
+}
+
+func (price *Price) SetHighDecimal(high Decimal) PriceInterface {
+	return price.SetHigh(high.String())
+}
+
 func (price *Price) SetHigh(high string) PriceInterface {
 	price.Set(COLUMN_HIGH, high)
 	return price
@@ -76,11 +142,43 @@ func (price *Price) LowFloat() float64 {
 	return cast.ToFloat64(price.Low())
 }
 
+// LowDecimal returns the low as an exact decimal, 0 if it is not a number
+func (price *Price) LowDecimal() Decimal {
+	return priceDecimal(price.Low())
+}
+
+func (price *Price) SetLowDecimal(low Decimal) PriceInterface {
+	return price.SetLow(low.String())
+}
+
 func (price *Price) SetLow(low string) PriceInterface {
 	price.Set(COLUMN_LOW, low)
 	return price
 }
 
+// OpenInterest returns the open interest at the close of the bar, empty if the bar has none
+func (price *Price) OpenInterest() string {
+	return price.Get(COLUMN_OPEN_INTEREST)
+}
+
+func (price *Price) OpenInterestFloat() float64 {
+	return cast.ToFloat64(price.OpenInterest())
+}
+
+// OpenInterestDecimal returns the open interest at the close of the bar as an exact decimal, 0 if it is not a number
+func (price *Price) OpenInterestDecimal() Decimal {
+	return priceDecimal(price.OpenInterest())
+}
+
+func (price *Price) SetOpenInterestDecimal(openInterest Decimal) PriceInterface {
+	return price.SetOpenInterest(openInterest.String())
+}
+
+func (price *Price) SetOpenInterest(openInterest string) PriceInterface {
+	price.Set(COLUMN_OPEN_INTEREST, openInterest)
+	return price
+}
+
 func (price *Price) Open() string {
 	return price.Get(COLUMN_OPEN)
 }
@@ -89,18 +187,50 @@ func (price *Price) OpenFloat() float64 {
 	return cast.ToFloat64(price.Open())
 }
 
+// OpenDecimal returns the open as an exact decimal, 0 if it is not a number
+func (price *Price) OpenDecimal() Decimal {
+	return priceDecimal(price.Open())
+}
+
+func (price *Price) SetOpenDecimal(open Decimal) PriceInterface {
+	return price.SetOpen(open.String())
+}
+
 func (price *Price) SetOpen(open string) PriceInterface {
 	price.Set(COLUMN_OPEN, open)
 	return price
 }
 
+// TakerBuyVolume returns the volume bought by takers (aggressive buyers), empty if the bar has none
+func (price *Price) TakerBuyVolume() string {
+	return price.Get(COLUMN_TAKER_BUY_VOLUME)
+}
+
+func (price *Price) TakerBuyVolumeFloat() float64 {
+	return cast.ToFloat64(price.TakerBuyVolume())
+}
+
+// TakerBuyVolumeDecimal returns the volume bought by takers (aggressive buyers) as an exact decimal, 0 if it is not a number
+func (price *Price) TakerBuyVolumeDecimal() Decimal {
+	return priceDecimal(price.TakerBuyVolume())
+}
+
+func (price *Price) SetTakerBuyVolumeDecimal(takerBuyVolume Decimal) PriceInterface {
+	return price.SetTakerBuyVolume(takerBuyVolume.String())
+}
+
+func (price *Price) SetTakerBuyVolume(takerBuyVolume string) PriceInterface {
+	price.Set(COLUMN_TAKER_BUY_VOLUME, takerBuyVolume)
+	return price
+}
+
 // Time returns the time as a Iso8601 formatted string.
 //
 // Parameters:
 // - none
 //
 // Returns:
-// - string: the time in ISO8601 format
+// - string: the time in ISO8601 format, in UTC (i.e. 2024-01-02T15:04:05Z)
 func (price *Price) Time() string {
 	return price.Get(COLUMN_TIME)
 }
@@ -109,6 +239,11 @@ func (price *Price) TimeCarbon() *carbon.Carbon {
 	return carbon.Parse(price.Time(), carbon.UTC)
 }
 
+// TimeT returns the time as a time.Time in UTC
+func (price *Price) TimeT() time.Time {
+	return price.TimeCarbon().StdTime().UTC()
+}
+
 // SetTime sets the time for a Price, must be in UTC.
 // The time is stored as an ISO8601 formatted string.
 //
@@ -118,8 +253,28 @@ func (price *Price) TimeCarbon() *carbon.Carbon {
 // Returns:
 // - *Price: the Price
 func (price *Price) SetTime(timeUtc string) PriceInterface {
-	timeUtc = carbon.Parse(timeUtc, carbon.UTC).ToIso8601ZuluString()
-	price.Set(COLUMN_TIME, timeUtc)
+	price.Set(COLUMN_TIME, priceTimeFormat(carbon.Parse(timeUtc, carbon.UTC).StdTime()))
+	return price
+}
+
+// SetTimeT sets the time for a Price from a time.Time in any location.
+// The time is stored in UTC as an ISO8601 formatted string.
+func (price *Price) SetTimeT(t time.Time) PriceInterface {
+	price.Set(COLUMN_TIME, priceTimeFormat(t))
+	return price
+}
+
+// Trades returns the number of trades in the bar, empty if the bar has none
+func (price *Price) Trades() string {
+	return price.Get(COLUMN_TRADES)
+}
+
+func (price *Price) TradesInt() int64 {
+	return cast.ToInt64(price.Trades())
+}
+
+func (price *Price) SetTrades(trades string) PriceInterface {
+	price.Set(COLUMN_TRADES, trades)
 	return price
 }
 
@@ -131,7 +286,69 @@ func (price *Price) VolumeFloat() float64 {
 	return cast.ToFloat64(price.Volume())
 }
 
+// VolumeDecimal returns the volume as an exact decimal, 0 if it is not a number
+func (price *Price) VolumeDecimal() Decimal {
+	return priceDecimal(price.Volume())
+}
+
+func (price *Price) SetVolumeDecimal(volume Decimal) PriceInterface {
+	return price.SetVolume(volume.String())
+}
+
 func (price *Price) SetVolume(volume string) PriceInterface {
 	price.Set(COLUMN_VOLUME, volume)
 	return price
 }
+
+// VWAP returns the volume weighted average price, empty if the bar has none
+func (price *Price) VWAP() string {
+	return price.Get(COLUMN_VWAP)
+}
+
+func (price *Price) VWAPFloat() float64 {
+	return cast.ToFloat64(price.VWAP())
+}
+
+// VWAPDecimal returns the volume weighted average price as an exact decimal, 0 if it is not a number
+func (price *Price) VWAPDecimal() Decimal {
+	return priceDecimal(price.VWAP())
+}
+
+func (price *Price) SetVWAPDecimal(vwap Decimal) PriceInterface {
+	return price.SetVWAP(vwap.String())
+}
+
+func (price *Price) SetVWAP(vwap string) PriceInterface {
+	price.Set(COLUMN_VWAP, vwap)
+	return price
+}
+
+// == PRIVATE FUNCTIONS ========================================================
+
+// priceFields are the extended price fields, which can be enabled per instrument
+var priceFields = []string{
+	COLUMN_TRADES,
+	COLUMN_VWAP,
+	COLUMN_OPEN_INTEREST,
+	COLUMN_TAKER_BUY_VOLUME,
+	COLUMN_BID_CLOSE,
+	COLUMN_ASK_CLOSE,
+}
+
+// priceTimeFormat formats the time of a price object as ISO8601 in UTC,
+// with fractional seconds only if there are any (i.e. 2024-01-02T15:04:05Z)
+func priceTimeFormat(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// priceDecimal parses a price value, returning 0 if it is not a number,
+// as cast.ToFloat64 does for the float accessors
+func priceDecimal(value string) Decimal {
+	decimal, err := NewDecimalFromString(value)
+
+	if err != nil {
+		return Decimal{}
+	}
+
+	return decimal
+}
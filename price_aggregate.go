@@ -0,0 +1,158 @@
+package tradingstore
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+)
+
+// PriceAggregate aggregates prices into bars of a larger timeframe.
+//
+// The prices must be sorted by time in ascending order, which is the default
+// order returned by PriceList. Each resulting bar takes the open of the first
+// price, the highest high, the lowest low, the close of the last price and
+// the sum of the volumes in its bucket. The bar time is the bucket start.
+//
+// Parameters:
+// - prices: the prices to aggregate, sorted by time ascending
+// - timeframe: the target timeframe, one of the TIMEFRAME_* constants
+//
+// Returns:
+// - []PriceInterface: the aggregated bars
+// - error: if the timeframe is not supported
+func PriceAggregate(prices []PriceInterface, timeframe string) ([]PriceInterface, error) {
+	return PriceAggregateWithOptions(prices, timeframe, TimeframeBucketOptions{})
+}
+
+// PriceAggregateWithOptions aggregates prices into bars of a larger timeframe,
+// aligning daily and larger bars with the given bucket options, i.e. in the
+// exchange time zone at the session open.
+//
+// Parameters:
+// - prices: the prices to aggregate, sorted by time ascending
+// - timeframe: the target timeframe, one of the TIMEFRAME_* constants
+// - options: the bucket alignment options
+//
+// Returns:
+// - []PriceInterface: the aggregated bars
+// - error: if the timeframe or the options are not supported
+func PriceAggregateWithOptions(prices []PriceInterface, timeframe string, options TimeframeBucketOptions) ([]PriceInterface, error) {
+	if _, err := TimeframeDuration(timeframe); err != nil {
+		return nil, err
+	}
+
+	bars := []PriceInterface{}
+
+	var bucket []PriceInterface
+	var bucketStart time.Time
+
+	for _, price := range prices {
+		if price == nil {
+			return nil, errors.New("price aggregate: price is nil")
+		}
+
+		start, err := TimeframeBucketStartWithOptions(timeframe, price.TimeCarbon().StdTime(), options)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(bucket) > 0 && !bucketStart.Equal(start) {
+			bars = append(bars, priceAggregateBucket(bucket, bucketStart))
+			bucket = nil
+		}
+
+		if len(bucket) == 0 {
+			bucketStart = start
+		}
+
+		bucket = append(bucket, price)
+	}
+
+	if len(bucket) > 0 {
+		bars = append(bars, priceAggregateBucket(bucket, bucketStart))
+	}
+
+	return bars, nil
+}
+
+// priceAggregateBucket builds a single bar from the prices of one bucket.
+// The values are computed with exact decimals, so summed volumes do not drift
+func priceAggregateBucket(bucket []PriceInterface, bucketStart time.Time) PriceInterface {
+	high := bucket[0].HighDecimal()
+	low := bucket[0].LowDecimal()
+	volume := Decimal{}
+
+	for _, price := range bucket {
+		high = high.Max(price.HighDecimal())
+		low = low.Min(price.LowDecimal())
+		volume = volume.Add(price.VolumeDecimal())
+	}
+
+	bar := NewPrice().
+		SetTime(carbon.CreateFromStdTime(bucketStart, carbon.UTC).ToDateTimeString(carbon.UTC)).
+		SetOpen(bucket[0].Open()).
+		SetHighDecimal(high).
+		SetLowDecimal(low).
+		SetClose(bucket[len(bucket)-1].Close()).
+		SetVolumeDecimal(volume)
+
+	priceAggregateFields(bucket, bar)
+
+	return bar
+}
+
+// priceAggregateFields sets the extended price fields of the bar from the
+// prices of its bucket which have them. Trades and taker buy volumes are
+// summed, the VWAP is weighted by volume, and the open interest, bid close
+// and ask close are taken from the last price.
+func priceAggregateFields(bucket []PriceInterface, bar PriceInterface) {
+	trades, takerBuyVolume := Decimal{}, Decimal{}
+	hasTrades, hasTakerBuyVolume := false, false
+
+	// the vwap keeps at least the default price precision, as the average
+	// of whole prices is usually not a whole price
+	vwapNotional, vwapVolume := Decimal{}, Decimal{}
+	vwapScale := int32(PRICE_PRECISION_DEFAULT)
+
+	for _, price := range bucket {
+		if price.Trades() != "" {
+			trades, hasTrades = trades.Add(priceDecimal(price.Trades())), true
+		}
+
+		if price.TakerBuyVolume() != "" {
+			takerBuyVolume, hasTakerBuyVolume = takerBuyVolume.Add(price.TakerBuyVolumeDecimal()), true
+		}
+
+		if price.VWAP() != "" {
+			vwapNotional = vwapNotional.Add(price.VWAPDecimal().Mul(price.VolumeDecimal()))
+			vwapVolume = vwapVolume.Add(price.VolumeDecimal())
+			vwapScale = max(vwapScale, price.VWAPDecimal().Scale())
+		}
+
+		if price.OpenInterest() != "" {
+			bar.SetOpenInterest(price.OpenInterest())
+		}
+
+		if price.BidClose() != "" {
+			bar.SetBidClose(price.BidClose())
+		}
+
+		if price.AskClose() != "" {
+			bar.SetAskClose(price.AskClose())
+		}
+	}
+
+	if hasTrades {
+		bar.SetTrades(trades.String())
+	}
+
+	if hasTakerBuyVolume {
+		bar.SetTakerBuyVolumeDecimal(takerBuyVolume)
+	}
+
+	if !vwapVolume.IsZero() {
+		bar.SetVWAPDecimal(vwapNotional.Div(vwapVolume, vwapScale))
+	}
+}
@@ -0,0 +1,211 @@
+package tradingstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeframeBucketStart(t *testing.T) {
+	moment := time.Date(2024, time.March, 13, 14, 37, 12, 0, time.UTC) // Wednesday
+
+	cases := map[string]time.Time{
+		TIMEFRAME_1_MINUTE:   time.Date(2024, time.March, 13, 14, 37, 0, 0, time.UTC),
+		TIMEFRAME_5_MINUTES:  time.Date(2024, time.March, 13, 14, 35, 0, 0, time.UTC),
+		TIMEFRAME_1_HOUR:     time.Date(2024, time.March, 13, 14, 0, 0, 0, time.UTC),
+		TIMEFRAME_4_HOURS:    time.Date(2024, time.March, 13, 12, 0, 0, 0, time.UTC),
+		TIMEFRAME_1_DAY:      time.Date(2024, time.March, 13, 0, 0, 0, 0, time.UTC),
+		TIMEFRAME_1_WEEK:     time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+		TIMEFRAME_1_MONTH:    time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		TIMEFRAME_1_YEAR:     time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		TIMEFRAME_15_MINUTES: time.Date(2024, time.March, 13, 14, 30, 0, 0, time.UTC),
+	}
+
+	for timeframe, expected := range cases {
+		start, err := TimeframeBucketStart(timeframe, moment)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if !start.Equal(expected) {
+			t.Fatal("Bucket start for", timeframe, "MUST BE", expected, ", found:", start)
+		}
+	}
+
+	if _, err := TimeframeBucketStart("2min", moment); err == nil {
+		t.Fatal("Error MUST NOT be nil for unsupported timeframe")
+	}
+}
+
+func TestPriceAggregate(t *testing.T) {
+	prices := []PriceInterface{
+		NewPrice().SetTime("2020-01-01 00:00:00").SetOpen("10").SetHigh("12").SetLow("9").SetClose("11").SetVolume("100"),
+		NewPrice().SetTime("2020-01-01 00:01:00").SetOpen("11").SetHigh("15").SetLow("10").SetClose("14").SetVolume("200"),
+		NewPrice().SetTime("2020-01-01 00:04:00").SetOpen("14").SetHigh("14").SetLow("8").SetClose("9").SetVolume("50"),
+		NewPrice().SetTime("2020-01-01 00:05:00").SetOpen("9").SetHigh("10").SetLow("9").SetClose("10").SetVolume("10"),
+	}
+
+	bars, err := PriceAggregate(prices, TIMEFRAME_5_MINUTES)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(bars) != 2 {
+		t.Fatal("Bars count MUST BE 2, found:", len(bars))
+	}
+
+	first := bars[0]
+
+	if first.Time() != "2020-01-01T00:00:00Z" {
+		t.Fatal("Bar time MUST BE 2020-01-01T00:00:00Z, found:", first.Time())
+	}
+
+	if first.Open() != "10" || first.High() != "15" || first.Low() != "8" || first.Close() != "9" || first.Volume() != "350" {
+		t.Fatal("Unexpected bar:", first.Data())
+	}
+
+	if bars[1].Time() != "2020-01-01T00:05:00Z" || bars[1].Open() != "9" || bars[1].Volume() != "10" {
+		t.Fatal("Unexpected bar:", bars[1].Data())
+	}
+}
+
+func TestPriceAggregateIsExact(t *testing.T) {
+	prices := []PriceInterface{
+		NewPrice().SetTime("2020-01-01 00:00:00").SetOpen("0.1").SetHigh("0.30000000000000001").SetLow("0.1").SetClose("0.2").SetVolume("0.1"),
+		NewPrice().SetTime("2020-01-01 00:01:00").SetOpen("0.2").SetHigh("0.3").SetLow("0.09999999999999999").SetClose("0.3").SetVolume("0.2"),
+	}
+
+	bars, err := PriceAggregate(prices, TIMEFRAME_5_MINUTES)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if bars[0].Volume() != "0.3" {
+		t.Fatal("Summed volume MUST BE exactly 0.3, found:", bars[0].Volume())
+	}
+
+	if bars[0].High() != "0.30000000000000001" || bars[0].Low() != "0.09999999999999999" {
+		t.Fatal("High and low MUST keep every decimal, found:", bars[0].High(), bars[0].Low())
+	}
+}
+
+func TestTimeframeBucketStartWithOptions(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	forex := TimeframeBucketOptions{Location: newYork, DayAnchor: "17:00"}
+
+	// Sunday 18:00 New York is the first bar of Monday's trading day
+	moment := time.Date(2024, time.March, 10, 18, 0, 0, 0, newYork)
+	expected := time.Date(2024, time.March, 10, 17, 0, 0, 0, newYork).UTC()
+
+	for _, timeframe := range []string{TIMEFRAME_1_DAY, TIMEFRAME_1_WEEK} {
+		start, err := TimeframeBucketStartWithOptions(timeframe, moment, forex)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if !start.Equal(expected) {
+			t.Fatal("Forex", timeframe, "bucket start MUST BE", expected, ", found:", start)
+		}
+	}
+
+	// Friday 16:00 New York still belongs to the week started on Sunday
+	start, err := TimeframeBucketStartWithOptions(TIMEFRAME_1_WEEK, time.Date(2024, time.March, 15, 16, 0, 0, 0, newYork), forex)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !start.Equal(expected) {
+		t.Fatal("Forex week bucket start MUST BE", expected, ", found:", start)
+	}
+
+	end, err := TimeframeBucketEndWithOptions(TIMEFRAME_1_DAY, expected, forex)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !end.Equal(time.Date(2024, time.March, 11, 17, 0, 0, 0, newYork).UTC()) {
+		t.Fatal("Forex day bucket end MUST BE Monday 17:00, found:", end.In(newYork))
+	}
+
+	// Sydney session opens at 10:00 local, which is the previous day in UTC
+	sydney, err := time.LoadLocation("Australia/Sydney")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	asx := TimeframeBucketOptions{Location: sydney, DayAnchor: "10:00"}
+
+	start, err = TimeframeBucketStartWithOptions(TIMEFRAME_1_DAY, time.Date(2024, time.March, 12, 23, 30, 0, 0, time.UTC), asx)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !start.Equal(time.Date(2024, time.March, 13, 10, 0, 0, 0, sydney).UTC()) {
+		t.Fatal("ASX day bucket MUST start on March 13 10:00 Sydney, found:", start.In(sydney))
+	}
+
+	start, err = TimeframeBucketStartWithOptions(TIMEFRAME_1_MONTH, time.Date(2024, time.March, 12, 23, 30, 0, 0, time.UTC), asx)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !start.Equal(time.Date(2024, time.March, 1, 10, 0, 0, 0, sydney).UTC()) {
+		t.Fatal("ASX month bucket MUST start on March 1 10:00 Sydney, found:", start.In(sydney))
+	}
+
+	if _, err := TimeframeBucketStartWithOptions(TIMEFRAME_1_DAY, moment, TimeframeBucketOptions{DayAnchor: "5pm"}); err == nil {
+		t.Fatal("Error MUST NOT be nil for a malformed day anchor")
+	}
+}
+
+func TestPriceAggregateExtendedFields(t *testing.T) {
+	prices := []PriceInterface{
+		NewPrice().SetTime("2024-01-02 00:00:00").SetOpen("10").SetHigh("11").SetLow("9").SetClose("10").SetVolume("1").
+			SetTrades("3").SetVWAP("10").SetOpenInterest("500").SetBidClose("9.9"),
+		NewPrice().SetTime("2024-01-02 00:01:00").SetOpen("10").SetHigh("12").SetLow("10").SetClose("11").SetVolume("3").
+			SetTrades("7").SetVWAP("11").SetOpenInterest("510").SetBidClose("10.9"),
+		NewPrice().SetTime("2024-01-02 00:02:00").SetOpen("11").SetHigh("11").SetLow("10").SetClose("10").SetVolume("1"),
+	}
+
+	bars, err := PriceAggregate(prices, TIMEFRAME_5_MINUTES)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(bars) != 1 {
+		t.Fatal("Expected 1 bar, got", len(bars))
+	}
+
+	bar := bars[0]
+
+	if bar.Trades() != "10" {
+		t.Fatal("Trades MUST be summed, got", bar.Trades())
+	}
+
+	// (10 * 1 + 11 * 3) / 4, the last price has no vwap
+	if !bar.VWAPDecimal().Equal(NewDecimal(1075, 2)) {
+		t.Fatal("VWAP MUST be weighted by volume, got", bar.VWAP())
+	}
+
+	if bar.OpenInterest() != "510" || bar.BidClose() != "10.9" {
+		t.Fatal("Open interest and bid close MUST be the last values, got", bar.OpenInterest(), bar.BidClose())
+	}
+
+	if bar.TakerBuyVolume() != "" || bar.AskClose() != "" {
+		t.Fatal("Fields missing from all prices MUST NOT be set, got", bar.TakerBuyVolume(), bar.AskClose())
+	}
+}
@@ -0,0 +1,132 @@
+package tradingstore
+
+import (
+	"errors"
+	"sort"
+)
+
+// PriceContribution is the contribution of an exchange to consolidated prices
+type PriceContribution struct {
+	Exchange string
+
+	// Bars is the number of prices of the exchange
+	Bars int
+
+	// Volume is the volume traded on the exchange
+	Volume Decimal
+
+	// Share is the volume of the exchange divided by the consolidated volume,
+	// between 0 and 1
+	Share Decimal
+}
+
+// priceContributionShareDecimals is the number of decimals of the volume share
+const priceContributionShareDecimals = 6
+
+// PriceConsolidate merges the prices of the same instrument on several
+// exchanges into one series.
+//
+// The prices are merged by time, an exchange without a price at a time does
+// not contribute to it. The open and close are weighted by the volume of
+// each exchange, or averaged if no volume was traded. The high is the highest
+// high, the low the lowest low, and the volume the summed volume.
+//
+// Parameters:
+// - pricesByExchange: the prices of each exchange
+// - decimals: the number of decimals of the weighted open and close
+//
+// Returns:
+// - []PriceInterface: the consolidated prices, sorted by time ascending
+// - []PriceContribution: the contribution of each exchange, sorted by exchange
+// - error: if a price has no time
+func PriceConsolidate(pricesByExchange map[string][]PriceInterface, decimals int32) ([]PriceInterface, []PriceContribution, error) {
+	exchanges := []string{}
+
+	for exchange := range pricesByExchange {
+		exchanges = append(exchanges, exchange)
+	}
+
+	sort.Strings(exchanges)
+
+	// prices of each time, in the order the times were first seen
+	times := []string{}
+	pricesByTime := map[string][]PriceInterface{}
+
+	contributions := []PriceContribution{}
+	totalVolume := Decimal{}
+
+	for _, exchange := range exchanges {
+		contribution := PriceContribution{Exchange: exchange}
+
+		for _, price := range pricesByExchange[exchange] {
+			if price == nil || price.Time() == "" {
+				return nil, nil, errors.New("price consolidate: prices must include the time column")
+			}
+
+			if _, found := pricesByTime[price.Time()]; !found {
+				times = append(times, price.Time())
+			}
+
+			pricesByTime[price.Time()] = append(pricesByTime[price.Time()], price)
+
+			contribution.Bars++
+			contribution.Volume = contribution.Volume.Add(price.VolumeDecimal())
+		}
+
+		totalVolume = totalVolume.Add(contribution.Volume)
+		contributions = append(contributions, contribution)
+	}
+
+	for index := range contributions {
+		if totalVolume.Sign() > 0 {
+			contributions[index].Share = contributions[index].Volume.Div(totalVolume, priceContributionShareDecimals)
+		} else {
+			contributions[index].Share = NewDecimal(0, priceContributionShareDecimals)
+		}
+	}
+
+	consolidated := make([]PriceInterface, 0, len(times))
+
+	for _, time := range times {
+		consolidated = append(consolidated, priceConsolidateBar(time, pricesByTime[time], decimals))
+	}
+
+	sort.SliceStable(consolidated, func(i, j int) bool {
+		return consolidated[i].TimeCarbon().Lt(consolidated[j].TimeCarbon())
+	})
+
+	return consolidated, contributions, nil
+}
+
+// priceConsolidateBar merges the prices of several exchanges at one time
+func priceConsolidateBar(time string, prices []PriceInterface, decimals int32) PriceInterface {
+	high, low := prices[0].HighDecimal(), prices[0].LowDecimal()
+	volume, openSum, closeSum := Decimal{}, Decimal{}, Decimal{}
+	weightedOpen, weightedClose := Decimal{}, Decimal{}
+
+	for _, price := range prices {
+		high = high.Max(price.HighDecimal())
+		low = low.Min(price.LowDecimal())
+		volume = volume.Add(price.VolumeDecimal())
+		openSum = openSum.Add(price.OpenDecimal())
+		closeSum = closeSum.Add(price.CloseDecimal())
+		weightedOpen = weightedOpen.Add(price.OpenDecimal().Mul(price.VolumeDecimal()))
+		weightedClose = weightedClose.Add(price.CloseDecimal().Mul(price.VolumeDecimal()))
+	}
+
+	open := openSum.Div(NewDecimal(int64(len(prices)), 0), decimals)
+	close := closeSum.Div(NewDecimal(int64(len(prices)), 0), decimals)
+
+	if volume.Sign() > 0 {
+		open = weightedOpen.Div(volume, decimals)
+		close = weightedClose.Div(volume, decimals)
+	}
+
+	return NewPrice().
+		SetTime(time).
+		SetOpenDecimal(open).
+		SetHighDecimal(high).
+		SetLowDecimal(low).
+		SetCloseDecimal(close).
+		SetVolumeDecimal(volume)
+}
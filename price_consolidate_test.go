@@ -0,0 +1,52 @@
+package tradingstore
+
+import (
+	"testing"
+)
+
+func TestPriceConsolidate(t *testing.T) {
+	pricesByExchange := map[string][]PriceInterface{
+		"BINANCE": {
+			NewPrice().SetTime("2024-01-01 00:00:00").SetOpen("100").SetHigh("110").SetLow("95").SetClose("105").SetVolume("3"),
+			NewPrice().SetTime("2024-01-01 00:01:00").SetOpen("105").SetHigh("106").SetLow("104").SetClose("106").SetVolume("1"),
+		},
+		"COINBASE": {
+			NewPrice().SetTime("2024-01-01 00:00:00").SetOpen("104").SetHigh("112").SetLow("99").SetClose("101").SetVolume("1"),
+		},
+	}
+
+	prices, contributions, err := PriceConsolidate(pricesByExchange, 2)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 {
+		t.Fatal("Expected 2 consolidated prices, got", len(prices))
+	}
+
+	// (105 * 3 + 101 * 1) / 4 = 104
+	if prices[0].Close() != "104.00" || prices[0].Open() != "101.00" {
+		t.Fatal("Unexpected volume-weighted open and close:", prices[0].Open(), prices[0].Close())
+	}
+
+	if prices[0].HighFloat() != 112 || prices[0].LowFloat() != 95 || prices[0].VolumeFloat() != 4 {
+		t.Fatal("Unexpected consolidated price:", prices[0].Data())
+	}
+
+	if prices[1].Close() != "106.00" || prices[1].VolumeFloat() != 1 {
+		t.Fatal("A time of one exchange MUST take its price, got", prices[1].Data())
+	}
+
+	if len(contributions) != 2 || contributions[0].Exchange != "BINANCE" || contributions[1].Exchange != "COINBASE" {
+		t.Fatal("Expected the contributions sorted by exchange")
+	}
+
+	if contributions[0].Bars != 2 || contributions[0].Volume.String() != "4" || contributions[0].Share.String() != "0.800000" {
+		t.Fatal("Unexpected contribution:", contributions[0])
+	}
+
+	if contributions[1].Share.String() != "0.200000" {
+		t.Fatal("Unexpected contribution:", contributions[1])
+	}
+}
@@ -1,6 +1,8 @@
 package tradingstore
 
 import (
+	"time"
+
 	"github.com/dromara/carbon/v2"
 )
 
@@ -17,27 +19,73 @@ type PriceInterface interface {
 	ID() string
 	SetID(id string) PriceInterface
 
+	AskClose() string
+	AskCloseFloat() float64
+	AskCloseDecimal() Decimal
+	SetAskClose(askClose string) PriceInterface
+	SetAskCloseDecimal(askClose Decimal) PriceInterface
+
+	BidClose() string
+	BidCloseFloat() float64
+	BidCloseDecimal() Decimal
+	SetBidClose(bidClose string) PriceInterface
+	SetBidCloseDecimal(bidClose Decimal) PriceInterface
+
 	Close() string
 	CloseFloat() float64
+	CloseDecimal() Decimal
 	SetClose(close string) PriceInterface
+	SetCloseDecimal(close Decimal) PriceInterface
 
 	High() string
 	HighFloat() float64
+	HighDecimal() Decimal
 	SetHigh(high string) PriceInterface
+	SetHighDecimal(high Decimal) PriceInterface
 
 	Low() string
 	LowFloat() float64
+	LowDecimal() Decimal
 	SetLow(low string) PriceInterface
+	SetLowDecimal(low Decimal) PriceInterface
 
 	Open() string
 	OpenFloat() float64
+	OpenDecimal() Decimal
 	SetOpen(open string) PriceInterface
+	SetOpenDecimal(open Decimal) PriceInterface
+
+	OpenInterest() string
+	OpenInterestFloat() float64
+	OpenInterestDecimal() Decimal
+	SetOpenInterest(openInterest string) PriceInterface
+	SetOpenInterestDecimal(openInterest Decimal) PriceInterface
+
+	TakerBuyVolume() string
+	TakerBuyVolumeFloat() float64
+	TakerBuyVolumeDecimal() Decimal
+	SetTakerBuyVolume(takerBuyVolume string) PriceInterface
+	SetTakerBuyVolumeDecimal(takerBuyVolume Decimal) PriceInterface
 
 	Time() string
 	TimeCarbon() *carbon.Carbon
+	TimeT() time.Time
 	SetTime(time string) PriceInterface
+	SetTimeT(t time.Time) PriceInterface
+
+	Trades() string
+	TradesInt() int64
+	SetTrades(trades string) PriceInterface
 
 	Volume() string
 	VolumeFloat() float64
+	VolumeDecimal() Decimal
 	SetVolume(volume string) PriceInterface
+	SetVolumeDecimal(volume Decimal) PriceInterface
+
+	VWAP() string
+	VWAPFloat() float64
+	VWAPDecimal() Decimal
+	SetVWAP(vwap string) PriceInterface
+	SetVWAPDecimal(vwap Decimal) PriceInterface
 }
@@ -0,0 +1,33 @@
+package tradingstore
+
+// priceIteratorBatchSize is the number of prices loaded per batch
+const priceIteratorBatchSize = 1000
+
+// PriceIteratorInterface iterates over stored prices in ascending time order,
+// loading them from the database in batches
+type PriceIteratorInterface interface {
+	// Next advances to the next price, returns false when there are no more
+	// prices or an error occurred
+	Next() bool
+
+	// Price returns the current price
+	Price() PriceInterface
+
+	// Err returns the error which stopped the iteration, if any
+	Err() error
+
+	// Close stops the iteration
+	Close() error
+}
+
+// priceIterator implements PriceIteratorInterface using keyset pagination
+// on the price time, so large series are never loaded at once
+type priceIterator struct {
+	*keysetIterator[PriceInterface]
+}
+
+var _ PriceIteratorInterface = (*priceIterator)(nil) // verify interface is implemented
+
+func (it *priceIterator) Price() PriceInterface {
+	return it.current
+}
@@ -0,0 +1,161 @@
+package tradingstore
+
+import (
+	"errors"
+	"sort"
+)
+
+// PricePanel is the prices of several instruments aligned on a common time
+// index, one row per time
+type PricePanel struct {
+	// Instruments are the instruments of the columns
+	Instruments []InstrumentInterface
+
+	// Rows are the aligned prices, in ascending time order
+	Rows []PricePanelRow
+}
+
+// PricePanelRow is the prices of the instruments of a panel at one time
+type PricePanelRow struct {
+	// Time is the time of the row
+	Time string
+
+	// Prices are the prices of the instruments, in the order of the
+	// instruments, nil for an instrument without a price at the time
+	Prices []PriceInterface
+
+	// Filled is true for the prices filled by the fill policy,
+	// in the order of the instruments
+	Filled []bool
+}
+
+// PricePanelOptions define how the prices of a panel are aligned
+type PricePanelOptions struct {
+	// Join is PRICE_PANEL_JOIN_OUTER (default) or PRICE_PANEL_JOIN_INNER
+	Join string
+
+	// Fill is PRICE_PANEL_FILL_NONE (default) or PRICE_PANEL_FILL_FORWARD
+	Fill string
+}
+
+// Column returns the column of the instrument, -1 if not found
+func (panel PricePanel) Column(instrumentID string) int {
+	for index, instrument := range panel.Instruments {
+		if instrument.ID() == instrumentID {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// PricePanelAlign aligns price series on a common time index.
+//
+// With PRICE_PANEL_JOIN_OUTER there is a row for each time at which any
+// series has a price, with PRICE_PANEL_JOIN_INNER only for the times at
+// which every series has a price. With PRICE_PANEL_FILL_FORWARD a missing
+// price of an outer join is filled with a flat price at the last close of
+// the series, with no volume, once the series has started.
+//
+// Parameters:
+// - prices: the price series
+// - options: the join and fill options
+//
+// Returns:
+// - []PricePanelRow: the rows, in ascending time order, with the prices in the order of the series
+// - error: if the options are not supported or a price has no time
+func PricePanelAlign(prices [][]PriceInterface, options PricePanelOptions) ([]PricePanelRow, error) {
+	join := options.Join
+
+	if join == "" {
+		join = PRICE_PANEL_JOIN_OUTER
+	}
+
+	if join != PRICE_PANEL_JOIN_OUTER && join != PRICE_PANEL_JOIN_INNER {
+		return nil, errors.New("price panel: join is not supported: " + join)
+	}
+
+	fill := options.Fill
+
+	if fill == "" {
+		fill = PRICE_PANEL_FILL_NONE
+	}
+
+	if fill != PRICE_PANEL_FILL_NONE && fill != PRICE_PANEL_FILL_FORWARD {
+		return nil, errors.New("price panel: fill is not supported: " + fill)
+	}
+
+	// prices of each series by time, and the time strings by time
+	pricesByTime := make([]map[int64]PriceInterface, len(prices))
+	times := map[int64]string{}
+
+	for index, series := range prices {
+		pricesByTime[index] = map[int64]PriceInterface{}
+
+		for _, price := range series {
+			if price == nil || price.Time() == "" {
+				return nil, errors.New("price panel: prices must include the time column")
+			}
+
+			key := price.TimeCarbon().StdTime().UnixNano()
+			pricesByTime[index][key] = price
+			times[key] = price.Time()
+		}
+	}
+
+	keys := []int64{}
+
+	for key := range times {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	rows := []PricePanelRow{}
+	lastPrices := make([]PriceInterface, len(prices))
+
+	for _, key := range keys {
+		row := PricePanelRow{
+			Time:   times[key],
+			Prices: make([]PriceInterface, len(prices)),
+			Filled: make([]bool, len(prices)),
+		}
+
+		complete := true
+
+		for index := range prices {
+			price, found := pricesByTime[index][key]
+
+			if found {
+				row.Prices[index] = price
+				lastPrices[index] = price
+				continue
+			}
+
+			complete = false
+
+			if fill == PRICE_PANEL_FILL_FORWARD && lastPrices[index] != nil {
+				close := lastPrices[index].CloseDecimal()
+
+				row.Prices[index] = NewPrice().
+					SetTime(times[key]).
+					SetOpenDecimal(close).
+					SetHighDecimal(close).
+					SetLowDecimal(close).
+					SetCloseDecimal(close).
+					SetVolume("0")
+				row.Filled[index] = true
+			}
+		}
+
+		if join == PRICE_PANEL_JOIN_INNER && !complete {
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,75 @@
+package tradingstore
+
+import (
+	"testing"
+)
+
+func pricePanelTestPrices(closes map[string]string) []PriceInterface {
+	prices := []PriceInterface{}
+
+	for _, time := range []string{"2024-01-01 00:00:00", "2024-01-02 00:00:00", "2024-01-03 00:00:00"} {
+		if close, found := closes[time]; found {
+			prices = append(prices, NewPrice().SetTime(time).SetOpen(close).SetHigh(close).SetLow(close).SetClose(close).SetVolume("5"))
+		}
+	}
+
+	return prices
+}
+
+func TestPricePanelAlign(t *testing.T) {
+	a := pricePanelTestPrices(map[string]string{
+		"2024-01-01 00:00:00": "10",
+		"2024-01-02 00:00:00": "11",
+		"2024-01-03 00:00:00": "12",
+	})
+
+	b := pricePanelTestPrices(map[string]string{
+		"2024-01-02 00:00:00": "20",
+	})
+
+	outer, err := PricePanelAlign([][]PriceInterface{a, b}, PricePanelOptions{})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(outer) != 3 {
+		t.Fatal("Expected 3 rows in an outer join, got", len(outer))
+	}
+
+	if outer[0].Prices[1] != nil || outer[1].Prices[1].CloseFloat() != 20 || outer[2].Prices[1] != nil {
+		t.Fatal("Missing prices MUST be nil without a fill")
+	}
+
+	inner, err := PricePanelAlign([][]PriceInterface{a, b}, PricePanelOptions{Join: PRICE_PANEL_JOIN_INNER})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(inner) != 1 || inner[0].Prices[0].CloseFloat() != 11 {
+		t.Fatal("Expected the one common row in an inner join")
+	}
+
+	filled, err := PricePanelAlign([][]PriceInterface{a, b}, PricePanelOptions{Fill: PRICE_PANEL_FILL_FORWARD})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if filled[0].Prices[1] != nil {
+		t.Fatal("A series MUST NOT be filled before its first price")
+	}
+
+	if !filled[2].Filled[1] || filled[2].Prices[1].CloseFloat() != 20 || filled[2].Prices[1].VolumeFloat() != 0 || filled[2].Prices[1].Time() != filled[2].Time {
+		t.Fatal("Expected a flat forward filled price, got", filled[2].Prices[1].Data())
+	}
+
+	if filled[2].Filled[0] {
+		t.Fatal("A stored price MUST NOT be marked as filled")
+	}
+
+	if _, err := PricePanelAlign([][]PriceInterface{a}, PricePanelOptions{Join: "LEFT"}); err == nil {
+		t.Fatal("An unsupported join MUST be rejected")
+	}
+}
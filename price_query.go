@@ -1,6 +1,11 @@
 package tradingstore
 
-import "errors"
+import (
+	"errors"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+)
 
 // PriceQuery is a shortcut for NewPriceQuery
 func PriceQuery() PriceQueryInterface {
@@ -14,6 +19,62 @@ func NewPriceQuery() PriceQueryInterface {
 	}
 }
 
+// priceQueryCopy returns a copy of the query, so the store can change the
+// order or the time range of a query without changing the query of the caller
+func priceQueryCopy(options PriceQueryInterface) PriceQueryInterface {
+	query := PriceQuery()
+
+	if options.IsAdjustmentSet() {
+		query.SetAdjustment(options.Adjustment())
+	}
+
+	if options.IsColumnsSet() {
+		query.SetColumns(options.Columns())
+	}
+
+	if options.IsCountOnlySet() {
+		query.SetCountOnly(options.IsCountOnly())
+	}
+
+	if options.IsIDSet() {
+		query.SetID(options.ID())
+	}
+
+	if options.IsIDInSet() {
+		query.SetIDIn(options.IDIn())
+	}
+
+	if options.IsLimitSet() {
+		query.SetLimit(options.Limit())
+	}
+
+	if options.IsOffsetSet() {
+		query.SetOffset(options.Offset())
+	}
+
+	if options.IsOrderBySet() {
+		query.SetOrderBy(options.OrderBy())
+	}
+
+	if options.IsOrderDirectionSet() {
+		query.SetOrderDirection(options.OrderDirection())
+	}
+
+	if options.IsTimeSet() {
+		query.SetTime(options.Time())
+	}
+
+	if options.IsTimeGteSet() {
+		query.SetTimeGte(options.TimeGte())
+	}
+
+	if options.IsTimeLteSet() {
+		query.SetTimeLte(options.TimeLte())
+	}
+
+	return query
+}
+
 type priceQueryImplementation struct {
 	properties map[string]any
 }
@@ -60,9 +121,43 @@ func (c *priceQueryImplementation) Validate() error {
 		return errors.New("price query. time_lte cannot be empty")
 	}
 
+	times := map[string]string{"time": c.Time(), "time_gte": c.TimeGte(), "time_lte": c.TimeLte()}
+
+	for name, value := range times {
+		if value != "" && carbon.Parse(value, carbon.UTC).Error != nil {
+			return errors.New("price query. " + name + " is not a valid time: " + value)
+		}
+	}
+
+	if c.IsAdjustmentSet() && (c.Adjustment() < 0 || c.Adjustment() > ADJUST_SPLITS|ADJUST_DIVIDENDS) {
+		return errors.New("price query. adjustment must be a combination of the ADJUST_* flags")
+	}
+
 	return nil
 }
 
+func (c *priceQueryImplementation) IsAdjustmentSet() bool {
+	return c.hasProperty("adjustment")
+}
+
+// Adjustment returns the ADJUST_* flags the prices are back-adjusted with
+func (c *priceQueryImplementation) Adjustment() int {
+	if !c.IsAdjustmentSet() {
+		return ADJUST_NONE
+	}
+
+	return c.properties["adjustment"].(int)
+}
+
+// SetAdjustment sets the ADJUST_* flags to back-adjust the prices with,
+// i.e. ADJUST_SPLITS | ADJUST_DIVIDENDS. The adjustment is computed on
+// read from the corporate actions, the stored prices are not modified.
+func (c *priceQueryImplementation) SetAdjustment(adjustment int) PriceQueryInterface {
+	c.properties["adjustment"] = adjustment
+
+	return c
+}
+
 func (c *priceQueryImplementation) IsColumnsSet() bool {
 	return c.hasProperty("columns")
 }
@@ -225,6 +320,11 @@ func (c *priceQueryImplementation) SetTime(time string) PriceQueryInterface {
 	return c
 }
 
+// SetTimeT sets the time to match, as a time.Time in any location
+func (c *priceQueryImplementation) SetTimeT(t time.Time) PriceQueryInterface {
+	return c.SetTime(priceTimeFormat(t))
+}
+
 func (c *priceQueryImplementation) IsTimeGteSet() bool {
 	return c.hasProperty("time_gte")
 }
@@ -243,6 +343,11 @@ func (c *priceQueryImplementation) SetTimeGte(timeGte string) PriceQueryInterfac
 	return c
 }
 
+// SetTimeGteT sets the inclusive lower time bound, as a time.Time in any location
+func (c *priceQueryImplementation) SetTimeGteT(t time.Time) PriceQueryInterface {
+	return c.SetTimeGte(priceTimeFormat(t))
+}
+
 func (c *priceQueryImplementation) IsTimeLteSet() bool {
 	return c.hasProperty("time_lte")
 }
@@ -260,3 +365,8 @@ func (c *priceQueryImplementation) SetTimeLte(timeLte string) PriceQueryInterfac
 
 	return c
 }
+
+// SetTimeLteT sets the inclusive upper time bound, as a time.Time in any location
+func (c *priceQueryImplementation) SetTimeLteT(t time.Time) PriceQueryInterface {
+	return c.SetTimeLte(priceTimeFormat(t))
+}
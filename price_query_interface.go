@@ -1,8 +1,14 @@
 package tradingstore
 
+import "time"
+
 type PriceQueryInterface interface {
 	Validate() error
 
+	IsAdjustmentSet() bool
+	Adjustment() int
+	SetAdjustment(adjustment int) PriceQueryInterface
+
 	IsColumnsSet() bool
 	Columns() []string
 	SetColumns(columns []string) PriceQueryInterface
@@ -14,14 +20,17 @@ type PriceQueryInterface interface {
 	IsTimeSet() bool
 	Time() string
 	SetTime(createdAt string) PriceQueryInterface
+	SetTimeT(t time.Time) PriceQueryInterface
 
 	IsTimeGteSet() bool
 	TimeGte() string
 	SetTimeGte(createdAtGte string) PriceQueryInterface
+	SetTimeGteT(t time.Time) PriceQueryInterface
 
 	IsTimeLteSet() bool
 	TimeLte() string
 	SetTimeLte(createdAtLte string) PriceQueryInterface
+	SetTimeLteT(t time.Time) PriceQueryInterface
 
 	IsIDSet() bool
 	ID() string
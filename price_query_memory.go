@@ -0,0 +1,76 @@
+package tradingstore
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/dracory/sb"
+	"github.com/samber/lo"
+)
+
+// priceQueryMemoryValidate returns an error if the query can not be applied
+// to prices computed in memory, which have no stored IDs and are ordered
+// only by time
+func priceQueryMemoryValidate(options PriceQueryInterface, name string) error {
+	if options == nil {
+		return errors.New("price options is nil")
+	}
+
+	if options.IsOrderBySet() && !strings.EqualFold(options.OrderBy(), COLUMN_TIME) {
+		return errors.New(name + ": only ordering by time is supported")
+	}
+
+	if options.IsIDSet() || options.IsIDInSet() {
+		return errors.New(name + ": filtering by id is not supported")
+	}
+
+	return nil
+}
+
+// priceQuerySource returns the query listing the stored prices the computed
+// prices are made of, with the time filters and the adjustment of the query
+func priceQuerySource(options PriceQueryInterface) PriceQueryInterface {
+	source := PriceQuery()
+
+	if options.IsTimeSet() {
+		source.SetTime(options.Time())
+	}
+
+	if options.IsTimeGteSet() {
+		source.SetTimeGte(options.TimeGte())
+	}
+
+	if options.IsTimeLteSet() {
+		source.SetTimeLte(options.TimeLte())
+	}
+
+	if options.IsAdjustmentSet() {
+		source.SetAdjustment(options.Adjustment())
+	}
+
+	return source
+}
+
+// priceQueryMemoryPage applies the order, offset and limit of the query to
+// prices computed in memory
+func priceQueryMemoryPage(prices []PriceInterface, options PriceQueryInterface) []PriceInterface {
+	sort.SliceStable(prices, func(i, j int) bool {
+		return prices[i].TimeCarbon().Lt(prices[j].TimeCarbon())
+	})
+
+	// as with the price tables, ordering by time without a direction is descending
+	if options.IsOrderBySet() && !strings.EqualFold(lo.Ternary(options.IsOrderDirectionSet(), options.OrderDirection(), sb.DESC), sb.ASC) {
+		prices = lo.Reverse(prices)
+	}
+
+	if options.IsOffsetSet() {
+		prices = prices[min(options.Offset(), len(prices)):]
+	}
+
+	if options.IsLimitSet() && options.Limit() < len(prices) {
+		prices = prices[:options.Limit()]
+	}
+
+	return prices
+}
@@ -0,0 +1,308 @@
+package tradingstore
+
+import (
+	"errors"
+	"strconv"
+)
+
+// PriceTransformOptions define the bars transformed from prices
+type PriceTransformOptions struct {
+	// Type is the bar type, BAR_TYPE_HEIKIN_ASHI, BAR_TYPE_RENKO or BAR_TYPE_RANGE
+	Type string
+
+	// BrickSize is the fixed brick size of Renko bars
+	BrickSize Decimal
+
+	// ATRPeriod sizes the Renko bricks with the average true range of the
+	// prices over the period, used when BrickSize is not set
+	ATRPeriod int
+
+	// Range is the high to low range of range bars
+	Range Decimal
+}
+
+// PriceTransform transforms prices into Heikin-Ashi, Renko or range bars.
+//
+// The prices must be sorted by time in ascending order, which is the default
+// order returned by PriceList.
+//
+// Parameters:
+// - prices: the prices to transform, sorted by time ascending
+// - options: the bar options
+//
+// Returns:
+// - []PriceInterface: the bars
+// - error: if the options are not supported
+func PriceTransform(prices []PriceInterface, options PriceTransformOptions) ([]PriceInterface, error) {
+	for _, price := range prices {
+		if price == nil {
+			return nil, errors.New("price transform: price is nil")
+		}
+	}
+
+	switch options.Type {
+	case BAR_TYPE_HEIKIN_ASHI:
+		return PriceHeikinAshi(prices), nil
+	case BAR_TYPE_RENKO:
+		brickSize := options.BrickSize
+
+		if brickSize.Sign() == 0 && options.ATRPeriod > 0 {
+			var err error
+			brickSize, err = PriceATR(prices, options.ATRPeriod)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return PriceRenko(prices, brickSize)
+	case BAR_TYPE_RANGE:
+		return PriceRangeBars(prices, options.Range)
+	}
+
+	return nil, errors.New("price transform: bar type is not supported: " + options.Type)
+}
+
+// PriceHeikinAshi returns the Heikin-Ashi bars of the prices, one per price.
+// The close is the average of the open, high, low and close, the open is the
+// midpoint of the previous bar, and the high and low include both.
+//
+// Parameters:
+// - prices: the prices, sorted by time ascending
+//
+// Returns:
+// - []PriceInterface: the Heikin-Ashi bars, with the time and volume of the prices
+func PriceHeikinAshi(prices []PriceInterface) []PriceInterface {
+	bars := []PriceInterface{}
+
+	// the averages keep two more decimals than the prices
+	scale := int32(0)
+
+	for _, price := range prices {
+		scale = max(scale, price.OpenDecimal().Scale(), price.HighDecimal().Scale(), price.LowDecimal().Scale(), price.CloseDecimal().Scale())
+	}
+
+	scale += 2
+
+	two, four := NewDecimal(2, 0), NewDecimal(4, 0)
+
+	var previousOpen, previousClose Decimal
+
+	for index, price := range prices {
+		open, high, low, close := price.OpenDecimal(), price.HighDecimal(), price.LowDecimal(), price.CloseDecimal()
+
+		haClose := open.Add(high).Add(low).Add(close).Div(four, scale)
+		haOpen := open.Add(close).Div(two, scale)
+
+		if index > 0 {
+			haOpen = previousOpen.Add(previousClose).Div(two, scale)
+		}
+
+		bars = append(bars, NewPrice().
+			SetTime(price.Time()).
+			SetOpenDecimal(haOpen).
+			SetHighDecimal(high.Max(haOpen).Max(haClose)).
+			SetLowDecimal(low.Min(haOpen).Min(haClose)).
+			SetCloseDecimal(haClose).
+			SetVolume(price.Volume()))
+
+		previousOpen, previousClose = haOpen, haClose
+	}
+
+	return bars
+}
+
+// PriceRenko returns the Renko bricks of the closes of the prices. A brick is
+// added each time the close moves a brick size above the top or below the
+// bottom of the last brick, so a reversal takes two brick sizes. The first
+// close anchors the bricks.
+//
+// A brick takes the time of the price which completed it, and the volume
+// traded since the previous brick. A price completing several bricks gives
+// its volume to the first one.
+//
+// Parameters:
+// - prices: the prices, sorted by time ascending
+// - brickSize: the brick size, greater than zero
+//
+// Returns:
+// - []PriceInterface: the bricks
+// - error: if the brick size is not greater than zero
+func PriceRenko(prices []PriceInterface, brickSize Decimal) ([]PriceInterface, error) {
+	if brickSize.Sign() <= 0 {
+		return nil, errors.New("price renko: brick size must be greater than zero")
+	}
+
+	bricks := []PriceInterface{}
+
+	if len(prices) < 1 {
+		return bricks, nil
+	}
+
+	top := prices[0].CloseDecimal()
+	bottom := top
+	volume := Decimal{}
+
+	for _, price := range prices {
+		volume = volume.Add(price.VolumeDecimal())
+		close := price.CloseDecimal()
+
+		for {
+			var open, brickClose Decimal
+
+			if close.Cmp(top.Add(brickSize)) >= 0 {
+				open, brickClose = top, top.Add(brickSize)
+			} else if close.Cmp(bottom.Sub(brickSize)) <= 0 {
+				open, brickClose = bottom, bottom.Sub(brickSize)
+			} else {
+				break
+			}
+
+			bricks = append(bricks, NewPrice().
+				SetTime(price.Time()).
+				SetOpenDecimal(open).
+				SetHighDecimal(open.Max(brickClose)).
+				SetLowDecimal(open.Min(brickClose)).
+				SetCloseDecimal(brickClose).
+				SetVolumeDecimal(volume))
+
+			top, bottom = open.Max(brickClose), open.Min(brickClose)
+			volume = Decimal{}
+		}
+	}
+
+	return bricks, nil
+}
+
+// PriceRangeBars returns the range bars of the prices. A bar closes when its
+// high to low range reaches the range size, at its low plus the range or its
+// high minus the range, and the next bar opens at that price.
+//
+// The path within each price is approximated from its open, through its low
+// then high for a rising price (high then low for a falling one), to its
+// close. A bar takes the time of the price it opened in, and the volume of
+// the prices which closed in it. The last bar is included even if it has not
+// reached the range.
+//
+// Parameters:
+// - prices: the prices, sorted by time ascending
+// - rangeSize: the range size, greater than zero
+//
+// Returns:
+// - []PriceInterface: the range bars
+// - error: if the range size is not greater than zero
+func PriceRangeBars(prices []PriceInterface, rangeSize Decimal) ([]PriceInterface, error) {
+	if rangeSize.Sign() <= 0 {
+		return nil, errors.New("price range bars: range must be greater than zero")
+	}
+
+	bars := []PriceInterface{}
+
+	var bar PriceInterface
+	var high, low Decimal
+
+	start := func(time string, open Decimal) {
+		bar = NewPrice().SetTime(time).SetOpenDecimal(open).SetVolume("0")
+		high, low = open, open
+	}
+
+	flush := func(close Decimal) {
+		bar.SetHighDecimal(high).SetLowDecimal(low).SetCloseDecimal(close)
+		bars = append(bars, bar)
+	}
+
+	for _, price := range prices {
+		path := []Decimal{price.OpenDecimal(), price.LowDecimal(), price.HighDecimal(), price.CloseDecimal()}
+
+		if price.CloseDecimal().Cmp(price.OpenDecimal()) < 0 {
+			path[1], path[2] = path[2], path[1]
+		}
+
+		for _, point := range path {
+			if bar == nil {
+				start(price.Time(), point)
+				continue
+			}
+
+			for point.Cmp(low.Add(rangeSize)) >= 0 {
+				boundary := low.Add(rangeSize)
+				high = boundary
+				flush(boundary)
+				start(price.Time(), boundary)
+			}
+
+			for point.Cmp(high.Sub(rangeSize)) <= 0 {
+				boundary := high.Sub(rangeSize)
+				low = boundary
+				flush(boundary)
+				start(price.Time(), boundary)
+			}
+
+			high, low = high.Max(point), low.Min(point)
+		}
+
+		bar.SetVolumeDecimal(bar.VolumeDecimal().Add(price.VolumeDecimal())).
+			SetCloseDecimal(price.CloseDecimal())
+	}
+
+	if bar != nil {
+		flush(bar.CloseDecimal())
+	}
+
+	return bars, nil
+}
+
+// PriceATR returns the average true range of the prices over the period, with
+// Wilder smoothing, at the last price. The true range needs the previous
+// close, so there must be more prices than the period.
+//
+// Parameters:
+// - prices: the prices, sorted by time ascending
+// - period: the period, greater than zero
+//
+// Returns:
+// - Decimal: the average true range
+// - error: if the period is not greater than zero or there are not enough prices
+func PriceATR(prices []PriceInterface, period int) (Decimal, error) {
+	if period < 1 {
+		return Decimal{}, errors.New("price atr: period must be greater than zero")
+	}
+
+	if len(prices) <= period {
+		return Decimal{}, errors.New("price atr: at least " + strconv.Itoa(period+1) + " prices are required")
+	}
+
+	// the average keeps four more decimals than the prices
+	scale := int32(0)
+
+	for _, price := range prices {
+		scale = max(scale, price.HighDecimal().Scale(), price.LowDecimal().Scale(), price.CloseDecimal().Scale())
+	}
+
+	scale += 4
+
+	n := NewDecimal(int64(period), 0)
+	atr := Decimal{}
+
+	for index := 1; index < len(prices); index++ {
+		high, low := prices[index].HighDecimal(), prices[index].LowDecimal()
+		previousClose := prices[index-1].CloseDecimal()
+
+		trueRange := high.Sub(low).Max(high.Sub(previousClose).Abs()).Max(low.Sub(previousClose).Abs())
+
+		if index <= period {
+			// seeded with the average of the first true ranges
+			atr = atr.Add(trueRange)
+
+			if index == period {
+				atr = atr.Div(n, scale)
+			}
+
+			continue
+		}
+
+		atr = atr.Mul(n.Sub(NewDecimal(1, 0))).Add(trueRange).Div(n, scale)
+	}
+
+	return atr, nil
+}
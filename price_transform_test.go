@@ -0,0 +1,152 @@
+package tradingstore
+
+import (
+	"testing"
+	"time"
+)
+
+func priceTransformTestPrices(ohlc [][4]string) []PriceInterface {
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	prices := []PriceInterface{}
+
+	for index, values := range ohlc {
+		prices = append(prices, NewPrice().
+			SetTimeT(start.AddDate(0, 0, index)).
+			SetOpen(values[0]).
+			SetHigh(values[1]).
+			SetLow(values[2]).
+			SetClose(values[3]).
+			SetVolume("10"))
+	}
+
+	return prices
+}
+
+func TestPriceHeikinAshi(t *testing.T) {
+	prices := priceTransformTestPrices([][4]string{
+		{"10", "12", "9", "11"},
+		{"11", "14", "10", "13"},
+	})
+
+	bars := PriceHeikinAshi(prices)
+
+	if len(bars) != 2 {
+		t.Fatal("Expected 2 bars, got", len(bars))
+	}
+
+	// close (10+12+9+11)/4, open (10+11)/2
+	if !bars[0].CloseDecimal().Equal(NewDecimal(105, 1)) || !bars[0].OpenDecimal().Equal(NewDecimal(105, 1)) {
+		t.Fatal("Unexpected first bar:", bars[0].Open(), bars[0].Close())
+	}
+
+	// open (10.5+10.5)/2, close (11+14+10+13)/4
+	if !bars[1].OpenDecimal().Equal(NewDecimal(105, 1)) || !bars[1].CloseDecimal().Equal(NewDecimal(12, 0)) {
+		t.Fatal("Unexpected second bar:", bars[1].Open(), bars[1].Close())
+	}
+
+	if bars[1].HighFloat() != 14 || bars[1].LowFloat() != 10 || bars[1].Time() != prices[1].Time() || bars[1].Volume() != "10" {
+		t.Fatal("Unexpected second bar:", bars[1].Data())
+	}
+}
+
+func TestPriceRenko(t *testing.T) {
+	prices := priceTransformTestPrices([][4]string{
+		{"100", "100", "100", "100"},
+		{"100", "103", "100", "102.5"}, // two up bricks
+		{"102", "102", "101", "101"},   // no reversal yet
+		{"101", "101", "99", "99.5"},   // one down brick, from 101 to 100
+	})
+
+	bricks, err := PriceRenko(prices, NewDecimal(1, 0))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(bricks) != 3 {
+		t.Fatal("Expected 3 bricks, got", len(bricks))
+	}
+
+	if bricks[0].OpenFloat() != 100 || bricks[0].CloseFloat() != 101 || bricks[1].CloseFloat() != 102 {
+		t.Fatal("Unexpected up bricks:", bricks[0].Data(), bricks[1].Data())
+	}
+
+	if bricks[0].VolumeFloat() != 20 || bricks[1].VolumeFloat() != 0 {
+		t.Fatal("Price completing several bricks MUST give its volume to the first, got", bricks[0].Volume(), bricks[1].Volume())
+	}
+
+	if bricks[2].OpenFloat() != 101 || bricks[2].CloseFloat() != 100 || bricks[2].VolumeFloat() != 20 || bricks[2].Time() != prices[3].Time() {
+		t.Fatal("Unexpected down brick:", bricks[2].Data())
+	}
+
+	if _, err := PriceRenko(prices, Decimal{}); err == nil {
+		t.Fatal("PriceRenko MUST reject a brick size of zero")
+	}
+}
+
+func TestPriceTransformRenkoATR(t *testing.T) {
+	prices := priceTransformTestPrices([][4]string{
+		{"10", "11", "9", "10"},
+		{"10", "12", "10", "11"},
+		{"11", "13", "11", "12"},
+		{"12", "14", "12", "13"},
+	})
+
+	atr, err := PriceATR(prices, 2)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// true ranges 2, 2, 2
+	if !atr.Equal(NewDecimal(2, 0)) {
+		t.Fatal("Expected ATR 2, got", atr.String())
+	}
+
+	bricks, err := PriceTransform(prices, PriceTransformOptions{Type: BAR_TYPE_RENKO, ATRPeriod: 2})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(bricks) != 1 || bricks[0].CloseFloat() != 12 {
+		t.Fatal("Expected 1 brick of size 2, got", bricks)
+	}
+
+	if _, err := PriceATR(prices, 4); err == nil {
+		t.Fatal("PriceATR MUST fail without more prices than the period")
+	}
+
+	if _, err := PriceTransform(prices, PriceTransformOptions{Type: BAR_TYPE_TICK}); err == nil {
+		t.Fatal("PriceTransform MUST reject an unsupported bar type")
+	}
+}
+
+func TestPriceRangeBars(t *testing.T) {
+	prices := priceTransformTestPrices([][4]string{
+		{"100", "101", "99.5", "100.5"}, // range 1.5 reached at the high, closing at 100.5 in the next bar
+		{"100.5", "101", "100.5", "101"},
+	})
+
+	bars, err := PriceRangeBars(prices, NewDecimal(15, 1))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(bars) != 2 {
+		t.Fatal("Expected 2 range bars, got", len(bars))
+	}
+
+	if bars[0].OpenFloat() != 100 || bars[0].LowFloat() != 99.5 || bars[0].HighFloat() != 101 || bars[0].CloseFloat() != 101 {
+		t.Fatal("Unexpected first range bar:", bars[0].Data())
+	}
+
+	if bars[1].OpenFloat() != 101 || bars[1].LowFloat() != 100.5 || bars[1].CloseFloat() != 101 || bars[1].VolumeFloat() != 20 {
+		t.Fatal("Unexpected last range bar:", bars[1].Data())
+	}
+
+	if _, err := PriceRangeBars(prices, Decimal{}); err == nil {
+		t.Fatal("PriceRangeBars MUST reject a range of zero")
+	}
+}
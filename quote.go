@@ -0,0 +1,183 @@
+package tradingstore
+
+import (
+	"time"
+
+	"github.com/dracory/dataobject"
+	"github.com/dracory/uid"
+	"github.com/dromara/carbon/v2"
+	"github.com/spf13/cast"
+)
+
+// == CLASS ====================================================================
+
+// quoteImplementation represents the top of the book (best bid and best ask)
+// of an instrument at a point in time
+type quoteImplementation struct {
+	dataobject.DataObject
+}
+
+// == CONSTRUCTORS =============================================================
+
+func NewQuote() QuoteInterface {
+	o := (&quoteImplementation{}).
+		SetID(uid.HumanUid())
+
+	o.SetBidSize("")
+	o.SetAskSize("")
+
+	return o
+}
+
+func NewQuoteFromExistingData(data map[string]string) QuoteInterface {
+	o := &quoteImplementation{}
+	o.Hydrate(data)
+	return o
+}
+
+// == METHODS ==================================================================
+
+// Mid returns the midpoint of the bid and the ask
+func (quote *quoteImplementation) Mid() Decimal {
+	bid := quote.BidDecimal()
+	ask := quote.AskDecimal()
+	scale := max(bid.Scale(), ask.Scale()) + 1
+
+	return bid.Add(ask).Div(NewDecimal(2, 0), scale)
+}
+
+// Spread returns the ask minus the bid
+func (quote *quoteImplementation) Spread() Decimal {
+	return quote.AskDecimal().Sub(quote.BidDecimal())
+}
+
+// == SETTERS & GETTERS ========================================================
+
+func (quote *quoteImplementation) ID() string {
+	return quote.Get(COLUMN_ID)
+}
+
+func (quote *quoteImplementation) SetID(id string) QuoteInterface {
+	quote.Set(COLUMN_ID, id)
+	return quote
+}
+
+// Ask returns the best ask price
+func (quote *quoteImplementation) Ask() string {
+	return quote.Get(COLUMN_ASK)
+}
+
+func (quote *quoteImplementation) AskFloat() float64 {
+	return cast.ToFloat64(quote.Ask())
+}
+
+// AskDecimal returns the ask as an exact decimal, 0 if it is not a number
+func (quote *quoteImplementation) AskDecimal() Decimal {
+	return priceDecimal(quote.Ask())
+}
+
+func (quote *quoteImplementation) SetAsk(ask string) QuoteInterface {
+	quote.Set(COLUMN_ASK, ask)
+	return quote
+}
+
+func (quote *quoteImplementation) SetAskDecimal(ask Decimal) QuoteInterface {
+	return quote.SetAsk(ask.String())
+}
+
+// AskSize returns the size available at the best ask, empty if unknown
+func (quote *quoteImplementation) AskSize() string {
+	return quote.Get(COLUMN_ASK_SIZE)
+}
+
+func (quote *quoteImplementation) AskSizeFloat() float64 {
+	return cast.ToFloat64(quote.AskSize())
+}
+
+// AskSizeDecimal returns the ask size as an exact decimal, 0 if it is not a number
+func (quote *quoteImplementation) AskSizeDecimal() Decimal {
+	return priceDecimal(quote.AskSize())
+}
+
+func (quote *quoteImplementation) SetAskSize(askSize string) QuoteInterface {
+	quote.Set(COLUMN_ASK_SIZE, askSize)
+	return quote
+}
+
+func (quote *quoteImplementation) SetAskSizeDecimal(askSize Decimal) QuoteInterface {
+	return quote.SetAskSize(askSize.String())
+}
+
+// Bid returns the best bid price
+func (quote *quoteImplementation) Bid() string {
+	return quote.Get(COLUMN_BID)
+}
+
+func (quote *quoteImplementation) BidFloat() float64 {
+	return cast.ToFloat64(quote.Bid())
+}
+
+// BidDecimal returns the bid as an exact decimal, 0 if it is not a number
+func (quote *quoteImplementation) BidDecimal() Decimal {
+	return priceDecimal(quote.Bid())
+}
+
+func (quote *quoteImplementation) SetBid(bid string) QuoteInterface {
+	quote.Set(COLUMN_BID, bid)
+	return quote
+}
+
+func (quote *quoteImplementation) SetBidDecimal(bid Decimal) QuoteInterface {
+	return quote.SetBid(bid.String())
+}
+
+// BidSize returns the size available at the best bid, empty if unknown
+func (quote *quoteImplementation) BidSize() string {
+	return quote.Get(COLUMN_BID_SIZE)
+}
+
+func (quote *quoteImplementation) BidSizeFloat() float64 {
+	return cast.ToFloat64(quote.BidSize())
+}
+
+// BidSizeDecimal returns the bid size as an exact decimal, 0 if it is not a number
+func (quote *quoteImplementation) BidSizeDecimal() Decimal {
+	return priceDecimal(quote.BidSize())
+}
+
+func (quote *quoteImplementation) SetBidSize(bidSize string) QuoteInterface {
+	quote.Set(COLUMN_BID_SIZE, bidSize)
+	return quote
+}
+
+func (quote *quoteImplementation) SetBidSizeDecimal(bidSize Decimal) QuoteInterface {
+	return quote.SetBidSize(bidSize.String())
+}
+
+// Time returns the time of the quote as ISO8601 in UTC, with microseconds
+// if there are any (i.e. 2024-01-02T15:04:05.123456Z)
+func (quote *quoteImplementation) Time() string {
+	return quote.Get(COLUMN_TIME)
+}
+
+func (quote *quoteImplementation) TimeCarbon() *carbon.Carbon {
+	return carbon.Parse(quote.Time(), carbon.UTC)
+}
+
+// TimeT returns the time of the quote as a time.Time in UTC
+func (quote *quoteImplementation) TimeT() time.Time {
+	return quote.TimeCarbon().StdTime().UTC()
+}
+
+// SetTime sets the time of the quote, must be in UTC.
+// The time is truncated to microseconds.
+func (quote *quoteImplementation) SetTime(timeUtc string) QuoteInterface {
+	return quote.SetTimeT(carbon.Parse(timeUtc, carbon.UTC).StdTime())
+}
+
+// SetTimeT sets the time of the quote from a time.Time in any location.
+// The time is truncated to microseconds and stored in UTC.
+func (quote *quoteImplementation) SetTimeT(t time.Time) QuoteInterface {
+	quote.Set(COLUMN_TIME, tickTimeFormat(t))
+	return quote
+}
@@ -0,0 +1,195 @@
+package tradingstore
+
+import (
+	"errors"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// QuoteBarOptions define the bars built from quotes
+type QuoteBarOptions struct {
+	// Source is the quote price the bars are made of, one of the
+	// QUOTE_BAR_SOURCE_* constants
+	Source string
+
+	// Timeframe is the timeframe of the bars, one of the TIMEFRAME_* constants
+	Timeframe string
+
+	// BucketOptions align daily and larger bars
+	BucketOptions TimeframeBucketOptions
+
+	// Fields are the extended price fields set on the bars, any of
+	// COLUMN_BID_CLOSE and COLUMN_ASK_CLOSE
+	Fields []string
+}
+
+// quoteBarFields are the extended price fields which can be built from quotes
+var quoteBarFields = []string{COLUMN_BID_CLOSE, COLUMN_ASK_CLOSE}
+
+// QuoteBarBuilder builds OHLC bars from quotes added in ascending time order.
+//
+// The bars start at their bucket start. Quotes carry no traded volume, so
+// the volume of a bar is the number of quotes it was built from (tick volume).
+type QuoteBarBuilder struct {
+	options QuoteBarOptions
+
+	// the bar being built
+	quotes      int64
+	bucketStart time.Time
+	open        Decimal
+	high        Decimal
+	low         Decimal
+	close       Decimal
+	lastQuote   QuoteInterface
+}
+
+// NewQuoteBarBuilder creates a bar builder
+//
+// Parameters:
+// - options: the bar options
+//
+// Returns:
+// - *QuoteBarBuilder: the builder
+// - error: if the options are not supported
+func NewQuoteBarBuilder(options QuoteBarOptions) (*QuoteBarBuilder, error) {
+	if !lo.Contains([]string{QUOTE_BAR_SOURCE_ASK, QUOTE_BAR_SOURCE_BID, QUOTE_BAR_SOURCE_MID}, options.Source) {
+		return nil, errors.New("quote bar builder: source is not supported: " + options.Source)
+	}
+
+	if _, err := TimeframeDuration(options.Timeframe); err != nil {
+		return nil, err
+	}
+
+	if _, err := options.BucketOptions.anchor(); err != nil {
+		return nil, err
+	}
+
+	for _, field := range options.Fields {
+		if !lo.Contains(quoteBarFields, field) {
+			return nil, errors.New("quote bar builder: field can not be built from quotes: " + field)
+		}
+	}
+
+	return &QuoteBarBuilder{options: options}, nil
+}
+
+// Add adds the next quote to the bar being built.
+//
+// Returns:
+// - []PriceInterface: the bar completed by the quote, if any
+// - error: if the quote is nil or older than the previous quote
+func (builder *QuoteBarBuilder) Add(quote QuoteInterface) ([]PriceInterface, error) {
+	if quote == nil {
+		return nil, errors.New("quote bar builder: quote is nil")
+	}
+
+	if builder.lastQuote != nil && quote.TimeT().Before(builder.lastQuote.TimeT()) {
+		return nil, errors.New("quote bar builder: quotes must be added in ascending time order")
+	}
+
+	start, err := TimeframeBucketStartWithOptions(builder.options.Timeframe, quote.TimeT(), builder.options.BucketOptions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	completed := []PriceInterface{}
+
+	if builder.quotes > 0 && !start.Equal(builder.bucketStart) {
+		completed = append(completed, builder.Flush())
+	}
+
+	builder.bucketStart = start
+
+	price := builder.sourcePrice(quote)
+
+	if builder.quotes == 0 {
+		builder.open = price
+		builder.high = price
+		builder.low = price
+	}
+
+	builder.quotes++
+	builder.high = builder.high.Max(price)
+	builder.low = builder.low.Min(price)
+	builder.close = price
+	builder.lastQuote = quote
+
+	return completed, nil
+}
+
+// Flush returns the bar being built, nil if it has no quotes, and starts a new bar
+func (builder *QuoteBarBuilder) Flush() PriceInterface {
+	if builder.quotes < 1 {
+		return nil
+	}
+
+	bar := NewPrice().
+		SetTimeT(builder.bucketStart).
+		SetOpenDecimal(builder.open).
+		SetHighDecimal(builder.high).
+		SetLowDecimal(builder.low).
+		SetCloseDecimal(builder.close).
+		SetVolumeDecimal(NewDecimal(builder.quotes, 0))
+
+	if lo.Contains(builder.options.Fields, COLUMN_BID_CLOSE) {
+		bar.SetBidCloseDecimal(builder.lastQuote.BidDecimal())
+	}
+
+	if lo.Contains(builder.options.Fields, COLUMN_ASK_CLOSE) {
+		bar.SetAskCloseDecimal(builder.lastQuote.AskDecimal())
+	}
+
+	*builder = QuoteBarBuilder{options: builder.options, lastQuote: builder.lastQuote}
+
+	return bar
+}
+
+// sourcePrice returns the price of the quote the bars are made of
+func (builder *QuoteBarBuilder) sourcePrice(quote QuoteInterface) Decimal {
+	switch builder.options.Source {
+	case QUOTE_BAR_SOURCE_ASK:
+		return quote.AskDecimal()
+	case QUOTE_BAR_SOURCE_BID:
+		return quote.BidDecimal()
+	}
+
+	return quote.Mid()
+}
+
+// QuoteAggregate builds the bars of the quotes, which must be sorted by time
+// in ascending order. The last bar is included even if it is not complete.
+//
+// Parameters:
+// - quotes: the quotes, sorted by time ascending
+// - options: the bar options
+//
+// Returns:
+// - []PriceInterface: the bars
+// - error: if the options are not supported or the quotes are not sorted
+func QuoteAggregate(quotes []QuoteInterface, options QuoteBarOptions) ([]PriceInterface, error) {
+	builder, err := NewQuoteBarBuilder(options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bars := []PriceInterface{}
+
+	for _, quote := range quotes {
+		completed, err := builder.Add(quote)
+
+		if err != nil {
+			return nil, err
+		}
+
+		bars = append(bars, completed...)
+	}
+
+	if bar := builder.Flush(); bar != nil {
+		bars = append(bars, bar)
+	}
+
+	return bars, nil
+}
@@ -0,0 +1,55 @@
+package tradingstore
+
+import (
+	"time"
+
+	"github.com/dromara/carbon/v2"
+)
+
+type QuoteInterface interface {
+	// from dataobject
+
+	Data() map[string]string
+	DataChanged() map[string]string
+	MarkAsNotDirty()
+
+	// methods
+
+	Mid() Decimal
+	Spread() Decimal
+
+	// setters and getters
+
+	ID() string
+	SetID(id string) QuoteInterface
+
+	Ask() string
+	AskFloat() float64
+	AskDecimal() Decimal
+	SetAsk(ask string) QuoteInterface
+	SetAskDecimal(ask Decimal) QuoteInterface
+
+	AskSize() string
+	AskSizeFloat() float64
+	AskSizeDecimal() Decimal
+	SetAskSize(askSize string) QuoteInterface
+	SetAskSizeDecimal(askSize Decimal) QuoteInterface
+
+	Bid() string
+	BidFloat() float64
+	BidDecimal() Decimal
+	SetBid(bid string) QuoteInterface
+	SetBidDecimal(bid Decimal) QuoteInterface
+
+	BidSize() string
+	BidSizeFloat() float64
+	BidSizeDecimal() Decimal
+	SetBidSize(bidSize string) QuoteInterface
+	SetBidSizeDecimal(bidSize Decimal) QuoteInterface
+
+	Time() string
+	TimeCarbon() *carbon.Carbon
+	TimeT() time.Time
+	SetTime(time string) QuoteInterface
+	SetTimeT(t time.Time) QuoteInterface
+}
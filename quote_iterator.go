@@ -0,0 +1,33 @@
+package tradingstore
+
+// quoteIteratorBatchSize is the number of quotes loaded per batch
+const quoteIteratorBatchSize = 1000
+
+// QuoteIteratorInterface iterates over stored quotes in ascending time order,
+// loading them from the database in batches
+type QuoteIteratorInterface interface {
+	// Next advances to the next quote, returns false when there are no more
+	// quotes or an error occurred
+	Next() bool
+
+	// Quote returns the current quote
+	Quote() QuoteInterface
+
+	// Err returns the error which stopped the iteration, if any
+	Err() error
+
+	// Close stops the iteration
+	Close() error
+}
+
+// quoteIterator implements QuoteIteratorInterface using keyset pagination
+// on the quote time, so large series are never loaded at once
+type quoteIterator struct {
+	*keysetIterator[QuoteInterface]
+}
+
+var _ QuoteIteratorInterface = (*quoteIterator)(nil) // verify interface is implemented
+
+func (it *quoteIterator) Quote() QuoteInterface {
+	return it.current
+}
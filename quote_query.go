@@ -0,0 +1,156 @@
+package tradingstore
+
+import "time"
+
+// QuoteQuery is a shortcut for NewQuoteQuery
+func QuoteQuery() QuoteQueryInterface {
+	return NewQuoteQuery()
+}
+
+// NewQuoteQuery creates a new quote query
+func NewQuoteQuery() QuoteQueryInterface {
+	return &quoteQueryImplementation{
+		timeRangeQuery: newTimeRangeQuery(),
+	}
+}
+
+// quoteQueryCopy returns a copy of the query, so the store can change the
+// time range of a query without changing the query of the caller
+func quoteQueryCopy(options QuoteQueryInterface) QuoteQueryInterface {
+	query := QuoteQuery()
+
+	if options.IsColumnsSet() {
+		query.SetColumns(options.Columns())
+	}
+
+	if options.IsCountOnlySet() {
+		query.SetCountOnly(options.IsCountOnly())
+	}
+
+	if options.IsIDSet() {
+		query.SetID(options.ID())
+	}
+
+	if options.IsIDInSet() {
+		query.SetIDIn(options.IDIn())
+	}
+
+	if options.IsLimitSet() {
+		query.SetLimit(options.Limit())
+	}
+
+	if options.IsOffsetSet() {
+		query.SetOffset(options.Offset())
+	}
+
+	if options.IsOrderBySet() {
+		query.SetOrderBy(options.OrderBy())
+	}
+
+	if options.IsOrderDirectionSet() {
+		query.SetOrderDirection(options.OrderDirection())
+	}
+
+	if options.IsTimeSet() {
+		query.SetTime(options.Time())
+	}
+
+	if options.IsTimeGteSet() {
+		query.SetTimeGte(options.TimeGte())
+	}
+
+	if options.IsTimeLteSet() {
+		query.SetTimeLte(options.TimeLte())
+	}
+
+	return query
+}
+
+type quoteQueryImplementation struct {
+	timeRangeQuery
+}
+
+func (c *quoteQueryImplementation) Validate() error {
+	return c.validate("quote query")
+}
+
+func (c *quoteQueryImplementation) SetColumns(columns []string) QuoteQueryInterface {
+	c.properties["columns"] = columns
+
+	return c
+}
+
+func (c *quoteQueryImplementation) SetCountOnly(countOnly bool) QuoteQueryInterface {
+	c.properties["count_only"] = countOnly
+
+	return c
+}
+
+func (c *quoteQueryImplementation) SetID(id string) QuoteQueryInterface {
+	c.properties["id"] = id
+
+	return c
+}
+
+func (c *quoteQueryImplementation) SetIDIn(idIn []string) QuoteQueryInterface {
+	c.properties["id_in"] = idIn
+
+	return c
+}
+
+func (c *quoteQueryImplementation) SetLimit(limit int) QuoteQueryInterface {
+	c.properties["limit"] = limit
+
+	return c
+}
+
+func (c *quoteQueryImplementation) SetOffset(offset int) QuoteQueryInterface {
+	c.properties["offset"] = offset
+
+	return c
+}
+
+func (c *quoteQueryImplementation) SetOrderBy(orderBy string) QuoteQueryInterface {
+	c.properties["order_by"] = orderBy
+
+	return c
+}
+
+func (c *quoteQueryImplementation) SetOrderDirection(orderDirection string) QuoteQueryInterface {
+	c.properties["order_direction"] = orderDirection
+
+	return c
+}
+
+func (c *quoteQueryImplementation) SetTime(time string) QuoteQueryInterface {
+	c.properties["time"] = time
+
+	return c
+}
+
+// SetTimeT sets the time to match, as a time.Time in any location
+func (c *quoteQueryImplementation) SetTimeT(t time.Time) QuoteQueryInterface {
+	return c.SetTime(tickTimeFormat(t))
+}
+
+func (c *quoteQueryImplementation) SetTimeGte(timeGte string) QuoteQueryInterface {
+	c.properties["time_gte"] = timeGte
+
+	return c
+}
+
+// SetTimeGteT sets the inclusive lower time bound, as a time.Time in any location
+func (c *quoteQueryImplementation) SetTimeGteT(t time.Time) QuoteQueryInterface {
+	return c.SetTimeGte(tickTimeFormat(t))
+}
+
+func (c *quoteQueryImplementation) SetTimeLte(timeLte string) QuoteQueryInterface {
+	c.properties["time_lte"] = timeLte
+
+	return c
+}
+
+// SetTimeLteT sets the inclusive upper time bound, as a time.Time in any location
+func (c *quoteQueryImplementation) SetTimeLteT(t time.Time) QuoteQueryInterface {
+	return c.SetTimeLte(tickTimeFormat(t))
+}
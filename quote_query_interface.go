@@ -0,0 +1,56 @@
+package tradingstore
+
+import "time"
+
+type QuoteQueryInterface interface {
+	Validate() error
+
+	IsColumnsSet() bool
+	Columns() []string
+	SetColumns(columns []string) QuoteQueryInterface
+
+	IsCountOnlySet() bool
+	IsCountOnly() bool
+	SetCountOnly(countOnly bool) QuoteQueryInterface
+
+	IsTimeSet() bool
+	Time() string
+	SetTime(createdAt string) QuoteQueryInterface
+	SetTimeT(t time.Time) QuoteQueryInterface
+
+	IsTimeGteSet() bool
+	TimeGte() string
+	SetTimeGte(createdAtGte string) QuoteQueryInterface
+	SetTimeGteT(t time.Time) QuoteQueryInterface
+
+	IsTimeLteSet() bool
+	TimeLte() string
+	SetTimeLte(createdAtLte string) QuoteQueryInterface
+	SetTimeLteT(t time.Time) QuoteQueryInterface
+
+	IsIDSet() bool
+	ID() string
+	SetID(id string) QuoteQueryInterface
+
+	IsIDInSet() bool
+	IDIn() []string
+	SetIDIn(idIn []string) QuoteQueryInterface
+
+	IsLimitSet() bool
+	Limit() int
+	SetLimit(limit int) QuoteQueryInterface
+
+	IsOffsetSet() bool
+	Offset() int
+	SetOffset(offset int) QuoteQueryInterface
+
+	IsOrderBySet() bool
+	OrderBy() string
+	SetOrderBy(orderBy string) QuoteQueryInterface
+
+	IsOrderDirectionSet() bool
+	OrderDirection() string
+	SetOrderDirection(orderDirection string) QuoteQueryInterface
+
+	hasProperty(name string) bool
+}
@@ -0,0 +1,97 @@
+package replay
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ClockInterface is the clock the playback is paced against
+type ClockInterface interface {
+	// Now returns the current time of the clock
+	Now() time.Time
+
+	// Sleep waits for the duration, or returns the error of the context
+	// if it is done first
+	Sleep(ctx context.Context, duration time.Duration) error
+}
+
+// systemClock is the wall clock
+type systemClock struct{}
+
+var _ ClockInterface = (*systemClock)(nil) // verify interface is implemented
+
+// NewSystemClock returns the wall clock
+func NewSystemClock() ClockInterface {
+	return &systemClock{}
+}
+
+func (clock *systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (clock *systemClock) Sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ManualClock is a clock which only moves when slept on or advanced, so
+// paced playback runs instantly, i.e. in tests
+type ManualClock struct {
+	mutex sync.Mutex
+	now   time.Time
+	slept time.Duration
+}
+
+var _ ClockInterface = (*ManualClock)(nil) // verify interface is implemented
+
+// NewManualClock returns a manual clock starting at the given time
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now returns the current time of the clock
+func (clock *ManualClock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	return clock.now
+}
+
+// Sleep advances the clock by the duration without waiting
+func (clock *ManualClock) Sleep(ctx context.Context, duration time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	clock.now = clock.now.Add(duration)
+	clock.slept += duration
+
+	return nil
+}
+
+// Advance moves the clock forward, as time spent outside of the playback
+func (clock *ManualClock) Advance(duration time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	clock.now = clock.now.Add(duration)
+}
+
+// Slept returns the total duration slept on the clock
+func (clock *ManualClock) Slept() time.Duration {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	return clock.slept
+}
@@ -0,0 +1,314 @@
+// Package replay streams the prices of many tradingstore series in global
+// time order, for event-driven backtests. The series are merged from their
+// price iterators, so they are never loaded at once, and the prices of the
+// same time are delivered together as a batch.
+package replay
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dracory/tradingstore"
+)
+
+// Series identifies a price series of the store
+type Series struct {
+	Symbol    string
+	Exchange  string
+	Timeframe string
+}
+
+// Source is a series and the iterator streaming its prices, in ascending
+// time order
+type Source struct {
+	Series   Series
+	Iterator tradingstore.PriceIteratorInterface
+}
+
+// Event is a price of a series
+type Event struct {
+	Series Series
+	Price  tradingstore.PriceInterface
+}
+
+// Batch is the prices of all the series at one time, in the order of
+// the sources
+type Batch struct {
+	Time   time.Time
+	Events []Event
+}
+
+// Options define the playback of a replay
+type Options struct {
+	// Speed is the market time played per clock time, i.e. 1 for real time
+	// or 60 for a minute of prices per second. The batches are delivered as
+	// fast as possible if 0
+	Speed float64
+
+	// Clock is the clock the playback is paced against,
+	// defaults to the system clock
+	Clock ClockInterface
+}
+
+// Replay merges the prices of several series into batches in global time
+// order. Its methods follow PriceIteratorInterface: call Next until it
+// returns false, read each Batch, then check Err and Close the replay.
+type Replay struct {
+	ctx     context.Context
+	sources []Source
+	options Options
+	heads   replayHeap
+	batch   Batch
+	err     error
+	started bool
+	done    bool
+
+	// cursor is the time of the batch being merged, no source may go back before it
+	cursor time.Time
+
+	// the clock and market times of the first batch, the playback origin
+	clockStart  time.Time
+	marketStart time.Time
+}
+
+// New returns a replay merging the sources. The iterators are advanced
+// lazily by Next, and closed by Close.
+//
+// Parameters:
+// - ctx: the context, the playback stops when it is done
+// - sources: the series and their iterators
+// - options: the playback options
+//
+// Returns:
+// - *Replay: the replay
+// - error: if a source has no iterator or the speed is negative
+func New(ctx context.Context, sources []Source, options Options) (*Replay, error) {
+	for _, source := range sources {
+		if source.Iterator == nil {
+			return nil, errors.New("replay: iterator of " + source.Series.Symbol + " is nil")
+		}
+	}
+
+	if options.Speed < 0 {
+		return nil, errors.New("replay: speed must not be negative")
+	}
+
+	if options.Clock == nil {
+		options.Clock = NewSystemClock()
+	}
+
+	return &Replay{ctx: ctx, sources: sources, options: options}, nil
+}
+
+// NewFromStore returns a replay merging the stored prices of the series
+// matching the query options
+//
+// Parameters:
+// - ctx: the context, the playback stops when it is done
+// - store: the store to stream the prices from
+// - series: the series to replay
+// - query: the query options of the prices of each series, i.e. SetTimeGte
+// - options: the playback options
+//
+// Returns:
+// - *Replay: the replay
+// - error: if an iterator could not be opened
+func NewFromStore(ctx context.Context, store tradingstore.StoreInterface, series []Series, query tradingstore.PriceQueryInterface, options Options) (*Replay, error) {
+	if store == nil {
+		return nil, errors.New("replay: store is nil")
+	}
+
+	if query == nil {
+		query = tradingstore.NewPriceQuery()
+	}
+
+	sources := []Source{}
+
+	for _, s := range series {
+		iterator, err := store.PriceIterate(ctx, s.Symbol, s.Exchange, s.Timeframe, query)
+
+		if err != nil {
+			closeSources(sources)
+			return nil, err
+		}
+
+		sources = append(sources, Source{Series: s, Iterator: iterator})
+	}
+
+	return New(ctx, sources, options)
+}
+
+// Next advances to the batch of the next time, waiting for its time on the
+// clock when a speed is set. It returns false when all the series are
+// exhausted or an error occurred.
+func (r *Replay) Next() bool {
+	if r.done {
+		return false
+	}
+
+	if !r.started {
+		r.started = true
+
+		for index := range r.sources {
+			if !r.advance(index) {
+				return r.stop()
+			}
+		}
+	}
+
+	if r.heads.Len() < 1 {
+		return r.stop()
+	}
+
+	batchTime := r.heads[0].time
+	batch := Batch{Time: batchTime, Events: []Event{}}
+	r.cursor = batchTime
+
+	for r.heads.Len() > 0 && r.heads[0].time.Equal(batchTime) {
+		head := heap.Pop(&r.heads).(replayHead)
+
+		batch.Events = append(batch.Events, Event{Series: r.sources[head.source].Series, Price: head.price})
+
+		if !r.advance(head.source) {
+			return r.stop()
+		}
+	}
+
+	if err := r.wait(batchTime); err != nil {
+		r.err = err
+		return r.stop()
+	}
+
+	r.batch = batch
+
+	return true
+}
+
+// Batch returns the current batch
+func (r *Replay) Batch() Batch {
+	return r.batch
+}
+
+// Err returns the error which stopped the replay, if any
+func (r *Replay) Err() error {
+	return r.err
+}
+
+// Close stops the replay and closes the iterators of the sources
+func (r *Replay) Close() error {
+	r.done = true
+	return closeSources(r.sources)
+}
+
+// advance reads the next price of the source onto the heap, it returns
+// false if the source failed or is out of time order
+func (r *Replay) advance(index int) bool {
+	iterator := r.sources[index].Iterator
+
+	if !iterator.Next() {
+		if err := iterator.Err(); err != nil {
+			r.err = err
+			return false
+		}
+
+		return true
+	}
+
+	price := iterator.Price()
+	priceTime := price.TimeCarbon().StdTime().UTC()
+
+	if !r.cursor.IsZero() && priceTime.Before(r.cursor) {
+		r.err = errors.New("replay: prices of " + r.sources[index].Series.Symbol + " are not in ascending time order")
+		return false
+	}
+
+	heap.Push(&r.heads, replayHead{time: priceTime, source: index, price: price})
+
+	return true
+}
+
+// wait waits until the clock reaches the playback time of the batch
+func (r *Replay) wait(batchTime time.Time) error {
+	if err := r.ctx.Err(); err != nil {
+		return err
+	}
+
+	if r.options.Speed == 0 {
+		return nil
+	}
+
+	if r.clockStart.IsZero() {
+		r.clockStart = r.options.Clock.Now()
+		r.marketStart = batchTime
+		return nil
+	}
+
+	elapsed := time.Duration(float64(batchTime.Sub(r.marketStart)) / r.options.Speed)
+	duration := r.clockStart.Add(elapsed).Sub(r.options.Clock.Now())
+
+	if duration <= 0 {
+		return nil
+	}
+
+	return r.options.Clock.Sleep(r.ctx, duration)
+}
+
+// stop ends the replay
+func (r *Replay) stop() bool {
+	r.done = true
+	r.batch = Batch{}
+	return false
+}
+
+// closeSources closes the iterators of the sources, returning the first error
+func closeSources(sources []Source) error {
+	var first error
+
+	for _, source := range sources {
+		if err := source.Iterator.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+// replayHead is the next price of a source
+type replayHead struct {
+	time   time.Time
+	source int
+	price  tradingstore.PriceInterface
+}
+
+// replayHeap is a min-heap of the next prices of the sources, by time then
+// by source, so the events of a batch are in the order of the sources
+type replayHeap []replayHead
+
+func (h replayHeap) Len() int {
+	return len(h)
+}
+
+func (h replayHeap) Less(i, j int) bool {
+	if h[i].time.Equal(h[j].time) {
+		return h[i].source < h[j].source
+	}
+
+	return h[i].time.Before(h[j].time)
+}
+
+func (h replayHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *replayHeap) Push(x any) {
+	*h = append(*h, x.(replayHead))
+}
+
+func (h *replayHeap) Pop() any {
+	old := *h
+	head := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return head
+}
@@ -0,0 +1,261 @@
+package replay
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dracory/tradingstore"
+	"github.com/dromara/carbon/v2"
+	_ "modernc.org/sqlite"
+)
+
+// sliceIterator streams prices from a slice
+type sliceIterator struct {
+	prices []tradingstore.PriceInterface
+	index  int
+	closed bool
+}
+
+func (i *sliceIterator) Next() bool {
+	if i.closed || i.index >= len(i.prices) {
+		return false
+	}
+
+	i.index++
+
+	return true
+}
+
+func (i *sliceIterator) Price() tradingstore.PriceInterface {
+	return i.prices[i.index-1]
+}
+
+func (i *sliceIterator) Err() error {
+	return nil
+}
+
+func (i *sliceIterator) Close() error {
+	i.closed = true
+	return nil
+}
+
+func testPrices(minutes ...int) []tradingstore.PriceInterface {
+	prices := []tradingstore.PriceInterface{}
+	start := carbon.Parse("2020-01-01 00:00:00", carbon.UTC)
+
+	for _, minute := range minutes {
+		prices = append(prices, tradingstore.NewPrice().
+			SetTime(start.Copy().AddMinutes(minute).ToDateTimeString(carbon.UTC)).
+			SetOpen("1").
+			SetHigh("1").
+			SetLow("1").
+			SetClose("1").
+			SetVolume("1"))
+	}
+
+	return prices
+}
+
+func TestReplayMerge(t *testing.T) {
+	a := &sliceIterator{prices: testPrices(0, 2, 3)}
+	b := &sliceIterator{prices: testPrices(1, 2)}
+
+	replay, err := New(context.Background(), []Source{
+		{Series: Series{Symbol: "A"}, Iterator: a},
+		{Series: Series{Symbol: "B"}, Iterator: b},
+	}, Options{})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	symbols := [][]string{}
+
+	for replay.Next() {
+		batch := replay.Batch()
+		batchSymbols := []string{}
+
+		for _, event := range batch.Events {
+			if !event.Price.TimeCarbon().StdTime().Equal(batch.Time) {
+				t.Fatal("An event MUST be at the time of its batch")
+			}
+
+			batchSymbols = append(batchSymbols, event.Series.Symbol)
+		}
+
+		symbols = append(symbols, batchSymbols)
+	}
+
+	if err := replay.Err(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expected := [][]string{{"A"}, {"B"}, {"A", "B"}, {"A"}}
+
+	if len(symbols) != len(expected) {
+		t.Fatal("Batches count MUST BE", len(expected), ", found:", symbols)
+	}
+
+	for index := range expected {
+		if len(symbols[index]) != len(expected[index]) || symbols[index][0] != expected[index][0] {
+			t.Fatal("Batch", index, "MUST BE", expected[index], ", found:", symbols[index])
+		}
+	}
+
+	if err := replay.Close(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !a.closed || !b.closed {
+		t.Fatal("The iterators MUST be closed")
+	}
+}
+
+func TestReplaySpeed(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	replay, err := New(context.Background(), []Source{
+		{Series: Series{Symbol: "A"}, Iterator: &sliceIterator{prices: testPrices(0, 1, 3)}},
+	}, Options{Speed: 60, Clock: clock})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	count := 0
+
+	for replay.Next() {
+		count++
+
+		// time spent handling the batch counts towards the next wait
+		clock.Advance(500 * time.Millisecond)
+	}
+
+	if count != 3 {
+		t.Fatal("Batches count MUST BE 3, found:", count)
+	}
+
+	// 3 minutes at 60x is 3 seconds, 1 second of which was spent handling
+	// the batches before the last one
+	if clock.Slept() != 2*time.Second {
+		t.Fatal("Slept MUST BE 2s, found:", clock.Slept())
+	}
+}
+
+func TestReplayOutOfOrder(t *testing.T) {
+	replay, err := New(context.Background(), []Source{
+		{Series: Series{Symbol: "A"}, Iterator: &sliceIterator{prices: testPrices(2, 1)}},
+	}, Options{})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for replay.Next() {
+	}
+
+	if replay.Err() == nil {
+		t.Fatal("Prices out of time order MUST stop the replay with an error")
+	}
+}
+
+func TestReplayCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	replay, err := New(ctx, []Source{
+		{Series: Series{Symbol: "A"}, Iterator: &sliceIterator{prices: testPrices(0, 1)}},
+	}, Options{})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !replay.Next() {
+		t.Fatal("The first batch MUST be delivered")
+	}
+
+	cancel()
+
+	if replay.Next() || replay.Err() == nil {
+		t.Fatal("A cancelled context MUST stop the replay with an error")
+	}
+}
+
+func TestNewFromStore(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err := tradingstore.NewStore(tradingstore.NewStoreOptions{
+		DB:                   db,
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	minutes := map[string][]int{
+		"AAPL": {0, 1, 2},
+		"MSFT": {1, 3},
+	}
+
+	series := []Series{}
+
+	for _, symbol := range []string{"AAPL", "MSFT"} {
+		err := store.InstrumentCreate(ctx, tradingstore.NewInstrument().
+			SetSymbol(symbol).
+			SetExchange("NASDAQ").
+			SetTimeframes([]string{tradingstore.TIMEFRAME_1_MINUTE}))
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		series = append(series, Series{Symbol: symbol, Exchange: "NASDAQ", Timeframe: tradingstore.TIMEFRAME_1_MINUTE})
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for symbol, symbolMinutes := range minutes {
+		for _, price := range testPrices(symbolMinutes...) {
+			if err := store.PriceCreate(ctx, symbol, "NASDAQ", tradingstore.TIMEFRAME_1_MINUTE, price); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		}
+	}
+
+	replay, err := NewFromStore(ctx, store, series, nil, Options{})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	defer replay.Close()
+
+	batches, events := 0, 0
+
+	for replay.Next() {
+		batches++
+		events += len(replay.Batch().Events)
+	}
+
+	if err := replay.Err(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if batches != 4 || events != 5 {
+		t.Fatal("Expected 4 batches of 5 events, found:", batches, events)
+	}
+}
@@ -1,6 +1,7 @@
 package tradingstore
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/dracory/sb"
@@ -16,53 +17,357 @@ func (store *Store) PriceTableName(symbol string, exchange string, timeframe str
 	return priceTableName + strings.ToLower(symbol) + "_" + strings.ToLower(timeframe)
 }
 
-func (store *Store) sqlTablePriceCreate(symbol string, exchange string, timeframe string) string {
+// IndicatorTableName returns the name of the indicator table of a price series
+func (store *Store) IndicatorTableName(symbol string, exchange string, timeframe string) string {
+	indicatorTableName := store.indicatorTableNamePrefix
+
+	if exchange != "" {
+		return indicatorTableName + strings.ToLower(symbol) + "_" + strings.ToLower(exchange) + "_" + strings.ToLower(timeframe)
+	}
+
+	return indicatorTableName + strings.ToLower(symbol) + "_" + strings.ToLower(timeframe)
+}
+
+// TickTableName returns the name of the tick table of an instrument
+func (store *Store) TickTableName(symbol string, exchange string) string {
+	tickTableName := store.tickTableNamePrefix
+
+	if exchange != "" {
+		return tickTableName + strings.ToLower(symbol) + "_" + strings.ToLower(exchange)
+	}
+
+	return tickTableName + strings.ToLower(symbol)
+}
+
+// OrderBookTableName returns the name of the order book snapshot table of an instrument
+func (store *Store) OrderBookTableName(symbol string, exchange string) string {
+	orderBookTableName := store.orderBookTableNamePrefix
+
+	if exchange != "" {
+		return orderBookTableName + strings.ToLower(symbol) + "_" + strings.ToLower(exchange)
+	}
+
+	return orderBookTableName + strings.ToLower(symbol)
+}
+
+// QuoteTableName returns the name of the quote table of an instrument
+func (store *Store) QuoteTableName(symbol string, exchange string) string {
+	quoteTableName := store.quoteTableNamePrefix
+
+	if exchange != "" {
+		return quoteTableName + strings.ToLower(symbol) + "_" + strings.ToLower(exchange)
+	}
+
+	return quoteTableName + strings.ToLower(symbol)
+}
+
+// sqlTablePriceCreate returns the sql to create a price table, with the
+// price and volume columns holding the given number of decimals, and
+// the extended price fields enabled for the instrument
+func (store *Store) sqlTablePriceCreate(tableName string, precision PriceColumnPrecision, fields []string) string {
 	builder := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
-		Table(store.PriceTableName(symbol, exchange, timeframe)).
-		Column(sb.Column{
+		Table(tableName)
+
+	for _, column := range store.priceTableColumns(precision, fields) {
+		builder = builder.Column(column)
+	}
+
+	// Create the table
+	sql, err := builder.CreateIfNotExists()
+	if err != nil {
+		return ""
+	}
+
+	return sql
+}
+
+// priceTableColumns returns the columns of a price table, followed by
+// the given extended price fields
+func (store *Store) priceTableColumns(precision PriceColumnPrecision, fields []string) []sb.Column {
+	columns := []sb.Column{
+		{
 			Name:       COLUMN_ID,
 			Type:       sb.COLUMN_TYPE_STRING,
 			Length:     40,
 			PrimaryKey: true,
-		}).
-		Column(sb.Column{
-			Name:     COLUMN_OPEN,
-			Type:     sb.COLUMN_TYPE_DECIMAL,
+		},
+		priceTableNumericColumn(COLUMN_OPEN, 12, precision.Price),
+		priceTableNumericColumn(COLUMN_HIGH, 12, precision.Price),
+		priceTableNumericColumn(COLUMN_LOW, 12, precision.Price),
+		priceTableNumericColumn(COLUMN_CLOSE, 12, precision.Price),
+		priceTableNumericColumn(COLUMN_VOLUME, 20, precision.Volume),
+		store.priceTableTimeColumn(),
+	}
+
+	for _, field := range fields {
+		column := priceTableFieldColumn(field, precision)
+
+		if column.Name != "" {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+// priceTableFieldColumn returns the column of an extended price field, empty
+// if the field is not supported. The columns are nullable, so they can be
+// added to existing tables, and bars without the field are stored as NULL
+func priceTableFieldColumn(field string, precision PriceColumnPrecision) sb.Column {
+	var column sb.Column
+
+	switch field {
+	case COLUMN_TRADES:
+		column = priceTableNumericColumn(field, 20, 0)
+	case COLUMN_VWAP, COLUMN_BID_CLOSE, COLUMN_ASK_CLOSE:
+		column = priceTableNumericColumn(field, 12, precision.Price)
+	case COLUMN_OPEN_INTEREST, COLUMN_TAKER_BUY_VOLUME:
+		column = priceTableNumericColumn(field, 20, precision.Volume)
+	default:
+		return sb.Column{}
+	}
+
+	column.Nullable = true
+
+	return column
+}
+
+// priceTableTimeColumn returns the time column of a price table,
+// in the time storage format of the store
+func (store *Store) priceTableTimeColumn() sb.Column {
+	if store.priceTimeStorage == PRICE_TIME_STORAGE_EPOCH_MILLIS {
+		return sb.Column{
+			Name:     COLUMN_TIME,
+			Type:     sb.COLUMN_TYPE_INTEGER,
 			Length:   20,
-			Decimals: 8,
 			Nullable: false,
-		}).
-		Column(sb.Column{
-			Name:     COLUMN_HIGH,
-			Type:     sb.COLUMN_TYPE_DECIMAL,
+		}
+	}
+
+	return sb.Column{
+		Name:     COLUMN_TIME,
+		Type:     sb.COLUMN_TYPE_DATETIME,
+		Nullable: false,
+	}
+}
+
+// priceTableNumericColumn returns a decimal column with the given digits before
+// and after the decimal point, or an integer column if there are no decimals
+// (the builder can not express a DECIMAL without decimals)
+func priceTableNumericColumn(name string, integerDigits int, decimals int) sb.Column {
+	if decimals < 1 {
+		return sb.Column{
+			Name:     name,
+			Type:     sb.COLUMN_TYPE_INTEGER,
+			Length:   integerDigits,
+			Nullable: false,
+		}
+	}
+
+	return sb.Column{
+		Name:     name,
+		Type:     sb.COLUMN_TYPE_DECIMAL,
+		Length:   integerDigits + decimals,
+		Decimals: decimals,
+		Nullable: false,
+	}
+}
+
+// sqlTableTickCreate returns the sql to create the tick table of an instrument,
+// with the price and size columns holding the given number of decimals
+func (store *Store) sqlTableTickCreate(symbol string, exchange string, precision PriceColumnPrecision) string {
+	builder := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
+		Table(store.TickTableName(symbol, exchange))
+
+	for _, column := range store.tickTableColumns(precision) {
+		builder = builder.Column(column)
+	}
+
+	sql, err := builder.CreateIfNotExists()
+	if err != nil {
+		return ""
+	}
+
+	return sql
+}
+
+// sqlTableIndexCreate returns the SQL to create an index of a column of a
+// table, if it does not exist on the databases which support it
+func (store *Store) sqlTableIndexCreate(tableName string, indexName string, columnName string) string {
+	sql, err := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
+		Table(tableName).
+		CreateIndexWithOptions(indexName, sb.IndexOptions{
+			IfNotExists: true,
+			Columns:     []sb.IndexColumn{{Name: columnName}},
+		})
+
+	if err != nil {
+		return ""
+	}
+
+	return sql
+}
+
+// tickTableColumns returns the columns of a tick table. The time is stored
+// as an integer of microseconds since the Unix epoch
+func (store *Store) tickTableColumns(precision PriceColumnPrecision) []sb.Column {
+	return []sb.Column{
+		{
+			Name:       COLUMN_ID,
+			Type:       sb.COLUMN_TYPE_STRING,
+			Length:     40,
+			PrimaryKey: true,
+		},
+		{
+			Name:     COLUMN_TIME,
+			Type:     sb.COLUMN_TYPE_INTEGER,
 			Length:   20,
-			Decimals: 8,
 			Nullable: false,
-		}).
-		Column(sb.Column{
-			Name:     COLUMN_LOW,
-			Type:     sb.COLUMN_TYPE_DECIMAL,
+		},
+		priceTableNumericColumn(COLUMN_PRICE, 12, precision.Price),
+		priceTableNumericColumn(COLUMN_SIZE, 20, precision.Volume),
+		{
+			Name:     COLUMN_SIDE,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   4,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_TRADE_ID,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   64,
+			Nullable: true,
+		},
+	}
+}
+
+// sqlTableQuoteCreate returns the sql to create the quote table of an instrument,
+// with the bid, ask and size columns holding the given number of decimals
+func (store *Store) sqlTableQuoteCreate(symbol string, exchange string, precision PriceColumnPrecision) string {
+	builder := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
+		Table(store.QuoteTableName(symbol, exchange))
+
+	for _, column := range store.quoteTableColumns(precision) {
+		builder = builder.Column(column)
+	}
+
+	sql, err := builder.CreateIfNotExists()
+	if err != nil {
+		return ""
+	}
+
+	return sql
+}
+
+// quoteTableColumns returns the columns of a quote table. The time is stored
+// as an integer of microseconds since the Unix epoch, the sizes are optional
+func (store *Store) quoteTableColumns(precision PriceColumnPrecision) []sb.Column {
+	bidSize := priceTableNumericColumn(COLUMN_BID_SIZE, 20, precision.Volume)
+	bidSize.Nullable = true
+
+	askSize := priceTableNumericColumn(COLUMN_ASK_SIZE, 20, precision.Volume)
+	askSize.Nullable = true
+
+	return []sb.Column{
+		{
+			Name:       COLUMN_ID,
+			Type:       sb.COLUMN_TYPE_STRING,
+			Length:     40,
+			PrimaryKey: true,
+		},
+		{
+			Name:     COLUMN_TIME,
+			Type:     sb.COLUMN_TYPE_INTEGER,
 			Length:   20,
-			Decimals: 8,
 			Nullable: false,
-		}).
-		Column(sb.Column{
-			Name:     COLUMN_CLOSE,
-			Type:     sb.COLUMN_TYPE_DECIMAL,
+		},
+		priceTableNumericColumn(COLUMN_BID, 12, precision.Price),
+		priceTableNumericColumn(COLUMN_ASK, 12, precision.Price),
+		bidSize,
+		askSize,
+	}
+}
+
+// sqlTableOrderBookCreate returns the sql to create the order book snapshot
+// table of an instrument
+func (store *Store) sqlTableOrderBookCreate(symbol string, exchange string) string {
+	builder := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
+		Table(store.OrderBookTableName(symbol, exchange))
+
+	for _, column := range store.orderBookTableColumns() {
+		builder = builder.Column(column)
+	}
+
+	sql, err := builder.CreateIfNotExists()
+	if err != nil {
+		return ""
+	}
+
+	return sql
+}
+
+// orderBookTableColumns returns the columns of an order book snapshot table.
+// The time is stored as an integer of microseconds since the Unix epoch, and
+// the levels of each side as compact price:size pairs
+func (store *Store) orderBookTableColumns() []sb.Column {
+	return []sb.Column{
+		{
+			Name:       COLUMN_ID,
+			Type:       sb.COLUMN_TYPE_STRING,
+			Length:     40,
+			PrimaryKey: true,
+		},
+		{
+			Name:     COLUMN_TIME,
+			Type:     sb.COLUMN_TYPE_INTEGER,
 			Length:   20,
-			Decimals: 8,
 			Nullable: false,
+		},
+		{
+			Name:     COLUMN_BIDS,
+			Type:     sb.COLUMN_TYPE_TEXT,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_ASKS,
+			Type:     sb.COLUMN_TYPE_TEXT,
+			Nullable: true,
+		},
+	}
+}
+
+func (store *Store) sqlTableIndicatorCreate(symbol string, exchange string, timeframe string) string {
+	builder := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
+		Table(store.IndicatorTableName(symbol, exchange, timeframe)).
+		Column(sb.Column{
+			Name:       COLUMN_ID,
+			Type:       sb.COLUMN_TYPE_STRING,
+			Length:     40,
+			PrimaryKey: true,
 		}).
 		Column(sb.Column{
-			Name:     COLUMN_VOLUME,
-			Type:     sb.COLUMN_TYPE_INTEGER,
-			Length:   20,
+			Name:     COLUMN_NAME,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   50,
 			Nullable: false,
 		}).
 		Column(sb.Column{
-			Name:     COLUMN_TIME,
-			Type:     sb.COLUMN_TYPE_DATETIME,
+			Name:     COLUMN_PARAMS_HASH,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   64,
 			Nullable: false,
+		}).
+		Column(store.priceTableTimeColumn()).
+		Column(sb.Column{
+			Name:     COLUMN_INDICATOR_VALUES,
+			Type:     sb.COLUMN_TYPE_TEXT,
+			Nullable: true,
+		}).
+		Column(sb.Column{
+			Name:     COLUMN_CREATED_AT,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   50,
+			Nullable: true,
 		})
 
 	// Create the table
@@ -76,82 +381,292 @@ func (store *Store) sqlTablePriceCreate(symbol string, exchange string, timefram
 
 func (store *Store) sqlTableInstrumentCreate() string {
 	builder := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
-		Table(store.instrumentTableName).
-		Column(sb.Column{
+		Table(store.instrumentTableName)
+
+	for _, column := range store.instrumentTableColumns() {
+		builder = builder.Column(column)
+	}
+
+	// Create the table
+	sql, err := builder.CreateIfNotExists()
+	if err != nil {
+		return ""
+	}
+
+	return sql
+}
+
+// instrumentTableColumns returns the columns of the instrument table
+func (store *Store) instrumentTableColumns() []sb.Column {
+	return []sb.Column{
+		{
 			Name:       COLUMN_ID,
 			Type:       sb.COLUMN_TYPE_STRING,
 			Length:     40,
 			PrimaryKey: true,
-		}).
-		Column(sb.Column{
+		},
+		{
 			Name:     COLUMN_NAME,
 			Type:     sb.COLUMN_TYPE_STRING,
 			Length:   100,
 			Nullable: true,
-		}).
-		Column(sb.Column{
+		},
+		{
 			Name:     COLUMN_STATUS,
 			Type:     sb.COLUMN_TYPE_STRING,
 			Length:   20,
 			Nullable: true,
-		}).
-		Column(sb.Column{
+		},
+		{
 			Name:     COLUMN_ASSET_CLASS,
 			Type:     sb.COLUMN_TYPE_STRING,
 			Length:   40,
 			Nullable: true,
-		}).
-		Column(sb.Column{
+		},
+		{
 			Name:     COLUMN_SYMBOL,
 			Type:     sb.COLUMN_TYPE_STRING,
-			Length:   10,
+			Length:   50,
 			Nullable: true,
-		}).
-		Column(sb.Column{
+		},
+		{
 			Name:     COLUMN_EXCHANGE,
 			Type:     sb.COLUMN_TYPE_STRING,
 			Length:   50,
 			Nullable: true,
-		}).
-		Column(sb.Column{
+		},
+		{
 			Name:     COLUMN_TIMEFRAMES,
 			Type:     sb.COLUMN_TYPE_STRING,
 			Length:   100,
 			Nullable: true,
-		}).
-		Column(sb.Column{
+		},
+		{
+			Name:     COLUMN_SOURCE_TIMEFRAME,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   20,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_PRICE_FIELDS,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   255,
+			Nullable: true,
+		},
+		{
 			Name:     COLUMN_DESCRIPTION,
 			Type:     sb.COLUMN_TYPE_TEXT,
 			Nullable: true,
-		}).
-		Column(sb.Column{
+		},
+		{
 			Name:     COLUMN_MEMO,
 			Type:     sb.COLUMN_TYPE_TEXT,
 			Nullable: true,
-		}).
-		Column(sb.Column{
+		},
+		{
 			Name:     COLUMN_METAS,
 			Type:     sb.COLUMN_TYPE_LONGTEXT,
 			Nullable: true,
-		}).
-		Column(sb.Column{
+		},
+		{
+			Name:     COLUMN_UNDERLYING_ID,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   40,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_EXPIRY,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   20,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_STRIKE,
+			Type:     sb.COLUMN_TYPE_DECIMAL,
+			Length:   20,
+			Decimals: 8,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_OPTION_TYPE,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   10,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_MULTIPLIER,
+			Type:     sb.COLUMN_TYPE_DECIMAL,
+			Length:   20,
+			Decimals: 8,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_SETTLEMENT_TYPE,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   20,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_BASE_ASSET,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   20,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_QUOTE_CURRENCY,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   20,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_TICK_SIZE,
+			Type:     sb.COLUMN_TYPE_DECIMAL,
+			Length:   20,
+			Decimals: 8,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_LOT_SIZE,
+			Type:     sb.COLUMN_TYPE_DECIMAL,
+			Length:   20,
+			Decimals: 8,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_MIN_NOTIONAL,
+			Type:     sb.COLUMN_TYPE_DECIMAL,
+			Length:   20,
+			Decimals: 8,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_PRICE_PRECISION,
+			Type:     sb.COLUMN_TYPE_INTEGER,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_VOLUME_PRECISION,
+			Type:     sb.COLUMN_TYPE_INTEGER,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_SYNTHETIC,
+			Type:     sb.COLUMN_TYPE_TEXT,
+			Nullable: true,
+		},
+		{
 			Name:     COLUMN_CREATED_AT,
 			Type:     sb.COLUMN_TYPE_STRING,
 			Length:   50,
 			Nullable: true,
-		}).
-		Column(sb.Column{
+		},
+		{
 			Name:     COLUMN_UPDATED_AT,
 			Type:     sb.COLUMN_TYPE_STRING,
 			Length:   50,
 			Nullable: true,
-		}).
-		Column(sb.Column{
+		},
+		{
 			Name:     COLUMN_SOFT_DELETED_AT,
 			Type:     sb.COLUMN_TYPE_STRING,
 			Length:   50,
 			Nullable: true,
-		})
+		},
+	}
+}
+
+func (store *Store) sqlTableExchangeCalendarCreate() string {
+	builder := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
+		Table(store.exchangeCalendarTableName)
+
+	for _, column := range store.exchangeCalendarTableColumns() {
+		builder = builder.Column(column)
+	}
+
+	// Create the table
+	sql, err := builder.CreateIfNotExists()
+	if err != nil {
+		return ""
+	}
+
+	return sql
+}
+
+// exchangeCalendarTableColumns returns the columns of the exchange calendar table
+func (store *Store) exchangeCalendarTableColumns() []sb.Column {
+	return []sb.Column{
+		{
+			Name:       COLUMN_ID,
+			Type:       sb.COLUMN_TYPE_STRING,
+			Length:     40,
+			PrimaryKey: true,
+		},
+		{
+			Name:     COLUMN_EXCHANGE,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   50,
+			Nullable: false,
+		},
+		{
+			Name:     COLUMN_NAME,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   100,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_TIMEZONE,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   50,
+			Nullable: false,
+		},
+		{
+			Name:     COLUMN_DAY_ANCHOR,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   5,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_SESSIONS,
+			Type:     sb.COLUMN_TYPE_TEXT,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_HOLIDAYS,
+			Type:     sb.COLUMN_TYPE_LONGTEXT,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_HALF_DAYS,
+			Type:     sb.COLUMN_TYPE_LONGTEXT,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_MEMO,
+			Type:     sb.COLUMN_TYPE_TEXT,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_CREATED_AT,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   50,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_UPDATED_AT,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   50,
+			Nullable: true,
+		},
+	}
+}
+
+func (store *Store) sqlTableCorporateActionCreate() string {
+	builder := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
+		Table(store.corporateActionTableName)
+
+	for _, column := range store.corporateActionTableColumns() {
+		builder = builder.Column(column)
+	}
 
 	// Create the table
 	sql, err := builder.CreateIfNotExists()
@@ -162,6 +677,192 @@ func (store *Store) sqlTableInstrumentCreate() string {
 	return sql
 }
 
+// corporateActionTableColumns returns the columns of the corporate action table
+func (store *Store) corporateActionTableColumns() []sb.Column {
+	return []sb.Column{
+		{
+			Name:       COLUMN_ID,
+			Type:       sb.COLUMN_TYPE_STRING,
+			Length:     40,
+			PrimaryKey: true,
+		},
+		{
+			Name:     COLUMN_INSTRUMENT_ID,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   40,
+			Nullable: false,
+		},
+		{
+			Name:     COLUMN_ACTION_TYPE,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   20,
+			Nullable: false,
+		},
+		{
+			Name:     COLUMN_EX_DATE,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   10,
+			Nullable: false,
+		},
+		{
+			Name:     COLUMN_RATIO,
+			Type:     sb.COLUMN_TYPE_DECIMAL,
+			Length:   20,
+			Decimals: 8,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_AMOUNT,
+			Type:     sb.COLUMN_TYPE_DECIMAL,
+			Length:   20,
+			Decimals: 8,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_OLD_SYMBOL,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   10,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_NEW_SYMBOL,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   10,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_MEMO,
+			Type:     sb.COLUMN_TYPE_TEXT,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_CREATED_AT,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   50,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_UPDATED_AT,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   50,
+			Nullable: true,
+		},
+	}
+}
+
+func (store *Store) sqlTableContinuousContractCreate() string {
+	builder := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
+		Table(store.continuousContractTableName)
+
+	for _, column := range store.continuousContractTableColumns() {
+		builder = builder.Column(column)
+	}
+
+	// Create the table
+	sql, err := builder.CreateIfNotExists()
+	if err != nil {
+		return ""
+	}
+
+	return sql
+}
+
+// continuousContractTableColumns returns the columns of the continuous contract table
+func (store *Store) continuousContractTableColumns() []sb.Column {
+	return []sb.Column{
+		{
+			Name:       COLUMN_ID,
+			Type:       sb.COLUMN_TYPE_STRING,
+			Length:     40,
+			PrimaryKey: true,
+		},
+		{
+			Name:     COLUMN_SYMBOL,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   20,
+			Nullable: false,
+		},
+		{
+			Name:     COLUMN_EXCHANGE,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   50,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_NAME,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   100,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_CONTRACTS,
+			Type:     sb.COLUMN_TYPE_LONGTEXT,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_ROLL_RULE,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   20,
+			Nullable: false,
+		},
+		{
+			Name:     COLUMN_ADJUSTMENT_METHOD,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   20,
+			Nullable: false,
+		},
+		{
+			Name:     COLUMN_MEMO,
+			Type:     sb.COLUMN_TYPE_TEXT,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_CREATED_AT,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   50,
+			Nullable: true,
+		},
+		{
+			Name:     COLUMN_UPDATED_AT,
+			Type:     sb.COLUMN_TYPE_STRING,
+			Length:   50,
+			Nullable: true,
+		},
+	}
+}
+
+// sqlTableColumnAdd returns the SQL to add a column to an existing table
+func (store *Store) sqlTableColumnAdd(tableName string, column sb.Column) string {
+	sql, err := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
+		TableColumnAdd(tableName, column)
+
+	if err != nil {
+		return ""
+	}
+
+	return sql
+}
+
+// sqlTableColumnWiden returns the SQL to change the length of a string
+// column of an existing MySQL or PostgreSQL table
+func (store *Store) sqlTableColumnWiden(tableName string, column sb.Column) string {
+	switch sb.DatabaseDriverName(store.db) {
+	case sb.DIALECT_MYSQL:
+		sql, err := sb.NewBuilder(sb.DIALECT_MYSQL).
+			TableColumnChange(tableName, column)
+
+		if err != nil {
+			return ""
+		}
+
+		return sql
+	case sb.DIALECT_POSTGRES:
+		return `ALTER TABLE "` + tableName + `" ALTER COLUMN "` + column.Name + `" TYPE VARCHAR(` + strconv.Itoa(column.Length) + `);`
+	}
+
+	return ""
+}
+
 func (store *Store) sqlIndexesCreate() string {
 	sql := ""
 
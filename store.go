@@ -3,9 +3,15 @@ package tradingstore
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log/slog"
+	"strings"
+	"sync/atomic"
 
+	"github.com/doug-martin/goqu/v9"
 	"github.com/dracory/database"
+	"github.com/dracory/sb"
+	"github.com/spf13/cast"
 )
 
 // ============================================================================
@@ -18,9 +24,50 @@ type Store struct {
 	// priceTableNamePrefix is the prefix of the price table
 	priceTableNamePrefix string
 
+	// indicatorTableNamePrefix is the prefix of the indicator tables,
+	// indicator persistence is disabled when empty
+	indicatorTableNamePrefix string
+
+	// tickTableNamePrefix is the prefix of the tick tables,
+	// tick storage is disabled when empty
+	tickTableNamePrefix string
+
+	// orderBookTableNamePrefix is the prefix of the order book snapshot tables,
+	// order book storage is disabled when empty
+	orderBookTableNamePrefix string
+
+	// orderBookLevels is the number of top levels kept per side of the
+	// order book snapshots, all the levels when 0
+	orderBookLevels int
+
+	// quoteTableNamePrefix is the prefix of the quote tables,
+	// quote storage is disabled when empty
+	quoteTableNamePrefix string
+
+	// priceColumnPrecisions are the decimals of the price table columns per asset class
+	priceColumnPrecisions map[string]PriceColumnPrecision
+
+	// priceTimeStorage is the format of the time column of the price tables
+	priceTimeStorage string
+
 	// instrumentTableName is the name of the instrument table
 	instrumentTableName string
 
+	// exchangeCalendarTableName is the name of the exchange calendar table
+	exchangeCalendarTableName string
+
+	// exchangeCalendarTableChecked and exchangeCalendarTableFound cache
+	// whether the exchange calendar table exists, checked on the first
+	// calendar lookup of an instrument
+	exchangeCalendarTableChecked atomic.Bool
+	exchangeCalendarTableFound   atomic.Bool
+
+	// corporateActionTableName is the name of the corporate action table
+	corporateActionTableName string
+
+	// continuousContractTableName is the name of the continuous contract table
+	continuousContractTableName string
+
 	// useMultipleExchanges enables or disables the use of multiple exchanges
 	// if true, a price table will be created for each exchange, i.e price_eurusd_binance_1min
 	// if false, a price table will be created for the default exchange, i.e price_eurusd_1min
@@ -47,6 +94,8 @@ type Store struct {
 // ============================================================================
 
 // AutoMigrateInstruments auto migrates the instrument table
+// It will create the table if it does not exist, and add any columns
+// missing from a table created by an older version
 func (store *Store) AutoMigrateInstruments(ctx context.Context) error {
 	sql := store.sqlTableInstrumentCreate()
 
@@ -56,11 +105,77 @@ func (store *Store) AutoMigrateInstruments(ctx context.Context) error {
 		return err
 	}
 
+	err = store.autoMigrateColumns(ctx, store.instrumentTableName, store.instrumentTableColumns())
+
+	if err != nil {
+		return err
+	}
+
+	// the symbol column was widened to fit i.e. OCC option symbols
+	return store.autoMigrateColumnLengths(ctx, store.instrumentTableName, store.instrumentTableColumns())
+}
+
+// AutoMigrateContinuousContracts auto migrates the continuous contract table
+// It will create the table if it does not exist, and add any columns
+// missing from a table created by an older version
+func (store *Store) AutoMigrateContinuousContracts(ctx context.Context) error {
+	sql := store.sqlTableContinuousContractCreate()
+
+	_, err := store.db.Exec(sql)
+
+	if err != nil {
+		return err
+	}
+
+	return store.autoMigrateColumns(ctx, store.continuousContractTableName, store.continuousContractTableColumns())
+}
+
+// AutoMigrateCorporateActions auto migrates the corporate action table
+// It will create the table if it does not exist, and add any columns
+// missing from a table created by an older version
+func (store *Store) AutoMigrateCorporateActions(ctx context.Context) error {
+	sql := store.sqlTableCorporateActionCreate()
+
+	_, err := store.db.Exec(sql)
+
+	if err != nil {
+		return err
+	}
+
+	return store.autoMigrateColumns(ctx, store.corporateActionTableName, store.corporateActionTableColumns())
+}
+
+// AutoMigrateExchangeCalendars auto migrates the exchange calendar table
+// It will create the table if it does not exist, and add any columns
+// missing from a table created by an older version
+func (store *Store) AutoMigrateExchangeCalendars(ctx context.Context) error {
+	sql := store.sqlTableExchangeCalendarCreate()
+
+	_, err := store.db.Exec(sql)
+
+	if err != nil {
+		return err
+	}
+
+	err = store.autoMigrateColumns(ctx, store.exchangeCalendarTableName, store.exchangeCalendarTableColumns())
+
+	if err != nil {
+		return err
+	}
+
+	store.exchangeCalendarTableFound.Store(true)
+	store.exchangeCalendarTableChecked.Store(true)
+
 	return nil
 }
 
 // AutoMigratePrices auto migrates the price tables
-// It will create a price table for each instrument and each timeframe
+// It will create a price table for each instrument and each timeframe,
+// an indicator table if indicator persistence is enabled,
+// a tick table indexed on time for each instrument if tick storage is enabled,
+// a quote table for each instrument if quote storage is enabled,
+// and an order book snapshot table for each instrument if order book
+// storage is enabled
 // You will need to call this method when you create a new instrument
 func (store *Store) AutoMigratePrices(ctx context.Context) error {
 	instruments, err := store.InstrumentList(ctx, InstrumentQuery())
@@ -71,11 +186,33 @@ func (store *Store) AutoMigratePrices(ctx context.Context) error {
 
 	sqls := []string{}
 	for _, instrument := range instruments {
+		// the prices of synthetic instruments are computed from their legs
+		if instrument.IsSynthetic() {
+			continue
+		}
+
+		if store.tickTableNamePrefix != "" {
+			sqls = append(sqls, store.sqlTableTickCreate(instrument.Symbol(), instrument.Exchange(), store.priceColumnPrecision(instrument)))
+		}
+
+		if store.quoteTableNamePrefix != "" {
+			sqls = append(sqls, store.sqlTableQuoteCreate(instrument.Symbol(), instrument.Exchange(), store.priceColumnPrecision(instrument)))
+		}
+
+		if store.orderBookTableNamePrefix != "" {
+			sqls = append(sqls, store.sqlTableOrderBookCreate(instrument.Symbol(), instrument.Exchange()))
+		}
+
 		timeframes := instrument.Timeframes()
 
 		for _, timeframe := range timeframes {
-			sql := store.sqlTablePriceCreate(instrument.Symbol(), instrument.Exchange(), timeframe)
+			tableName := store.PriceTableName(instrument.Symbol(), instrument.Exchange(), timeframe)
+			sql := store.sqlTablePriceCreate(tableName, store.priceColumnPrecision(instrument), instrument.PriceFields())
 			sqls = append(sqls, sql)
+
+			if store.indicatorTableNamePrefix != "" {
+				sqls = append(sqls, store.sqlTableIndicatorCreate(instrument.Symbol(), instrument.Exchange(), timeframe))
+			}
 		}
 	}
 
@@ -87,6 +224,36 @@ func (store *Store) AutoMigratePrices(ctx context.Context) error {
 		}
 	}
 
+	// the ticks are read by time range, the tick tables are indexed on time
+	if store.tickTableNamePrefix != "" {
+		for _, instrument := range instruments {
+			if instrument.IsSynthetic() {
+				continue
+			}
+
+			if err := store.autoMigrateIndex(ctx, store.TickTableName(instrument.Symbol(), instrument.Exchange()), COLUMN_TIME); err != nil {
+				return err
+			}
+		}
+	}
+
+	// price fields enabled after the tables were created are added to them
+	for _, instrument := range instruments {
+		if len(instrument.PriceFields()) < 1 || instrument.IsSynthetic() {
+			continue
+		}
+
+		columns := store.priceTableColumns(store.priceColumnPrecision(instrument), instrument.PriceFields())
+
+		for _, timeframe := range instrument.Timeframes() {
+			tableName := store.PriceTableName(instrument.Symbol(), instrument.Exchange(), timeframe)
+
+			if err := store.autoMigrateColumns(ctx, tableName, columns); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -104,6 +271,271 @@ func (st *Store) EnableDebug(debug bool) {
 // == PRIVATE METHODS
 // ============================================================================
 
+// autoMigrateColumns adds the columns missing from an existing table
+func (store *Store) autoMigrateColumns(ctx context.Context, tableName string, columns []sb.Column) error {
+	for _, column := range columns {
+		exists, err := sb.TableColumnExists(store.toQuerableContext(ctx), tableName, column.Name)
+
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			continue
+		}
+
+		sql := store.sqlTableColumnAdd(tableName, column)
+
+		if sql == "" {
+			return errors.New("trading store: failed to build sql to add column " + column.Name + " to table " + tableName)
+		}
+
+		_, err = store.db.Exec(sql)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// autoMigrateIndex creates the index of the column of a table if it does
+// not exist yet. The index is named idx_<table>_<column>.
+func (store *Store) autoMigrateIndex(ctx context.Context, tableName string, columnName string) error {
+	indexName := "idx_" + tableName + "_" + columnName
+
+	// MySQL does not support CREATE INDEX IF NOT EXISTS
+	if sb.DatabaseDriverName(store.db) == sb.DIALECT_MYSQL {
+		exists, err := store.indexExists(ctx, tableName, indexName)
+
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return nil
+		}
+	}
+
+	sql := store.sqlTableIndexCreate(tableName, indexName, columnName)
+
+	if sql == "" {
+		return errors.New("trading store: failed to build sql to create index " + indexName)
+	}
+
+	store.logSql("migrate", sql)
+
+	_, err := store.db.Exec(sql)
+
+	return err
+}
+
+// indexExists returns true if the index exists on the table of a MySQL database
+func (store *Store) indexExists(ctx context.Context, tableName string, indexName string) (bool, error) {
+	sqlStr, params, err := goqu.Dialect(store.dbDriverName).
+		From(goqu.T("statistics").Schema("information_schema")).
+		Prepared(true).
+		Select(goqu.C("index_name")).
+		Where(
+			goqu.C("table_schema").Eq(goqu.L("DATABASE()")),
+			goqu.C("table_name").Eq(tableName),
+			goqu.C("index_name").Eq(indexName),
+		).
+		Limit(1).
+		ToSQL()
+
+	if err != nil {
+		return false, err
+	}
+
+	store.logSql("select", sqlStr, params...)
+
+	rows, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, params...)
+
+	if err != nil {
+		return false, err
+	}
+
+	return len(rows) > 0, nil
+}
+
+// autoMigrateColumnLengths widens the string columns of an existing table
+// which are shorter than expected. Columns are never narrowed. SQLite does
+// not enforce the length of string columns, so its tables are left as is.
+func (store *Store) autoMigrateColumnLengths(ctx context.Context, tableName string, columns []sb.Column) error {
+	driverName := sb.DatabaseDriverName(store.db)
+
+	if driverName != sb.DIALECT_MYSQL && driverName != sb.DIALECT_POSTGRES {
+		return nil
+	}
+
+	for _, column := range columns {
+		if column.Type != sb.COLUMN_TYPE_STRING || column.Length < 1 {
+			continue
+		}
+
+		length, err := store.columnLength(ctx, tableName, column.Name)
+
+		if err != nil {
+			return err
+		}
+
+		if length < 1 || length >= column.Length {
+			continue
+		}
+
+		sql := store.sqlTableColumnWiden(tableName, column)
+
+		if sql == "" {
+			return errors.New("trading store: failed to build sql to widen column " + column.Name + " of table " + tableName)
+		}
+
+		store.logSql("migrate", sql)
+
+		_, err = store.db.Exec(sql)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// columnLength returns the length of a string column of a MySQL or
+// PostgreSQL table, 0 if the column does not exist or has no length
+func (store *Store) columnLength(ctx context.Context, tableName string, columnName string) (int, error) {
+	currentSchema := goqu.L("current_schema()")
+
+	if sb.DatabaseDriverName(store.db) == sb.DIALECT_MYSQL {
+		currentSchema = goqu.L("DATABASE()")
+	}
+
+	sqlStr, params, err := goqu.Dialect(store.dbDriverName).
+		From(goqu.T("columns").Schema("information_schema")).
+		Prepared(true).
+		Select(goqu.C("character_maximum_length").As("length")).
+		Where(
+			goqu.C("table_schema").Eq(currentSchema),
+			goqu.C("table_name").Eq(tableName),
+			goqu.C("column_name").Eq(columnName),
+		).
+		Limit(1).
+		ToSQL()
+
+	if err != nil {
+		return 0, err
+	}
+
+	store.logSql("select", sqlStr, params...)
+
+	rows, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, params...)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rows) < 1 {
+		return 0, nil
+	}
+
+	return cast.ToInt(rows[0]["length"]), nil
+}
+
+// tableColumns returns the columns of a table, with their types commonized
+// as by sb.TableColumns, empty if the table does not exist. sb.TableColumns
+// supports only SQLite and MySQL, so the columns of a PostgreSQL table are
+// read from information_schema.
+func (store *Store) tableColumns(ctx context.Context, tableName string) ([]sb.Column, error) {
+	driverName := sb.DatabaseDriverName(store.db)
+
+	if driverName == sb.DIALECT_SQLITE || driverName == sb.DIALECT_MYSQL {
+		return sb.TableColumns(store.toQuerableContext(ctx), tableName, true)
+	}
+
+	if driverName != sb.DIALECT_POSTGRES {
+		return nil, errors.New("trading store: reading the columns of a table is not supported for database driver " + driverName)
+	}
+
+	sqlStr, params, err := goqu.Dialect(store.dbDriverName).
+		From(goqu.T("columns").Schema("information_schema")).
+		Prepared(true).
+		Select(
+			goqu.C("column_name").As("name"),
+			goqu.C("data_type").As("type"),
+			goqu.C("character_maximum_length").As("length"),
+			goqu.C("numeric_precision").As("precision"),
+			goqu.C("numeric_scale").As("decimals"),
+			goqu.C("is_nullable").As("nullable"),
+		).
+		Where(
+			goqu.C("table_schema").Eq(goqu.L("current_schema()")),
+			goqu.C("table_name").Eq(tableName),
+		).
+		Order(goqu.C("ordinal_position").Asc()).
+		ToSQL()
+
+	if err != nil {
+		return nil, err
+	}
+
+	store.logSql("select", sqlStr, params...)
+
+	rows, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, params...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []sb.Column{}
+
+	for _, row := range rows {
+		column := sb.Column{
+			Name:     row["name"],
+			Type:     postgresColumnType(row["type"]),
+			Length:   cast.ToInt(row["length"]),
+			Nullable: row["nullable"] == "YES",
+		}
+
+		if column.Type == sb.COLUMN_TYPE_DECIMAL {
+			column.Length = cast.ToInt(row["precision"])
+			column.Decimals = cast.ToInt(row["decimals"])
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// postgresColumnType returns the sb column type of a PostgreSQL data type,
+// commonized as by sb.TableColumns
+func postgresColumnType(dataType string) string {
+	dataType = strings.ToLower(dataType)
+
+	switch {
+	case dataType == "numeric" || dataType == "decimal":
+		return sb.COLUMN_TYPE_DECIMAL
+	case strings.Contains(dataType, "int"):
+		return sb.COLUMN_TYPE_INTEGER
+	case strings.Contains(dataType, "char"):
+		return sb.COLUMN_TYPE_STRING
+	case dataType == "text":
+		return sb.COLUMN_TYPE_TEXT
+	case dataType == "real" || dataType == "double precision":
+		return sb.COLUMN_TYPE_FLOAT
+	case strings.HasPrefix(dataType, "timestamp"):
+		return sb.COLUMN_TYPE_DATETIME
+	case dataType == "date":
+		return sb.COLUMN_TYPE_DATE
+	case dataType == "bytea":
+		return sb.COLUMN_TYPE_BLOB
+	}
+
+	return dataType
+}
+
 // logSql logs sql to the sql logger, if debug mode is enabled
 func (store *Store) logSql(sqlOperationType string, sql string, params ...interface{}) {
 	if !store.debugEnabled {
@@ -123,3 +555,28 @@ func (store *Store) toQuerableContext(ctx context.Context) database.QueryableCon
 
 	return database.Context(ctx, store.db)
 }
+
+// withTx runs fn within a transaction, which is committed if fn succeeds and
+// rolled back otherwise. If the context already carries a transaction, fn
+// runs within it and the caller remains responsible for committing it.
+func (store *Store) withTx(ctx context.Context, fn func(ctx database.QueryableContext) error) error {
+	queryableCtx := store.toQuerableContext(ctx)
+
+	if !queryableCtx.IsDB() {
+		return fn(queryableCtx)
+	}
+
+	tx, err := store.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() // no-op after commit
+
+	if err := fn(database.Context(ctx, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,563 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/dracory/database"
+	"github.com/dracory/sb"
+	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+)
+
+// ContinuousContractCount returns the number of continuous contracts based on the given query options
+func (store *Store) ContinuousContractCount(ctx context.Context, options ContinuousContractQueryInterface) (int64, error) {
+	if options == nil {
+		return -1, errors.New("continuous contract options is nil")
+	}
+
+	options.SetCountOnly(true)
+
+	q, _, err := store.continuousContractQuery(options)
+
+	if err != nil {
+		return -1, err
+	}
+
+	sqlStr, sqlParams, errSql := q.Prepared(true).
+		Limit(1).
+		Select(goqu.COUNT(goqu.Star()).As("count")).
+		ToSQL()
+
+	if errSql != nil {
+		return -1, nil
+	}
+
+	store.logSql("count", sqlStr, sqlParams...)
+
+	mapped, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return -1, err
+	}
+
+	if len(mapped) < 1 {
+		return -1, nil
+	}
+
+	countStr := mapped[0]["count"]
+
+	i, err := strconv.ParseInt(countStr, 10, 64)
+
+	if err != nil {
+		return -1, err
+	}
+
+	return i, nil
+}
+
+// ContinuousContractCreate creates a new continuous contract
+func (store *Store) ContinuousContractCreate(ctx context.Context, contract ContinuousContractInterface) error {
+	if err := continuousContractValidate(contract); err != nil {
+		return err
+	}
+
+	data := contract.Data()
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Insert(store.continuousContractTableName).
+		Prepared(true).
+		Rows(data).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("create", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return err
+	}
+
+	contract.MarkAsNotDirty()
+
+	return nil
+}
+
+// ContinuousContractDelete deletes a continuous contract
+func (store *Store) ContinuousContractDelete(ctx context.Context, contract ContinuousContractInterface) error {
+	if contract == nil {
+		return errors.New("continuous contract is nil")
+	}
+
+	return store.ContinuousContractDeleteByID(ctx, contract.ID())
+}
+
+// ContinuousContractDeleteByID deletes a continuous contract by its ID
+func (store *Store) ContinuousContractDeleteByID(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("continuous contract id is empty")
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Delete(store.continuousContractTableName).
+		Prepared(true).
+		Where(goqu.C(COLUMN_ID).Eq(id)).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("delete", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	return err
+}
+
+// ContinuousContractFindByID returns a continuous contract by its ID
+func (store *Store) ContinuousContractFindByID(ctx context.Context, id string) (ContinuousContractInterface, error) {
+	if id == "" {
+		return nil, errors.New("continuous contract id is empty")
+	}
+
+	list, err := store.ContinuousContractList(ctx, NewContinuousContractQuery().SetID(id).SetLimit(1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return nil, nil
+}
+
+// ContinuousContractList returns a list of continuous contracts based on the given query options
+func (store *Store) ContinuousContractList(ctx context.Context, options ContinuousContractQueryInterface) ([]ContinuousContractInterface, error) {
+	q, columns, err := store.continuousContractQuery(options)
+
+	if err != nil {
+		return []ContinuousContractInterface{}, err
+	}
+
+	q = q.Prepared(true).Select(columns...)
+
+	sqlStr, sqlParams, errSql := q.ToSQL()
+
+	if errSql != nil {
+		return []ContinuousContractInterface{}, errSql
+	}
+
+	store.logSql("list", sqlStr, sqlParams...)
+
+	modelMaps, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+	if err != nil {
+		return []ContinuousContractInterface{}, err
+	}
+
+	list := []ContinuousContractInterface{}
+
+	lo.ForEach(modelMaps, func(modelMap map[string]string, index int) {
+		model := NewContinuousContractFromExistingData(modelMap)
+		list = append(list, model)
+	})
+
+	return list, nil
+}
+
+// ContinuousContractPriceList returns the prices of a continuous contract,
+// stitched from the price tables of its contracts, with the rolls between them.
+//
+// Each contract is used from the roll into it, until the roll out of it.
+// With the date roll rule, the roll is at the start of the roll date in the
+// time zone of the contract exchange calendar. With the volume and open
+// interest roll rules, the roll is at the first bar at which the next contract
+// exceeds the current one, falling back to the roll date if set, and else
+// to the end of the current contract prices.
+//
+// The earlier contracts are back-adjusted by the adjustment method, so the
+// prices of the latest contract are unchanged. Only the time filters of the
+// query options are applied, the rolls are detected within that range.
+//
+// Parameters:
+// - ctx: the context
+// - contract: the continuous contract
+// - timeframe: the timeframe of the prices
+// - options: the query options, i.e. the time range
+//
+// Returns:
+// - []PriceInterface: the stitched prices, in ascending time order
+// - []ContinuousContractRoll: the rolls, in ascending time order
+// - error: if a contract or its prices could not be read
+func (store *Store) ContinuousContractPriceList(ctx context.Context, contract ContinuousContractInterface, timeframe string, options PriceQueryInterface) ([]PriceInterface, []ContinuousContractRoll, error) {
+	if err := continuousContractValidate(contract); err != nil {
+		return nil, nil, err
+	}
+
+	if options == nil {
+		options = PriceQuery()
+	}
+
+	legs, err := contract.Contracts()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	legPrices := make([][]PriceInterface, len(legs))
+	legLocations := make([]*time.Location, len(legs))
+	legPrecisions := make([]PriceColumnPrecision, len(legs))
+
+	for index, leg := range legs {
+		instrument, err := store.InstrumentFindByID(ctx, leg.InstrumentID)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if instrument == nil {
+			return nil, nil, errors.New("continuous contract: instrument not found: " + leg.InstrumentID)
+		}
+
+		query := PriceQuery().SetOrderBy(COLUMN_TIME).SetOrderDirection(sb.ASC)
+
+		if options.IsTimeGteSet() {
+			query.SetTimeGte(options.TimeGte())
+		}
+
+		if options.IsTimeLteSet() {
+			query.SetTimeLte(options.TimeLte())
+		}
+
+		legPrices[index], err = store.PriceList(ctx, instrument.Symbol(), instrument.Exchange(), timeframe, query)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		bucketOptions, err := store.priceBucketOptions(ctx, instrument)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		legLocations[index] = bucketOptions.location()
+		legPrecisions[index] = store.priceColumnPrecision(instrument)
+	}
+
+	// rollTimes[i] is the time of the roll from leg i to leg i+1
+	rollTimes := make([]time.Time, len(legs)-1)
+	activeFrom := time.Time{}
+
+	for index := range rollTimes {
+		rollTime, err := continuousContractRollTime(contract.RollRule(), legs[index], legLocations[index], legPrices[index], legPrices[index+1], activeFrom)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if rollTime.Before(activeFrom) {
+			rollTime = activeFrom
+		}
+
+		rollTimes[index] = rollTime
+		activeFrom = rollTime
+	}
+
+	rolls := make([]ContinuousContractRoll, len(rollTimes))
+	gaps := make([]Decimal, len(rollTimes))
+	ratios := make([]Decimal, len(rollTimes))
+
+	for index, rollTime := range rollTimes {
+		gaps[index], ratios[index] = continuousContractRollGap(legPrices[index], legPrices[index+1], rollTime)
+
+		rolls[index] = ContinuousContractRoll{
+			Time:             rollTime,
+			FromInstrumentID: legs[index].InstrumentID,
+			ToInstrumentID:   legs[index+1].InstrumentID,
+			Gap:              gaps[index].Float64(),
+			Ratio:            ratios[index].Float64(),
+		}
+	}
+
+	stitched := []PriceInterface{}
+
+	for index, prices := range legPrices {
+		difference, ratio := Decimal{}, NewDecimal(1, 0)
+
+		for rollIndex := index; rollIndex < len(rolls); rollIndex++ {
+			difference = difference.Add(gaps[rollIndex])
+			ratio = ratio.Mul(ratios[rollIndex])
+		}
+
+		for _, price := range prices {
+			priceTime := price.TimeCarbon().StdTime()
+
+			if index > 0 && priceTime.Before(rollTimes[index-1]) {
+				continue
+			}
+
+			if index < len(rollTimes) && !priceTime.Before(rollTimes[index]) {
+				continue
+			}
+
+			data := lo.Assign(price.Data())
+
+			for _, column := range []string{COLUMN_OPEN, COLUMN_HIGH, COLUMN_LOW, COLUMN_CLOSE} {
+				value, ok := data[column]
+
+				if !ok || value == "" {
+					continue
+				}
+
+				switch contract.AdjustmentMethod() {
+				case ROLL_ADJUSTMENT_DIFFERENCE:
+					data[column] = priceColumnFormat(column, priceDecimal(value).Add(difference), legPrecisions[index])
+				case ROLL_ADJUSTMENT_RATIO:
+					data[column] = priceColumnFormat(column, priceDecimal(value).Mul(ratio), legPrecisions[index])
+				}
+			}
+
+			stitched = append(stitched, NewPriceFromExistingData(data))
+		}
+	}
+
+	return stitched, rolls, nil
+}
+
+// ContinuousContractUpdate updates a continuous contract
+func (store *Store) ContinuousContractUpdate(ctx context.Context, contract ContinuousContractInterface) error {
+	if err := continuousContractValidate(contract); err != nil {
+		return err
+	}
+
+	contract.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+
+	dataChanged := contract.DataChanged()
+
+	delete(dataChanged, COLUMN_ID) // ID is not updateable
+
+	if len(dataChanged) < 1 {
+		return nil
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Update(store.continuousContractTableName).
+		Prepared(true).
+		Set(dataChanged).
+		Where(goqu.C(COLUMN_ID).Eq(contract.ID())).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("update", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	contract.MarkAsNotDirty()
+
+	return err
+}
+
+// continuousContractValidate returns an error if the continuous contract is incomplete
+func continuousContractValidate(contract ContinuousContractInterface) error {
+	if contract == nil {
+		return errors.New("continuous contract is nil")
+	}
+
+	if contract.Symbol() == "" {
+		return errors.New("continuous contract symbol is empty")
+	}
+
+	if !slices.Contains([]string{ROLL_RULE_DATE, ROLL_RULE_OPEN_INTEREST, ROLL_RULE_VOLUME}, contract.RollRule()) {
+		return errors.New("continuous contract roll rule is not supported: " + contract.RollRule())
+	}
+
+	if !slices.Contains([]string{ROLL_ADJUSTMENT_DIFFERENCE, ROLL_ADJUSTMENT_NONE, ROLL_ADJUSTMENT_RATIO}, contract.AdjustmentMethod()) {
+		return errors.New("continuous contract adjustment method is not supported: " + contract.AdjustmentMethod())
+	}
+
+	legs, err := contract.Contracts()
+
+	if err != nil {
+		return err
+	}
+
+	if len(legs) < 1 {
+		return errors.New("continuous contract has no contracts")
+	}
+
+	if contract.RollRule() == ROLL_RULE_DATE {
+		for _, leg := range legs[:len(legs)-1] {
+			if leg.RollDate == "" {
+				return errors.New("continuous contract roll date is required by the date roll rule, contract: " + leg.InstrumentID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// continuousContractRollTime returns the time of the roll from the current
+// to the next contract, which is active from the given time
+func continuousContractRollTime(rollRule string, leg ContinuousContractLeg, location *time.Location, current []PriceInterface, next []PriceInterface, activeFrom time.Time) (time.Time, error) {
+	if rollRule != ROLL_RULE_DATE {
+		currentByTime := map[int64]PriceInterface{}
+
+		for _, price := range current {
+			currentByTime[price.TimeCarbon().StdTime().UnixNano()] = price
+		}
+
+		for _, nextPrice := range next {
+			nextTime := nextPrice.TimeCarbon().StdTime()
+
+			if nextTime.Before(activeFrom) {
+				continue
+			}
+
+			currentPrice, ok := currentByTime[nextTime.UnixNano()]
+
+			if !ok {
+				continue
+			}
+
+			currentValue, nextValue := currentPrice.VolumeFloat(), nextPrice.VolumeFloat()
+
+			if rollRule == ROLL_RULE_OPEN_INTEREST {
+				if currentPrice.OpenInterest() == "" || nextPrice.OpenInterest() == "" {
+					return time.Time{}, errors.New("continuous contract: the open interest roll rule requires open interest in the prices")
+				}
+
+				currentValue, nextValue = currentPrice.OpenInterestFloat(), nextPrice.OpenInterestFloat()
+			}
+
+			if nextValue > currentValue {
+				return nextTime, nil
+			}
+		}
+	}
+
+	if leg.RollDate != "" {
+		return time.ParseInLocation(time.DateOnly, leg.RollDate, location)
+	}
+
+	if rollRule == ROLL_RULE_DATE {
+		return time.Time{}, errors.New("continuous contract roll date is required by the date roll rule, contract: " + leg.InstrumentID)
+	}
+
+	// roll when the current contract prices end
+	if len(current) < 1 {
+		return activeFrom, nil
+	}
+
+	return current[len(current)-1].TimeCarbon().StdTime().Add(time.Nanosecond), nil
+}
+
+// continuousContractRollGap returns the difference and ratio of the next
+// contract close to the current contract close, on the last current bar
+// before the roll. The ratio keeps PRICE_PRECISION_MAX decimals.
+func continuousContractRollGap(current []PriceInterface, next []PriceInterface, rollTime time.Time) (difference Decimal, ratio Decimal) {
+	var currentPrice PriceInterface
+
+	for _, price := range current {
+		if price.TimeCarbon().StdTime().Before(rollTime) {
+			currentPrice = price
+		}
+	}
+
+	if currentPrice == nil {
+		return Decimal{}, NewDecimal(1, 0)
+	}
+
+	referenceTime := currentPrice.TimeCarbon().StdTime()
+
+	var nextPrice PriceInterface
+
+	// the next contract close at the same time, or the closest to it
+	for _, price := range next {
+		if !price.TimeCarbon().StdTime().After(referenceTime) {
+			nextPrice = price
+		} else if nextPrice == nil {
+			nextPrice = price
+			break
+		}
+	}
+
+	if nextPrice == nil || currentPrice.CloseDecimal().IsZero() {
+		return Decimal{}, NewDecimal(1, 0)
+	}
+
+	difference = nextPrice.CloseDecimal().Sub(currentPrice.CloseDecimal())
+	ratio = nextPrice.CloseDecimal().Div(currentPrice.CloseDecimal(), PRICE_PRECISION_MAX)
+
+	return difference, ratio
+}
+
+// continuousContractQuery returns a query for continuous contracts based on the given query options
+func (store *Store) continuousContractQuery(options ContinuousContractQueryInterface) (selectDataset *goqu.SelectDataset, columns []any, err error) {
+	if options == nil {
+		return nil, nil, errors.New("continuous contract options is nil")
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	q := goqu.Dialect(store.dbDriverName).From(store.continuousContractTableName)
+
+	if options.IsExchangeSet() {
+		q = q.Where(goqu.C(COLUMN_EXCHANGE).Eq(options.Exchange()))
+	}
+
+	if options.IsIDSet() {
+		q = q.Where(goqu.C(COLUMN_ID).Eq(options.ID()))
+	}
+
+	if options.IsSymbolSet() {
+		q = q.Where(goqu.C(COLUMN_SYMBOL).Eq(options.Symbol()))
+	}
+
+	if !options.IsCountOnly() {
+		if options.IsLimitSet() {
+			q = q.Limit(cast.ToUint(options.Limit()))
+		}
+
+		if options.IsOffsetSet() {
+			q = q.Offset(cast.ToUint(options.Offset()))
+		}
+	}
+
+	if options.IsOrderBySet() {
+		sort := lo.Ternary(options.IsOrderDirectionSet(), options.OrderDirection(), sb.DESC)
+		if strings.EqualFold(sort, sb.ASC) {
+			q = q.Order(goqu.I(options.OrderBy()).Asc())
+		} else {
+			q = q.Order(goqu.I(options.OrderBy()).Desc())
+		}
+	} else {
+		// Default sorting by symbol if no specific ordering is requested
+		q = q.Order(goqu.I(COLUMN_SYMBOL).Asc())
+	}
+
+	columns = []any{}
+
+	for _, column := range options.Columns() {
+		columns = append(columns, column)
+	}
+
+	return q, columns, nil
+}
@@ -0,0 +1,275 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+)
+
+func initContinuousContractStore(t *testing.T) (StoreInterface, []ContinuousContractLeg) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	prices := map[string][]PriceInterface{
+		"ESH20": {
+			NewPrice().SetTime("2020-01-01 00:00:00").SetOpen("100").SetHigh("100").SetLow("100").SetClose("100").SetVolume("1000"),
+			NewPrice().SetTime("2020-01-02 00:00:00").SetOpen("101").SetHigh("101").SetLow("101").SetClose("101").SetVolume("900"),
+			NewPrice().SetTime("2020-01-03 00:00:00").SetOpen("102").SetHigh("102").SetLow("102").SetClose("102").SetVolume("500"),
+			NewPrice().SetTime("2020-01-04 00:00:00").SetOpen("103").SetHigh("103").SetLow("103").SetClose("103").SetVolume("100"),
+		},
+		"ESM20": {
+			NewPrice().SetTime("2020-01-02 00:00:00").SetOpen("105").SetHigh("105").SetLow("105").SetClose("105").SetVolume("100"),
+			NewPrice().SetTime("2020-01-03 00:00:00").SetOpen("106").SetHigh("106").SetLow("106").SetClose("106").SetVolume("600"),
+			NewPrice().SetTime("2020-01-04 00:00:00").SetOpen("107").SetHigh("107").SetLow("107").SetClose("107").SetVolume("1000"),
+		},
+	}
+
+	legs := []ContinuousContractLeg{}
+
+	for _, symbol := range []string{"ESH20", "ESM20"} {
+		instrument := NewInstrument().
+			SetSymbol(symbol).
+			SetExchange("CME").
+			SetAssetClass(ASSET_CLASS_FUTURE).
+			SetTimeframes([]string{TIMEFRAME_1_DAY})
+
+		if err := store.InstrumentCreate(ctx, instrument); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if err := store.AutoMigratePrices(ctx); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		for _, price := range prices[symbol] {
+			if err := store.PriceCreate(ctx, symbol, "CME", TIMEFRAME_1_DAY, price); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		}
+
+		legs = append(legs, ContinuousContractLeg{InstrumentID: instrument.ID()})
+	}
+
+	return store, legs
+}
+
+func TestStoreContinuousContractCreateAndFind(t *testing.T) {
+	store, legs := initContinuousContractStore(t)
+	ctx := context.Background()
+
+	contract := NewContinuousContract().
+		SetSymbol("ES1").
+		SetExchange("CME").
+		SetRollRule(ROLL_RULE_VOLUME).
+		SetAdjustmentMethod(ROLL_ADJUSTMENT_RATIO)
+
+	if err := contract.SetContracts(legs); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.ContinuousContractCreate(ctx, contract); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.ContinuousContractFindByID(ctx, contract.ID())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if found == nil {
+		t.Fatal("Continuous contract MUST NOT be nil")
+	}
+
+	foundLegs, err := found.Contracts()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(foundLegs) != 2 || foundLegs[1].InstrumentID != legs[1].InstrumentID {
+		t.Fatal("Continuous contract legs MUST BE restored, found:", foundLegs)
+	}
+
+	list, err := store.ContinuousContractList(ctx, ContinuousContractQuery().SetSymbol("ES1"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(list) != 1 {
+		t.Fatal("Continuous contracts count MUST BE 1, found:", len(list))
+	}
+
+	// the date roll rule requires roll dates
+	found.SetRollRule(ROLL_RULE_DATE)
+
+	if err := store.ContinuousContractUpdate(ctx, found); err == nil {
+		t.Fatal("Date roll rule without roll dates MUST return an error")
+	}
+
+	if err := store.ContinuousContractDeleteByID(ctx, contract.ID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestStoreContinuousContractPriceList(t *testing.T) {
+	store, legs := initContinuousContractStore(t)
+	ctx := context.Background()
+
+	testCases := []struct {
+		name       string
+		rollRule   string
+		rollDate   string
+		adjustment string
+		rollTime   string
+		closes     []float64
+	}{
+		{"volume, none", ROLL_RULE_VOLUME, "", ROLL_ADJUSTMENT_NONE, "2020-01-03 00:00:00", []float64{100, 101, 106, 107}},
+		{"volume, difference", ROLL_RULE_VOLUME, "", ROLL_ADJUSTMENT_DIFFERENCE, "2020-01-03 00:00:00", []float64{104, 105, 106, 107}},
+		{"volume, ratio", ROLL_RULE_VOLUME, "", ROLL_ADJUSTMENT_RATIO, "2020-01-03 00:00:00", []float64{100 * 105.0 / 101, 105, 106, 107}},
+		{"date, difference", ROLL_RULE_DATE, "2020-01-04", ROLL_ADJUSTMENT_DIFFERENCE, "2020-01-04 00:00:00", []float64{104, 105, 106, 107}},
+	}
+
+	for _, testCase := range testCases {
+		contract := NewContinuousContract().
+			SetSymbol("ES1").
+			SetRollRule(testCase.rollRule).
+			SetAdjustmentMethod(testCase.adjustment)
+
+		err := contract.SetContracts([]ContinuousContractLeg{
+			{InstrumentID: legs[0].InstrumentID, RollDate: testCase.rollDate},
+			legs[1],
+		})
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		prices, rolls, err := store.ContinuousContractPriceList(ctx, contract, TIMEFRAME_1_DAY, PriceQuery())
+
+		if err != nil {
+			t.Fatal(testCase.name, "unexpected error:", err)
+		}
+
+		if len(rolls) != 1 {
+			t.Fatal(testCase.name, "rolls count MUST BE 1, found:", len(rolls))
+		}
+
+		if rolls[0].Time.UTC().Format("2006-01-02 15:04:05") != testCase.rollTime {
+			t.Fatal(testCase.name, "roll time MUST BE", testCase.rollTime, ", found:", rolls[0].Time)
+		}
+
+		if len(prices) != len(testCase.closes) {
+			t.Fatal(testCase.name, "prices count MUST BE", len(testCase.closes), ", found:", len(prices))
+		}
+
+		for index, price := range prices {
+			if !price.CloseDecimal().Equal(NewDecimalFromFloat(testCase.closes[index]).Round(PRICE_PRECISION_DEFAULT)) {
+				t.Fatal(testCase.name, "close", index, "MUST BE", testCase.closes[index], ", found:", price.Close())
+			}
+		}
+	}
+
+	contract := NewContinuousContract().
+		SetSymbol("ES1").
+		SetRollRule(ROLL_RULE_OPEN_INTEREST)
+
+	if err := contract.SetContracts(legs); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, _, err := store.ContinuousContractPriceList(ctx, contract, TIMEFRAME_1_DAY, PriceQuery()); err == nil {
+		t.Fatal("Open interest roll rule without open interest MUST return an error")
+	}
+}
+
+func TestStoreContinuousContractPriceListOpenInterest(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	// the volume crosses on 2020-01-03, the open interest only on 2020-01-04
+	prices := map[string][]PriceInterface{
+		"ESH20": {
+			NewPrice().SetTime("2020-01-02 00:00:00").SetOpen("101").SetHigh("101").SetLow("101").SetClose("101").SetVolume("900").SetOpenInterest("5000"),
+			NewPrice().SetTime("2020-01-03 00:00:00").SetOpen("102").SetHigh("102").SetLow("102").SetClose("102").SetVolume("500").SetOpenInterest("4000"),
+			NewPrice().SetTime("2020-01-04 00:00:00").SetOpen("103").SetHigh("103").SetLow("103").SetClose("103").SetVolume("100").SetOpenInterest("3000"),
+		},
+		"ESM20": {
+			NewPrice().SetTime("2020-01-02 00:00:00").SetOpen("105").SetHigh("105").SetLow("105").SetClose("105").SetVolume("100").SetOpenInterest("1000"),
+			NewPrice().SetTime("2020-01-03 00:00:00").SetOpen("106").SetHigh("106").SetLow("106").SetClose("106").SetVolume("600").SetOpenInterest("3500"),
+			NewPrice().SetTime("2020-01-04 00:00:00").SetOpen("107").SetHigh("107").SetLow("107").SetClose("107").SetVolume("1000").SetOpenInterest("6000"),
+		},
+	}
+
+	legs := []ContinuousContractLeg{}
+
+	for _, symbol := range []string{"ESH20", "ESM20"} {
+		instrument := NewInstrument().
+			SetSymbol(symbol).
+			SetExchange("CME").
+			SetAssetClass(ASSET_CLASS_FUTURE).
+			SetTimeframes([]string{TIMEFRAME_1_DAY}).
+			SetPriceFields([]string{COLUMN_OPEN_INTEREST})
+
+		if err := store.InstrumentCreate(ctx, instrument); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if err := store.AutoMigratePrices(ctx); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		for _, price := range prices[symbol] {
+			if err := store.PriceCreate(ctx, symbol, "CME", TIMEFRAME_1_DAY, price); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		}
+
+		legs = append(legs, ContinuousContractLeg{InstrumentID: instrument.ID()})
+	}
+
+	contract := NewContinuousContract().
+		SetSymbol("ES1").
+		SetRollRule(ROLL_RULE_OPEN_INTEREST).
+		SetAdjustmentMethod(ROLL_ADJUSTMENT_NONE)
+
+	if err := contract.SetContracts(legs); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	continuous, rolls, err := store.ContinuousContractPriceList(ctx, contract, TIMEFRAME_1_DAY, PriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(rolls) != 1 {
+		t.Fatal("rolls count MUST BE 1, found:", len(rolls))
+	}
+
+	if rolls[0].Time.UTC().Format("2006-01-02 15:04:05") != "2020-01-04 00:00:00" {
+		t.Fatal("roll time MUST BE 2020-01-04 00:00:00, found:", rolls[0].Time)
+	}
+
+	closes := []float64{101, 102, 107}
+
+	if len(continuous) != len(closes) {
+		t.Fatal("prices count MUST BE", len(closes), ", found:", len(continuous))
+	}
+
+	for index, price := range continuous {
+		if price.CloseFloat() != closes[index] {
+			t.Fatal("close", index, "MUST BE", closes[index], ", found:", price.Close())
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/dracory/database"
+	"github.com/dracory/sb"
+	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+)
+
+// CorporateActionCount returns the number of corporate actions based on the given query options
+func (store *Store) CorporateActionCount(ctx context.Context, options CorporateActionQueryInterface) (int64, error) {
+	if options == nil {
+		return -1, errors.New("corporate action options is nil")
+	}
+
+	options.SetCountOnly(true)
+
+	q, _, err := store.corporateActionQuery(options)
+
+	if err != nil {
+		return -1, err
+	}
+
+	sqlStr, sqlParams, errSql := q.Prepared(true).
+		Limit(1).
+		Select(goqu.COUNT(goqu.Star()).As("count")).
+		ToSQL()
+
+	if errSql != nil {
+		return -1, nil
+	}
+
+	store.logSql("count", sqlStr, sqlParams...)
+
+	mapped, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return -1, err
+	}
+
+	if len(mapped) < 1 {
+		return -1, nil
+	}
+
+	countStr := mapped[0]["count"]
+
+	i, err := strconv.ParseInt(countStr, 10, 64)
+
+	if err != nil {
+		return -1, err
+	}
+
+	return i, nil
+}
+
+// CorporateActionCreate creates a new corporate action
+func (store *Store) CorporateActionCreate(ctx context.Context, action CorporateActionInterface) error {
+	if err := corporateActionValidate(action); err != nil {
+		return err
+	}
+
+	data := action.Data()
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Insert(store.corporateActionTableName).
+		Prepared(true).
+		Rows(data).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("create", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return err
+	}
+
+	action.MarkAsNotDirty()
+
+	return nil
+}
+
+// CorporateActionDelete deletes a corporate action
+func (store *Store) CorporateActionDelete(ctx context.Context, action CorporateActionInterface) error {
+	if action == nil {
+		return errors.New("corporate action is nil")
+	}
+
+	return store.CorporateActionDeleteByID(ctx, action.ID())
+}
+
+// CorporateActionDeleteByID deletes a corporate action by its ID
+func (store *Store) CorporateActionDeleteByID(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("corporate action id is empty")
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Delete(store.corporateActionTableName).
+		Prepared(true).
+		Where(goqu.C(COLUMN_ID).Eq(id)).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("delete", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	return err
+}
+
+// CorporateActionFindByID returns a corporate action by its ID
+func (store *Store) CorporateActionFindByID(ctx context.Context, id string) (CorporateActionInterface, error) {
+	if id == "" {
+		return nil, errors.New("corporate action id is empty")
+	}
+
+	list, err := store.CorporateActionList(ctx, NewCorporateActionQuery().SetID(id).SetLimit(1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return nil, nil
+}
+
+// CorporateActionList returns a list of corporate actions based on the given query options
+func (store *Store) CorporateActionList(ctx context.Context, options CorporateActionQueryInterface) ([]CorporateActionInterface, error) {
+	q, columns, err := store.corporateActionQuery(options)
+
+	if err != nil {
+		return []CorporateActionInterface{}, err
+	}
+
+	q = q.Prepared(true).Select(columns...)
+
+	sqlStr, sqlParams, errSql := q.ToSQL()
+
+	if errSql != nil {
+		return []CorporateActionInterface{}, errSql
+	}
+
+	store.logSql("list", sqlStr, sqlParams...)
+
+	modelMaps, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+	if err != nil {
+		return []CorporateActionInterface{}, err
+	}
+
+	list := []CorporateActionInterface{}
+
+	lo.ForEach(modelMaps, func(modelMap map[string]string, index int) {
+		model := NewCorporateActionFromExistingData(modelMap)
+		list = append(list, model)
+	})
+
+	return list, nil
+}
+
+// CorporateActionUpdate updates a corporate action
+func (store *Store) CorporateActionUpdate(ctx context.Context, action CorporateActionInterface) error {
+	if err := corporateActionValidate(action); err != nil {
+		return err
+	}
+
+	action.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+
+	dataChanged := action.DataChanged()
+
+	delete(dataChanged, COLUMN_ID) // ID is not updateable
+
+	if len(dataChanged) < 1 {
+		return nil
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Update(store.corporateActionTableName).
+		Prepared(true).
+		Set(dataChanged).
+		Where(goqu.C(COLUMN_ID).Eq(action.ID())).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("update", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	action.MarkAsNotDirty()
+
+	return err
+}
+
+// corporateActionValidate returns an error if the corporate action is incomplete
+func corporateActionValidate(action CorporateActionInterface) error {
+	if action == nil {
+		return errors.New("corporate action is nil")
+	}
+
+	if action.InstrumentID() == "" {
+		return errors.New("corporate action instrument id is empty")
+	}
+
+	if _, err := time.Parse(time.DateOnly, action.ExDate()); err != nil {
+		return errors.New("corporate action ex date must be formatted as YYYY-MM-DD: " + action.ExDate())
+	}
+
+	switch action.ActionType() {
+	case CORPORATE_ACTION_TYPE_SPLIT, CORPORATE_ACTION_TYPE_STOCK_DIVIDEND:
+		if action.RatioFloat() <= 0 {
+			return errors.New("corporate action ratio must be greater than zero")
+		}
+	case CORPORATE_ACTION_TYPE_CASH_DIVIDEND:
+		if action.AmountFloat() <= 0 {
+			return errors.New("corporate action amount must be greater than zero")
+		}
+	case CORPORATE_ACTION_TYPE_SYMBOL_CHANGE:
+		if action.NewSymbol() == "" {
+			return errors.New("corporate action new symbol is empty")
+		}
+	default:
+		return errors.New("corporate action type is not supported: " + action.ActionType())
+	}
+
+	return nil
+}
+
+// corporateActionQuery returns a query for corporate actions based on the given query options
+func (store *Store) corporateActionQuery(options CorporateActionQueryInterface) (selectDataset *goqu.SelectDataset, columns []any, err error) {
+	if options == nil {
+		return nil, nil, errors.New("corporate action options is nil")
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	q := goqu.Dialect(store.dbDriverName).From(store.corporateActionTableName)
+
+	if options.IsActionTypeSet() {
+		q = q.Where(goqu.C(COLUMN_ACTION_TYPE).Eq(options.ActionType()))
+	}
+
+	if options.IsActionTypeInSet() {
+		q = q.Where(goqu.C(COLUMN_ACTION_TYPE).In(options.ActionTypeIn()))
+	}
+
+	if options.IsExDateGteSet() {
+		q = q.Where(goqu.C(COLUMN_EX_DATE).Gte(options.ExDateGte()))
+	}
+
+	if options.IsExDateLteSet() {
+		q = q.Where(goqu.C(COLUMN_EX_DATE).Lte(options.ExDateLte()))
+	}
+
+	if options.IsIDSet() {
+		q = q.Where(goqu.C(COLUMN_ID).Eq(options.ID()))
+	}
+
+	if options.IsInstrumentIDSet() {
+		q = q.Where(goqu.C(COLUMN_INSTRUMENT_ID).Eq(options.InstrumentID()))
+	}
+
+	if !options.IsCountOnly() {
+		if options.IsLimitSet() {
+			q = q.Limit(cast.ToUint(options.Limit()))
+		}
+
+		if options.IsOffsetSet() {
+			q = q.Offset(cast.ToUint(options.Offset()))
+		}
+	}
+
+	if options.IsOrderBySet() {
+		sort := lo.Ternary(options.IsOrderDirectionSet(), options.OrderDirection(), sb.DESC)
+		if strings.EqualFold(sort, sb.ASC) {
+			q = q.Order(goqu.I(options.OrderBy()).Asc())
+		} else {
+			q = q.Order(goqu.I(options.OrderBy()).Desc())
+		}
+	} else {
+		// Default sorting by ex date if no specific ordering is requested
+		q = q.Order(goqu.I(COLUMN_EX_DATE).Asc())
+	}
+
+	columns = []any{}
+
+	for _, column := range options.Columns() {
+		columns = append(columns, column)
+	}
+
+	return q, columns, nil
+}
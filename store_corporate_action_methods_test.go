@@ -0,0 +1,270 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+)
+
+func findTestInstrument(t *testing.T, store StoreInterface, symbol string) InstrumentInterface {
+	instruments, err := store.InstrumentList(context.Background(), InstrumentQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, instrument := range instruments {
+		if instrument.Symbol() == symbol {
+			return instrument
+		}
+	}
+
+	t.Fatal("Instrument not found:", symbol)
+	return nil
+}
+
+func TestStoreCorporateActionCreateAndFind(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+	instrument := findTestInstrument(t, store, "AAPL")
+
+	action := NewCorporateAction().
+		SetInstrumentID(instrument.ID()).
+		SetActionType(CORPORATE_ACTION_TYPE_SPLIT).
+		SetExDate("2020-08-31").
+		SetRatio("4")
+
+	if err := store.CorporateActionCreate(ctx, action); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.CorporateActionFindByID(ctx, action.ID())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if found == nil {
+		t.Fatal("Corporate action MUST NOT be nil")
+	}
+
+	if found.SplitFactor() != 4 {
+		t.Fatal("Split factor MUST BE 4, found:", found.SplitFactor())
+	}
+
+	found.SetMemo("4-for-1")
+
+	if err := store.CorporateActionUpdate(ctx, found); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	count, err := store.CorporateActionCount(ctx, CorporateActionQuery().SetInstrumentID(instrument.ID()))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count != 1 {
+		t.Fatal("Corporate actions count MUST BE 1, found:", count)
+	}
+
+	if err := store.CorporateActionDelete(ctx, found); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err = store.CorporateActionFindByID(ctx, action.ID())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if found != nil {
+		t.Fatal("Corporate action MUST BE nil after delete")
+	}
+
+	invalid := NewCorporateAction().
+		SetInstrumentID(instrument.ID()).
+		SetActionType(CORPORATE_ACTION_TYPE_CASH_DIVIDEND).
+		SetExDate("2020-08-31")
+
+	if err := store.CorporateActionCreate(ctx, invalid); err == nil {
+		t.Fatal("Cash dividend without amount MUST return an error")
+	}
+}
+
+func TestCorporateActionSplitFactor(t *testing.T) {
+	testCases := []struct {
+		actionType string
+		ratio      string
+		expected   string
+	}{
+		{CORPORATE_ACTION_TYPE_SPLIT, "2", "2"},
+		{CORPORATE_ACTION_TYPE_SPLIT, "1.00000000000000000001", "1.00000000000000000001"},
+		{CORPORATE_ACTION_TYPE_STOCK_DIVIDEND, "0.05", "1.05"},
+		{CORPORATE_ACTION_TYPE_STOCK_DIVIDEND, "0.00000000000000000001", "1.00000000000000000001"},
+		{CORPORATE_ACTION_TYPE_SPLIT, "0", "1"},
+		{CORPORATE_ACTION_TYPE_SPLIT, "", "1"},
+		{CORPORATE_ACTION_TYPE_CASH_DIVIDEND, "2", "1"},
+	}
+
+	for _, testCase := range testCases {
+		action := NewCorporateAction().SetActionType(testCase.actionType).SetRatio(testCase.ratio)
+		factor := corporateActionSplitFactor(action)
+
+		if !factor.Equal(priceDecimal(testCase.expected)) {
+			t.Fatal("Split factor of", testCase.actionType, testCase.ratio, "MUST BE", testCase.expected, ", found:", factor.String())
+		}
+	}
+}
+
+func TestStorePriceListAdjusted(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+	instrument := findTestInstrument(t, store, "AAPL")
+
+	// daily bars at the New York open, a 2-for-1 split before the third bar
+	for _, price := range []PriceInterface{
+		NewPrice().SetTime("2020-01-01 14:30:00").SetOpen("100").SetHigh("100").SetLow("100").SetClose("100").SetVolume("1000"),
+		NewPrice().SetTime("2020-01-02 14:30:00").SetOpen("100").SetHigh("100").SetLow("100").SetClose("100").SetVolume("1000"),
+		NewPrice().SetTime("2020-01-03 14:30:00").SetOpen("50").SetHigh("50").SetLow("50").SetClose("50").SetVolume("2000"),
+		NewPrice().SetTime("2020-01-06 14:30:00").SetOpen("49").SetHigh("49").SetLow("49").SetClose("49").SetVolume("2000"),
+	} {
+		if err := store.PriceCreate(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_DAY, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	for _, action := range []CorporateActionInterface{
+		NewCorporateAction().SetInstrumentID(instrument.ID()).SetActionType(CORPORATE_ACTION_TYPE_SPLIT).SetExDate("2020-01-03").SetRatio("2"),
+		NewCorporateAction().SetInstrumentID(instrument.ID()).SetActionType(CORPORATE_ACTION_TYPE_CASH_DIVIDEND).SetExDate("2020-01-06").SetAmount("1"),
+	} {
+		if err := store.CorporateActionCreate(ctx, action); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	testCases := []struct {
+		adjustment int
+		closes     []string
+		volumes    []string
+	}{
+		{ADJUST_NONE, []string{"100", "100", "50", "49"}, []string{"1000", "1000", "2000", "2000"}},
+		{ADJUST_SPLITS, []string{"50", "50", "50", "49"}, []string{"2000", "2000", "2000", "2000"}},
+		{ADJUST_DIVIDENDS, []string{"98", "98", "49", "49"}, []string{"1000", "1000", "2000", "2000"}},
+		{ADJUST_SPLITS | ADJUST_DIVIDENDS, []string{"49", "49", "49", "49"}, []string{"2000", "2000", "2000", "2000"}},
+	}
+
+	for _, testCase := range testCases {
+		prices, err := store.PriceList(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_DAY, PriceQuery().
+			SetAdjustment(testCase.adjustment))
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if len(prices) != 4 {
+			t.Fatal("Prices count MUST BE 4, found:", len(prices))
+		}
+
+		for index, price := range prices {
+			if !price.CloseDecimal().Equal(priceDecimal(testCase.closes[index])) {
+				t.Fatal("Adjustment", testCase.adjustment, "close", index, "MUST BE", testCase.closes[index], ", found:", price.Close())
+			}
+
+			if !price.VolumeDecimal().Equal(priceDecimal(testCase.volumes[index])) {
+				t.Fatal("Adjustment", testCase.adjustment, "volume", index, "MUST BE", testCase.volumes[index], ", found:", price.Volume())
+			}
+		}
+	}
+
+	// the dividend factor uses the close before the ex-date, even outside the range
+	prices, err := store.PriceList(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_DAY, PriceQuery().
+		SetTimeLte("2020-01-02 23:59:59").
+		SetAdjustment(ADJUST_DIVIDENDS))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 || prices[0].CloseFloat() != 98 {
+		t.Fatal("Dividend adjusted close MUST BE 98")
+	}
+
+	// the stored prices are not modified
+	raw, err := store.PriceList(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_DAY, PriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if raw[0].CloseFloat() != 100 {
+		t.Fatal("Stored close MUST BE 100, found:", raw[0].Close())
+	}
+}
+
+func TestStorePriceListAdjustedWithPricePrecision(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instrument := NewInstrument().
+		SetSymbol("XYZ").
+		SetExchange("NASDAQ").
+		SetAssetClass(ASSET_CLASS_STOCK).
+		SetPricePrecision(2).
+		SetTimeframes([]string{TIMEFRAME_1_DAY})
+
+	if err := store.InstrumentCreate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, price := range []PriceInterface{
+		NewPrice().SetTime("2020-01-02 14:30:00").SetOpen("100").SetHigh("100").SetLow("100").SetClose("100").SetVolume("1000"),
+		NewPrice().SetTime("2020-01-03 14:30:00").SetOpen("34").SetHigh("34").SetLow("34").SetClose("34").SetVolume("3000"),
+	} {
+		if err := store.PriceCreate(ctx, "XYZ", "NASDAQ", TIMEFRAME_1_DAY, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	split := NewCorporateAction().SetInstrumentID(instrument.ID()).SetActionType(CORPORATE_ACTION_TYPE_SPLIT).SetExDate("2020-01-03").SetRatio("3")
+
+	if err := store.CorporateActionCreate(ctx, split); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	prices, err := store.PriceList(ctx, "XYZ", "NASDAQ", TIMEFRAME_1_DAY, PriceQuery().SetAdjustment(ADJUST_SPLITS))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 {
+		t.Fatal("Prices count MUST BE 2, found:", len(prices))
+	}
+
+	if prices[0].Close() != "33.33" {
+		t.Fatal("Adjusted close MUST BE rounded to the price precision, expected 33.33, found:", prices[0].Close())
+	}
+
+	if prices[0].Volume() != "3000" {
+		t.Fatal("Adjusted volume MUST BE 3000, found:", prices[0].Volume())
+	}
+}
@@ -0,0 +1,168 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+)
+
+// PriceListInCurrency returns the prices matching the query options converted
+// into the given currency.
+//
+// The prices are quoted in the quote currency of the instrument. They are
+// converted with the stored prices of the ASSET_CLASS_FOREX instruments,
+// whose base asset and quote currency define the pair, i.e. EUR and USD for
+// EURUSD. A pair quoted either way is used, and when no pair converts
+// directly the prices are converted through USD.
+//
+// The FX prices of the same timeframe are used, or the daily prices if the
+// pair has no such timeframe. Each price is converted with the last FX close
+// at or before its time, prices before the first FX close are dropped. The
+// close of a daily FX price is only used from the end of its day, so the
+// prices of a day are converted with the close of the previous day.
+//
+// Parameters:
+// - ctx: the context
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - timeframe: the timeframe of the prices
+// - currency: the currency to convert into, i.e. "EUR"
+// - options: the query options of the prices
+//
+// Returns:
+// - []PriceInterface: the converted prices
+// - error: if the instrument has no quote currency or no FX pair converts it
+func (store *Store) PriceListInCurrency(ctx context.Context, symbol string, exchange string, timeframe string, currency string, options PriceQueryInterface) ([]PriceInterface, error) {
+	if currency == "" {
+		return nil, errors.New("currency conversion: currency is empty")
+	}
+
+	instrument, err := store.instrumentFindBySymbol(ctx, symbol, exchange)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if instrument == nil {
+		return nil, errors.New("currency conversion: instrument not found: " + symbol)
+	}
+
+	if instrument.QuoteCurrency() == "" {
+		return nil, errors.New("currency conversion: instrument " + symbol + " has no quote currency")
+	}
+
+	prices, err := store.PriceList(ctx, symbol, exchange, timeframe, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(instrument.QuoteCurrency(), currency) || len(prices) < 1 {
+		return prices, nil
+	}
+
+	pairs, err := store.InstrumentList(ctx, InstrumentQuery().SetAssetClass(ASSET_CLASS_FOREX))
+
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := currencyConversionPath(pairs, instrument.QuoteCurrency(), currency)
+
+	if err != nil {
+		return nil, err
+	}
+
+	decimals := int32(store.priceColumnPrecision(instrument).Price)
+
+	for index, leg := range path {
+		rates, err := store.currencyConversionRates(ctx, leg.pair, timeframe, prices)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// the prices converted into the pivot currency keep every decimal
+		legDecimals := lo.Ternary(index == len(path)-1, decimals, int32(PRICE_PRECISION_MAX))
+
+		prices, err = PriceConvert(prices, rates, leg.inverse, legDecimals)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(prices) < 1 {
+			break
+		}
+	}
+
+	return prices, nil
+}
+
+// currencyConversionRates returns the prices of the FX pair covering the
+// prices, including the last FX price before the first of them.
+//
+// The daily prices used for other timeframes are stamped with the end of
+// their day, when their close is known, so the prices of a day are converted
+// with the close of the previous day rather than of a close yet to come.
+func (store *Store) currencyConversionRates(ctx context.Context, pair InstrumentInterface, timeframe string, prices []PriceInterface) ([]PriceInterface, error) {
+	rateTimeframe := timeframe
+	closeDelay := time.Duration(0)
+
+	if !lo.Contains(pair.Timeframes(), timeframe) {
+		if !lo.Contains(pair.Timeframes(), TIMEFRAME_1_DAY) {
+			return nil, errors.New("currency conversion: FX pair " + pair.Symbol() + " has no " + timeframe + " or " + TIMEFRAME_1_DAY + " prices")
+		}
+
+		rateTimeframe = TIMEFRAME_1_DAY
+
+		duration, err := TimeframeDuration(TIMEFRAME_1_DAY)
+
+		if err != nil {
+			return nil, err
+		}
+
+		closeDelay = duration
+	}
+
+	earliest := lo.MinBy(prices, func(a PriceInterface, b PriceInterface) bool {
+		return a.TimeCarbon().Lt(b.TimeCarbon())
+	}).TimeCarbon().StdTime()
+
+	latest := lo.MaxBy(prices, func(a PriceInterface, b PriceInterface) bool {
+		return a.TimeCarbon().Gt(b.TimeCarbon())
+	}).TimeCarbon().StdTime()
+
+	previous, err := store.PriceList(ctx, pair.Symbol(), pair.Exchange(), rateTimeframe, PriceQuery().
+		SetTimeLte(carbon.CreateFromStdTime(earliest.Add(-closeDelay), carbon.UTC).ToDateTimeString(carbon.UTC)).
+		SetOrderBy(COLUMN_TIME).
+		SetOrderDirection("desc").
+		SetLimit(1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := store.PriceList(ctx, pair.Symbol(), pair.Exchange(), rateTimeframe, PriceQuery().
+		SetTimeGte(carbon.CreateFromStdTime(earliest.Add(-closeDelay), carbon.UTC).ToDateTimeString(carbon.UTC)).
+		SetTimeLte(carbon.CreateFromStdTime(latest.Add(-closeDelay), carbon.UTC).ToDateTimeString(carbon.UTC)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	rates = append(previous, rates...)
+
+	if closeDelay == 0 {
+		return rates, nil
+	}
+
+	return lo.Map(rates, func(rate PriceInterface, _ int) PriceInterface {
+		return NewPriceFromExistingData(lo.Assign(rate.Data())).
+			SetTimeT(rate.TimeCarbon().StdTime().Add(closeDelay))
+	}), nil
+}
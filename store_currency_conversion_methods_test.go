@@ -0,0 +1,97 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePriceListInCurrency(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		DB:                   initDB(":memory:"),
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instruments := []InstrumentInterface{
+		NewInstrument().SetSymbol("TM").SetExchange("TSE").SetQuoteCurrency("JPY").SetTimeframes([]string{TIMEFRAME_1_HOUR}),
+		NewInstrument().SetSymbol("USDJPY").SetExchange("FOREX").SetAssetClass(ASSET_CLASS_FOREX).SetBaseAsset("USD").SetQuoteCurrency("JPY").SetTimeframes([]string{TIMEFRAME_1_DAY}),
+		NewInstrument().SetSymbol("EURUSD").SetExchange("FOREX").SetAssetClass(ASSET_CLASS_FOREX).SetBaseAsset("EUR").SetQuoteCurrency("USD").SetTimeframes([]string{TIMEFRAME_1_DAY}),
+	}
+
+	for _, instrument := range instruments {
+		if err := store.InstrumentCreate(ctx, instrument); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	closes := []struct {
+		symbol    string
+		exchange  string
+		timeframe string
+		time      string
+		close     string
+	}{
+		{"USDJPY", "FOREX", TIMEFRAME_1_DAY, "2024-01-01 00:00:00", "150"},
+		{"USDJPY", "FOREX", TIMEFRAME_1_DAY, "2024-01-02 00:00:00", "160"},
+		{"USDJPY", "FOREX", TIMEFRAME_1_DAY, "2024-01-03 00:00:00", "200"},
+		{"EURUSD", "FOREX", TIMEFRAME_1_DAY, "2024-01-01 00:00:00", "1.25"},
+		{"EURUSD", "FOREX", TIMEFRAME_1_DAY, "2024-01-03 00:00:00", "2"},
+		{"TM", "TSE", TIMEFRAME_1_HOUR, "2024-01-02 09:00:00", "1500"},
+		{"TM", "TSE", TIMEFRAME_1_HOUR, "2024-01-03 09:00:00", "1600"},
+	}
+
+	for _, c := range closes {
+		price := NewPrice().SetTime(c.time).SetOpen(c.close).SetHigh(c.close).SetLow(c.close).SetClose(c.close).SetVolume("10")
+
+		if err := store.PriceCreate(ctx, c.symbol, c.exchange, c.timeframe, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	// JPY to USD with the inverse USDJPY pair, then USD to EUR with the inverse EURUSD pair
+	prices, err := store.PriceListInCurrency(ctx, "TM", "TSE", TIMEFRAME_1_HOUR, "EUR", NewPriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 {
+		t.Fatal("Expected 2 converted prices, got", len(prices))
+	}
+
+	// the intraday prices of a day are converted with the daily closes of
+	// the previous day, the closes of their own day are not known yet
+	if prices[0].CloseFloat() != 8 || prices[1].CloseFloat() != 8 {
+		t.Fatal("Intraday prices MUST be converted with the previous daily FX closes, got:", prices[0].Close(), prices[1].Close())
+	}
+
+	if prices[0].VolumeFloat() != 10 {
+		t.Fatal("The volume MUST NOT be converted, got", prices[0].Volume())
+	}
+
+	same, err := store.PriceListInCurrency(ctx, "TM", "TSE", TIMEFRAME_1_HOUR, "JPY", NewPriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(same) != 2 || same[0].CloseFloat() != 1500 {
+		t.Fatal("Prices in their own currency MUST be unchanged")
+	}
+
+	if _, err := store.PriceListInCurrency(ctx, "TM", "TSE", TIMEFRAME_1_HOUR, "GBP", NewPriceQuery()); err == nil {
+		t.Fatal("A conversion without FX pairs MUST fail")
+	}
+}
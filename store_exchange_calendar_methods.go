@@ -0,0 +1,422 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/dracory/database"
+	"github.com/dracory/sb"
+	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+)
+
+// ExchangeCalendarCount returns the number of exchange calendars based on the given query options
+func (store *Store) ExchangeCalendarCount(ctx context.Context, options ExchangeCalendarQueryInterface) (int64, error) {
+	if options == nil {
+		return -1, errors.New("exchange calendar options is nil")
+	}
+
+	options.SetCountOnly(true)
+
+	q, _, err := store.exchangeCalendarQuery(options)
+
+	if err != nil {
+		return -1, err
+	}
+
+	sqlStr, sqlParams, errSql := q.Prepared(true).
+		Limit(1).
+		Select(goqu.COUNT(goqu.Star()).As("count")).
+		ToSQL()
+
+	if errSql != nil {
+		return -1, nil
+	}
+
+	store.logSql("count", sqlStr, sqlParams...)
+
+	mapped, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return -1, err
+	}
+
+	if len(mapped) < 1 {
+		return -1, nil
+	}
+
+	countStr := mapped[0]["count"]
+
+	i, err := strconv.ParseInt(countStr, 10, 64)
+
+	if err != nil {
+		return -1, err
+	}
+
+	return i, nil
+}
+
+// ExchangeCalendarCreate creates a new exchange calendar,
+// its exchange is stored in upper case
+func (store *Store) ExchangeCalendarCreate(ctx context.Context, calendar ExchangeCalendarInterface) error {
+	if calendar == nil {
+		return errors.New("exchange calendar is nil")
+	}
+
+	if calendar.Exchange() == "" {
+		return errors.New("exchange calendar exchange is empty")
+	}
+
+	if _, err := calendar.Location(); err != nil {
+		return err
+	}
+
+	// exchanges are stored in upper case, as the built-in calendars
+	if exchange := strings.ToUpper(calendar.Exchange()); exchange != calendar.Exchange() {
+		calendar.SetExchange(exchange)
+	}
+
+	data := calendar.Data()
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Insert(store.exchangeCalendarTableName).
+		Prepared(true).
+		Rows(data).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("create", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return err
+	}
+
+	calendar.MarkAsNotDirty()
+
+	return nil
+}
+
+// ExchangeCalendarDelete deletes an exchange calendar
+func (store *Store) ExchangeCalendarDelete(ctx context.Context, calendar ExchangeCalendarInterface) error {
+	if calendar == nil {
+		return errors.New("exchange calendar is nil")
+	}
+
+	return store.ExchangeCalendarDeleteByID(ctx, calendar.ID())
+}
+
+// ExchangeCalendarDeleteByID deletes an exchange calendar by its ID
+func (store *Store) ExchangeCalendarDeleteByID(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("exchange calendar id is empty")
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Delete(store.exchangeCalendarTableName).
+		Prepared(true).
+		Where(goqu.C(COLUMN_ID).Eq(id)).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("delete", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	return err
+}
+
+// ExchangeCalendarFindByExchange returns the stored calendar of an exchange,
+// matched case insensitively
+func (store *Store) ExchangeCalendarFindByExchange(ctx context.Context, exchange string) (ExchangeCalendarInterface, error) {
+	if exchange == "" {
+		return nil, errors.New("exchange calendar exchange is empty")
+	}
+
+	list, err := store.ExchangeCalendarList(ctx, NewExchangeCalendarQuery().SetExchange(exchange).SetLimit(1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return nil, nil
+}
+
+// ExchangeCalendarFindByID returns an exchange calendar by its ID
+func (store *Store) ExchangeCalendarFindByID(ctx context.Context, id string) (ExchangeCalendarInterface, error) {
+	if id == "" {
+		return nil, errors.New("exchange calendar id is empty")
+	}
+
+	list, err := store.ExchangeCalendarList(ctx, NewExchangeCalendarQuery().SetID(id).SetLimit(1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return nil, nil
+}
+
+// ExchangeCalendarFindByInstrument returns the calendar the instrument trades on.
+//
+// The calendar is looked up by the instrument exchange, first in the store and
+// then in the built-in calendars. Crypto and forex instruments without a
+// matching exchange calendar fall back to the 24/7 crypto and 24/5 forex
+// calendars. Returns nil if no calendar applies.
+//
+// Stores whose exchange calendar table was not migrated, i.e. with auto
+// migration disabled, use the built-in calendars only. Whether the table
+// exists is checked on the first lookup only, a table created later is used
+// once it is migrated with AutoMigrateExchangeCalendars.
+func (store *Store) ExchangeCalendarFindByInstrument(ctx context.Context, instrument InstrumentInterface) (ExchangeCalendarInterface, error) {
+	if instrument == nil {
+		return nil, errors.New("instrument is nil")
+	}
+
+	tableExists, err := store.exchangeCalendarTableExists(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	exchanges := []string{}
+
+	if instrument.Exchange() != "" {
+		exchanges = append(exchanges, instrument.Exchange())
+	}
+
+	switch instrument.AssetClass() {
+	case ASSET_CLASS_CRYPTO:
+		exchanges = append(exchanges, EXCHANGE_CRYPTO)
+	case ASSET_CLASS_FOREX, ASSET_CLASS_CURRENCY:
+		exchanges = append(exchanges, EXCHANGE_FOREX)
+	}
+
+	for _, exchange := range exchanges {
+		if tableExists {
+			calendar, err := store.ExchangeCalendarFindByExchange(ctx, exchange)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if calendar != nil {
+				return calendar, nil
+			}
+		}
+
+		if calendar := BuiltinExchangeCalendar(exchange); calendar != nil {
+			return calendar, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// exchangeCalendarTableExists returns true if the exchange calendar table
+// exists. The check runs once per store, as it is on the path of every price
+// write and read, and is updated when the table is auto migrated.
+func (store *Store) exchangeCalendarTableExists(ctx context.Context) (bool, error) {
+	if store.exchangeCalendarTableChecked.Load() {
+		return store.exchangeCalendarTableFound.Load(), nil
+	}
+
+	exists, err := sb.TableColumnExists(store.toQuerableContext(ctx), store.exchangeCalendarTableName, COLUMN_EXCHANGE)
+
+	if err != nil {
+		return false, err
+	}
+
+	store.exchangeCalendarTableFound.Store(exists)
+	store.exchangeCalendarTableChecked.Store(true)
+
+	return exists, nil
+}
+
+// ExchangeCalendarList returns a list of exchange calendars based on the given query options
+func (store *Store) ExchangeCalendarList(ctx context.Context, options ExchangeCalendarQueryInterface) ([]ExchangeCalendarInterface, error) {
+	q, columns, err := store.exchangeCalendarQuery(options)
+
+	if err != nil {
+		return []ExchangeCalendarInterface{}, err
+	}
+
+	q = q.Prepared(true).Select(columns...)
+
+	sqlStr, sqlParams, errSql := q.ToSQL()
+
+	if errSql != nil {
+		return []ExchangeCalendarInterface{}, errSql
+	}
+
+	store.logSql("list", sqlStr, sqlParams...)
+
+	modelMaps, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+	if err != nil {
+		return []ExchangeCalendarInterface{}, err
+	}
+
+	list := []ExchangeCalendarInterface{}
+
+	lo.ForEach(modelMaps, func(modelMap map[string]string, index int) {
+		model := NewExchangeCalendarFromExistingData(modelMap)
+		list = append(list, model)
+	})
+
+	return list, nil
+}
+
+// ExchangeCalendarSeedBuiltins persists the built-in exchange calendars
+// for the exchanges which do not have a stored calendar yet
+func (store *Store) ExchangeCalendarSeedBuiltins(ctx context.Context) error {
+	for _, calendar := range BuiltinExchangeCalendars() {
+		existing, err := store.ExchangeCalendarFindByExchange(ctx, calendar.Exchange())
+
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			continue
+		}
+
+		err = store.ExchangeCalendarCreate(ctx, calendar)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExchangeCalendarUpdate updates an exchange calendar
+func (store *Store) ExchangeCalendarUpdate(ctx context.Context, calendar ExchangeCalendarInterface) error {
+	if calendar == nil {
+		return errors.New("exchange calendar is nil")
+	}
+
+	if _, err := calendar.Location(); err != nil {
+		return err
+	}
+
+	// exchanges are stored in upper case, as the built-in calendars
+	if exchange := strings.ToUpper(calendar.Exchange()); exchange != calendar.Exchange() {
+		calendar.SetExchange(exchange)
+	}
+
+	calendar.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+
+	dataChanged := calendar.DataChanged()
+
+	delete(dataChanged, COLUMN_ID) // ID is not updateable
+
+	if len(dataChanged) < 1 {
+		return nil
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Update(store.exchangeCalendarTableName).
+		Prepared(true).
+		Set(dataChanged).
+		Where(goqu.C(COLUMN_ID).Eq(calendar.ID())).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("update", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	calendar.MarkAsNotDirty()
+
+	return err
+}
+
+// InstrumentIsMarketOpen returns true if the market of the instrument is open at the given time.
+// Instruments without a calendar are considered always open.
+func (store *Store) InstrumentIsMarketOpen(ctx context.Context, instrument InstrumentInterface, t time.Time) (bool, error) {
+	calendar, err := store.ExchangeCalendarFindByInstrument(ctx, instrument)
+
+	if err != nil {
+		return false, err
+	}
+
+	if calendar == nil {
+		return true, nil
+	}
+
+	return calendar.IsOpen(t)
+}
+
+// exchangeCalendarQuery returns a query for exchange calendars based on the given query options
+func (store *Store) exchangeCalendarQuery(options ExchangeCalendarQueryInterface) (selectDataset *goqu.SelectDataset, columns []any, err error) {
+	if options == nil {
+		return nil, nil, errors.New("exchange calendar options is nil")
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	q := goqu.Dialect(store.dbDriverName).From(store.exchangeCalendarTableName)
+
+	if options.IsExchangeSet() {
+		// case insensitive, as BuiltinExchangeCalendar, also matching
+		// calendars stored before the exchanges were upper cased
+		q = q.Where(goqu.Func("UPPER", goqu.C(COLUMN_EXCHANGE)).Eq(strings.ToUpper(options.Exchange())))
+	}
+
+	if options.IsIDSet() {
+		q = q.Where(goqu.C(COLUMN_ID).Eq(options.ID()))
+	}
+
+	if !options.IsCountOnly() {
+		if options.IsLimitSet() {
+			q = q.Limit(cast.ToUint(options.Limit()))
+		}
+
+		if options.IsOffsetSet() {
+			q = q.Offset(cast.ToUint(options.Offset()))
+		}
+	}
+
+	if options.IsOrderBySet() {
+		sort := lo.Ternary(options.IsOrderDirectionSet(), options.OrderDirection(), sb.DESC)
+		if strings.EqualFold(sort, sb.ASC) {
+			q = q.Order(goqu.I(options.OrderBy()).Asc())
+		} else {
+			q = q.Order(goqu.I(options.OrderBy()).Desc())
+		}
+	}
+
+	columns = []any{}
+
+	for _, column := range options.Columns() {
+		columns = append(columns, column)
+	}
+
+	return q, columns, nil
+}
@@ -0,0 +1,241 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStoreExchangeCalendarCreateAndFind(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	calendar := NewExchangeCalendar().
+		SetExchange("XETRA").
+		SetName("Xetra").
+		SetTimezone("Europe/Berlin")
+
+	err = calendar.SetSessions([]TradingSession{
+		{Weekday: time.Monday, Open: "09:00", Close: "17:30"},
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	err = calendar.SetHolidays([]string{"2024-12-25"})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	err = store.ExchangeCalendarCreate(ctx, calendar)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.ExchangeCalendarFindByExchange(ctx, "XETRA")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if found == nil {
+		t.Fatal("Calendar MUST NOT be nil")
+	}
+
+	if found.ID() != calendar.ID() || found.Timezone() != "Europe/Berlin" {
+		t.Fatal("Unexpected calendar:", found.Data())
+	}
+
+	holidays, err := found.Holidays()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(holidays) != 1 || holidays[0] != "2024-12-25" {
+		t.Fatal("Unexpected holidays:", holidays)
+	}
+
+	found.SetName("Deutsche Boerse Xetra")
+
+	err = store.ExchangeCalendarUpdate(ctx, found)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	updated, err := store.ExchangeCalendarFindByID(ctx, calendar.ID())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if updated.Name() != "Deutsche Boerse Xetra" {
+		t.Fatal("Calendar name MUST BE updated, found:", updated.Name())
+	}
+
+	err = store.ExchangeCalendarDelete(ctx, updated)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	count, err := store.ExchangeCalendarCount(ctx, ExchangeCalendarQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count != 0 {
+		t.Fatal("Calendars count MUST BE 0, found:", count)
+	}
+
+	calendar = NewExchangeCalendar().SetExchange("BROKEN").SetTimezone("Mars/Olympus_Mons")
+
+	if err := store.ExchangeCalendarCreate(ctx, calendar); err == nil {
+		t.Fatal("Error MUST NOT be nil for an unknown time zone")
+	}
+}
+
+func TestStoreExchangeCalendarExchangeCase(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	calendar := NewExchangeCalendar().
+		SetExchange("xetra").
+		SetName("Xetra").
+		SetTimezone("Europe/Berlin")
+
+	if err := store.ExchangeCalendarCreate(ctx, calendar); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if calendar.Exchange() != "XETRA" {
+		t.Fatal("Exchange MUST be stored in upper case, found:", calendar.Exchange())
+	}
+
+	for _, exchange := range []string{"XETRA", "xetra", "Xetra"} {
+		found, err := store.ExchangeCalendarFindByExchange(ctx, exchange)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if found == nil || found.ID() != calendar.ID() {
+			t.Fatal("Calendar MUST be found by exchange", exchange)
+		}
+	}
+
+	if err := store.ExchangeCalendarUpdate(ctx, calendar.SetExchange("xetra2")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.ExchangeCalendarFindByExchange(ctx, "XETRA2")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if found == nil || found.Exchange() != "XETRA2" {
+		t.Fatal("Updated exchange MUST be stored in upper case")
+	}
+}
+
+func TestStoreExchangeCalendarFindByInstrument(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	// Built-in calendar for the instrument exchange
+	calendar, err := store.ExchangeCalendarFindByInstrument(ctx, NewInstrument().SetExchange("NASDAQ"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if calendar == nil || calendar.Timezone() != "America/New_York" {
+		t.Fatal("Unexpected calendar for NASDAQ")
+	}
+
+	// Crypto instruments fall back to the 24/7 calendar
+	calendar, err = store.ExchangeCalendarFindByInstrument(ctx, NewInstrument().SetExchange("BINANCE").SetAssetClass(ASSET_CLASS_CRYPTO))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if calendar == nil || calendar.Exchange() != EXCHANGE_CRYPTO {
+		t.Fatal("Crypto instruments MUST use the crypto calendar")
+	}
+
+	// Stored calendars take precedence over the built-in ones
+	err = store.ExchangeCalendarSeedBuiltins(ctx)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	count, err := store.ExchangeCalendarCount(ctx, ExchangeCalendarQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count != int64(len(BuiltinExchangeCalendars())) {
+		t.Fatal("Calendars count MUST BE", len(BuiltinExchangeCalendars()), ", found:", count)
+	}
+
+	stored, err := store.ExchangeCalendarFindByExchange(ctx, EXCHANGE_NASDAQ)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := stored.SetHolidays([]string{"2024-07-04"}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.ExchangeCalendarUpdate(ctx, stored); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	instrument := NewInstrument().SetSymbol("AAPL").SetExchange("NASDAQ")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	isOpen, err := store.InstrumentIsMarketOpen(ctx, instrument, time.Date(2024, time.July, 4, 11, 0, 0, 0, newYork))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if isOpen {
+		t.Fatal("NASDAQ MUST be closed on a stored holiday")
+	}
+
+	isOpen, err = store.InstrumentIsMarketOpen(ctx, instrument, time.Date(2024, time.July, 5, 11, 0, 0, 0, newYork))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !isOpen {
+		t.Fatal("NASDAQ MUST be open on a regular trading day")
+	}
+}
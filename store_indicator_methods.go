@@ -0,0 +1,349 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/dracory/database"
+	"github.com/dracory/sb"
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+)
+
+// indicatorSaveBatchSize is the number of indicator values deleted and
+// inserted per statement
+const indicatorSaveBatchSize = 500
+
+// IndicatorInvalidate deletes the persisted indicator values of a price series
+// at or after the given time, for all indicators
+//
+// Values at or after a changed bar are stale, as indicators carry state
+// from one bar to the next. It is called automatically when prices are
+// updated or deleted through the store.
+//
+// Parameters:
+// - ctx: the context
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - timeframe: the timeframe of the price series
+// - from: the time of the first stale value
+//
+// Returns:
+// - error: if indicator persistence is disabled, or the values could not be deleted
+func (store *Store) IndicatorInvalidate(ctx context.Context, symbol string, exchange string, timeframe string, from time.Time) error {
+	if store.indicatorTableNamePrefix == "" {
+		return errors.New("indicator persistence is disabled, IndicatorTableNamePrefix is not set")
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Delete(store.IndicatorTableName(symbol, exchange, timeframe)).
+		Prepared(true).
+		Where(goqu.C(COLUMN_TIME).Gte(store.priceTimeToStorage(from))).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("delete", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	return err
+}
+
+// IndicatorList returns the persisted indicator values of a price series
+// based on the given query options, ordered by time
+func (store *Store) IndicatorList(ctx context.Context, symbol string, exchange string, timeframe string, options IndicatorValueQueryInterface) ([]IndicatorValueInterface, error) {
+	if store.indicatorTableNamePrefix == "" {
+		return []IndicatorValueInterface{}, errors.New("indicator persistence is disabled, IndicatorTableNamePrefix is not set")
+	}
+
+	q, columns, err := store.indicatorQuery(symbol, exchange, timeframe, options)
+
+	if err != nil {
+		return []IndicatorValueInterface{}, err
+	}
+
+	q = q.Prepared(true).Select(columns...)
+
+	sqlStr, sqlParams, errSql := q.ToSQL()
+
+	if errSql != nil {
+		return []IndicatorValueInterface{}, errSql
+	}
+
+	store.logSql("list", sqlStr, sqlParams...)
+
+	modelMaps, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+	if err != nil {
+		return []IndicatorValueInterface{}, err
+	}
+
+	list := []IndicatorValueInterface{}
+
+	lo.ForEach(modelMaps, func(modelMap map[string]string, index int) {
+		if value, found := modelMap[COLUMN_TIME]; found {
+			modelMap[COLUMN_TIME] = store.priceTimeFromStorage(value)
+		}
+
+		model := NewIndicatorValueFromExistingData(modelMap)
+		list = append(list, model)
+	})
+
+	return list, nil
+}
+
+// IndicatorSave persists indicator values of a price series. A value replaces
+// any value stored for the same indicator name, parameters hash and time.
+// The values are saved in batches within a single transaction.
+//
+// Parameters:
+// - ctx: the context
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - timeframe: the timeframe of the price series
+// - values: the indicator values to save
+//
+// Returns:
+// - error: if indicator persistence is disabled, a value is invalid, or could not be saved
+func (store *Store) IndicatorSave(ctx context.Context, symbol string, exchange string, timeframe string, values []IndicatorValueInterface) error {
+	if store.indicatorTableNamePrefix == "" {
+		return errors.New("indicator persistence is disabled, IndicatorTableNamePrefix is not set")
+	}
+
+	tableName := store.IndicatorTableName(symbol, exchange, timeframe)
+
+	// the values by key, the last value of a key replaces the others
+	keys := []string{}
+	records := map[string]goqu.Record{}
+
+	for _, value := range values {
+		if value == nil {
+			return errors.New("indicator value is nil")
+		}
+
+		if value.Name() == "" {
+			return errors.New("indicator value name is empty")
+		}
+
+		if value.ParamsHash() == "" {
+			return errors.New("indicator value params hash is empty")
+		}
+
+		if value.Time() == "" {
+			return errors.New("indicator value time is empty")
+		}
+
+		if value.TimeCarbon().Error != nil {
+			return errors.New("indicator value time is not a valid time: " + value.Time())
+		}
+
+		record := goqu.Record{}
+
+		for column, columnValue := range value.Data() {
+			record[column] = columnValue
+		}
+
+		// the times are stored in the time storage format of the price tables
+		t := value.TimeCarbon().StdTime()
+		record[COLUMN_TIME] = store.priceTimeToStorage(t)
+
+		key := value.Name() + "\n" + value.ParamsHash() + "\n" + priceTimeFormat(t)
+
+		if _, found := records[key]; !found {
+			keys = append(keys, key)
+		}
+
+		records[key] = record
+	}
+
+	if len(keys) < 1 {
+		return nil
+	}
+
+	err := store.withTx(ctx, func(queryableCtx database.QueryableContext) error {
+		for _, chunk := range lo.Chunk(keys, indicatorSaveBatchSize) {
+			conditions := lo.Map(chunk, func(key string, _ int) exp.Expression {
+				return goqu.And(
+					goqu.C(COLUMN_NAME).Eq(records[key][COLUMN_NAME]),
+					goqu.C(COLUMN_PARAMS_HASH).Eq(records[key][COLUMN_PARAMS_HASH]),
+					goqu.C(COLUMN_TIME).Eq(records[key][COLUMN_TIME]),
+				)
+			})
+
+			sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+				Delete(tableName).
+				Prepared(true).
+				Where(goqu.Or(conditions...)).
+				ToSQL()
+
+			if errSql != nil {
+				return errSql
+			}
+
+			store.logSql("delete", sqlStr, sqlParams...)
+
+			_, err := database.Execute(queryableCtx, sqlStr, sqlParams...)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		// rows inserted together must have the same columns
+		bySignature := lo.GroupBy(keys, func(key string) string {
+			columns := lo.Keys(records[key])
+			slices.Sort(columns)
+			return strings.Join(columns, ",")
+		})
+
+		for _, signatureKeys := range bySignature {
+			for _, chunk := range lo.Chunk(signatureKeys, indicatorSaveBatchSize) {
+				rows := lo.Map(chunk, func(key string, _ int) any {
+					return records[key]
+				})
+
+				sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+					Insert(tableName).
+					Prepared(true).
+					Rows(rows...).
+					ToSQL()
+
+				if errSql != nil {
+					return errSql
+				}
+
+				store.logSql("create", sqlStr, sqlParams...)
+
+				_, err := database.Execute(queryableCtx, sqlStr, sqlParams...)
+
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		value.MarkAsNotDirty()
+	}
+
+	return nil
+}
+
+// indicatorTableMigrate recreates the indicator table of a price series if
+// its time column has another time storage format than the store. The
+// persisted values can be computed again, so they are not converted.
+func (store *Store) indicatorTableMigrate(ctx context.Context, symbol string, exchange string, timeframe string) error {
+	tableName := store.IndicatorTableName(symbol, exchange, timeframe)
+
+	columns, err := store.tableColumns(ctx, tableName)
+
+	if err != nil {
+		return err
+	}
+
+	if len(columns) < 1 || !priceTableTimeChanged(columns, store.priceTableTimeColumn()) {
+		return nil
+	}
+
+	dropSql, err := sb.TableDropSql(store.toQuerableContext(ctx), tableName)
+
+	if err != nil {
+		return err
+	}
+
+	for _, sqlStr := range []string{dropSql, store.sqlTableIndicatorCreate(symbol, exchange, timeframe)} {
+		store.logSql("migrate", sqlStr)
+
+		if _, err := database.Execute(store.toQuerableContext(ctx), sqlStr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indicatorInvalidate invalidates the persisted indicator values from the
+// earliest of the given times, if indicator persistence is enabled
+func (store *Store) indicatorInvalidate(ctx context.Context, symbol string, exchange string, timeframe string, times ...time.Time) error {
+	if store.indicatorTableNamePrefix == "" || len(times) < 1 {
+		return nil
+	}
+
+	from := lo.MinBy(times, func(a time.Time, b time.Time) bool {
+		return a.Before(b)
+	})
+
+	return store.IndicatorInvalidate(ctx, symbol, exchange, timeframe, from)
+}
+
+// indicatorQuery returns a query for indicator values based on the given query options
+func (store *Store) indicatorQuery(symbol string, exchange string, timeframe string, options IndicatorValueQueryInterface) (selectDataset *goqu.SelectDataset, columns []any, err error) {
+	if options == nil {
+		return nil, nil, errors.New("indicator value options is nil")
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	q := goqu.Dialect(store.dbDriverName).From(store.IndicatorTableName(symbol, exchange, timeframe))
+
+	if options.IsNameSet() {
+		q = q.Where(goqu.C(COLUMN_NAME).Eq(options.Name()))
+	}
+
+	if options.IsParamsHashSet() {
+		q = q.Where(goqu.C(COLUMN_PARAMS_HASH).Eq(options.ParamsHash()))
+	}
+
+	if options.IsTimeSet() {
+		q = q.Where(goqu.C(COLUMN_TIME).Eq(store.priceTimeQueryValue(options.Time())))
+	}
+
+	if options.IsTimeGteSet() {
+		q = q.Where(goqu.C(COLUMN_TIME).Gte(store.priceTimeQueryValue(options.TimeGte())))
+	}
+
+	if options.IsTimeLteSet() {
+		q = q.Where(goqu.C(COLUMN_TIME).Lte(store.priceTimeQueryValue(options.TimeLte())))
+	}
+
+	if !options.IsCountOnly() {
+		if options.IsLimitSet() {
+			q = q.Limit(cast.ToUint(options.Limit()))
+		}
+
+		if options.IsOffsetSet() {
+			q = q.Offset(cast.ToUint(options.Offset()))
+		}
+	}
+
+	sort := lo.Ternary(options.IsOrderDirectionSet(), options.OrderDirection(), sb.ASC)
+	if strings.EqualFold(sort, sb.ASC) {
+		q = q.Order(goqu.I(COLUMN_TIME).Asc())
+	} else {
+		q = q.Order(goqu.I(COLUMN_TIME).Desc())
+	}
+
+	columns = []any{}
+
+	for _, column := range options.Columns() {
+		columns = append(columns, column)
+	}
+
+	return q, columns, nil
+}
@@ -0,0 +1,370 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dracory/sb"
+)
+
+func initIndicatorStore(t *testing.T) StoreInterface {
+	store, err := NewStore(NewStoreOptions{
+		DB:                       initDB(":memory:"),
+		PriceTableNamePrefix:     "price_",
+		InstrumentTableName:      "instrument",
+		IndicatorTableNamePrefix: "indicator_",
+		UseMultipleExchanges:     true,
+		AutomigrateEnabled:       true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := seedInstruments(store); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(context.Background()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func newTestIndicatorValue(t *testing.T, time string, value float64) IndicatorValueInterface {
+	indicatorValue := NewIndicatorValue().
+		SetName("sma").
+		SetParamsHash(IndicatorParamsHash(map[string]string{"period": "3"})).
+		SetTime(time)
+
+	if err := indicatorValue.SetValues(map[string]float64{"value": value}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return indicatorValue
+}
+
+func TestIndicatorParamsHash(t *testing.T) {
+	a := IndicatorParamsHash(map[string]string{"fast": "12", "slow": "26"})
+	b := IndicatorParamsHash(map[string]string{"slow": "26", "fast": "12"})
+	c := IndicatorParamsHash(map[string]string{"fast": "12", "slow": "27"})
+
+	if a != b {
+		t.Fatal("Hash MUST NOT depend on the parameters order")
+	}
+
+	if a == c {
+		t.Fatal("Hash MUST depend on the parameters values")
+	}
+}
+
+func TestStoreIndicatorSaveAndList(t *testing.T) {
+	store := initIndicatorStore(t)
+	ctx := context.Background()
+
+	err := store.IndicatorSave(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, []IndicatorValueInterface{
+		newTestIndicatorValue(t, "2020-01-01 00:01:00", 1),
+		newTestIndicatorValue(t, "2020-01-01 00:00:00", 0),
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// replaces the value at the same time
+	err = store.IndicatorSave(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, []IndicatorValueInterface{
+		newTestIndicatorValue(t, "2020-01-01 00:01:00", 2),
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	list, err := store.IndicatorList(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, IndicatorValueQuery().
+		SetName("sma").
+		SetParamsHash(IndicatorParamsHash(map[string]string{"period": "3"})))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(list) != 2 {
+		t.Fatal("Values count MUST BE 2, found:", len(list))
+	}
+
+	values, err := list[1].Values()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !list[0].TimeCarbon().Lt(list[1].TimeCarbon()) {
+		t.Fatal("Values MUST BE in ascending time order")
+	}
+
+	if values["value"] != 2 {
+		t.Fatal("Value MUST BE 2, found:", values["value"])
+	}
+
+	other, err := store.IndicatorList(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, IndicatorValueQuery().
+		SetName("sma").
+		SetParamsHash(IndicatorParamsHash(map[string]string{"period": "5"})))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(other) != 0 {
+		t.Fatal("Values of other parameters MUST BE empty, found:", len(other))
+	}
+}
+
+func TestStoreIndicatorSaveMany(t *testing.T) {
+	store := initIndicatorStore(t)
+	ctx := context.Background()
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	values := []IndicatorValueInterface{}
+
+	// more values than a batch, the last one repeating the time of the first
+	for i := 0; i < indicatorSaveBatchSize+100; i++ {
+		values = append(values, newTestIndicatorValue(t, start.Add(time.Duration(i)*time.Minute).Format(time.DateTime), float64(i)))
+	}
+
+	values = append(values, newTestIndicatorValue(t, start.Format(time.DateTime), -1))
+
+	if err := store.IndicatorSave(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, values); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	list, err := store.IndicatorList(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, IndicatorValueQuery().
+		SetName("sma").
+		SetParamsHash(IndicatorParamsHash(map[string]string{"period": "3"})))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(list) != indicatorSaveBatchSize+100 {
+		t.Fatal("Values count MUST BE", indicatorSaveBatchSize+100, ", found:", len(list))
+	}
+
+	first, err := list[0].Values()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if first["value"] != -1 {
+		t.Fatal("The last value of a time MUST replace the others, found:", first["value"])
+	}
+}
+
+func TestStoreIndicatorInvalidatedOnPriceWrites(t *testing.T) {
+	store := initIndicatorStore(t)
+	ctx := context.Background()
+
+	prices := []PriceInterface{}
+
+	for _, time := range []string{"2020-01-01 00:00:00", "2020-01-01 00:01:00", "2020-01-01 00:02:00"} {
+		price := NewPrice().SetTime(time).SetOpen("1").SetHigh("1").SetLow("1").SetClose("1").SetVolume("1")
+
+		if err := store.PriceCreate(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		prices = append(prices, price)
+	}
+
+	save := func() {
+		err := store.IndicatorSave(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, []IndicatorValueInterface{
+			newTestIndicatorValue(t, "2020-01-01 00:00:00", 1),
+			newTestIndicatorValue(t, "2020-01-01 00:01:00", 1),
+			newTestIndicatorValue(t, "2020-01-01 00:02:00", 1),
+		})
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	count := func() int {
+		list, err := store.IndicatorList(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, IndicatorValueQuery())
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		return len(list)
+	}
+
+	save()
+
+	if err := store.PriceUpdate(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, prices[1].SetClose("2")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count() != 1 {
+		t.Fatal("Values after the updated price MUST BE invalidated, found:", count())
+	}
+
+	save()
+
+	if err := store.PriceDeleteByID(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, prices[2].ID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count() != 2 {
+		t.Fatal("Values after the deleted price MUST BE invalidated, found:", count())
+	}
+}
+
+func TestStoreIndicatorPersistenceDisabled(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	err = store.IndicatorSave(context.Background(), "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, []IndicatorValueInterface{})
+
+	if err == nil {
+		t.Fatal("Saving without an indicator table prefix MUST return an error")
+	}
+}
+
+func TestStoreIndicatorTimesUseThePriceTimeStorage(t *testing.T) {
+	store := initIndicatorStore(t)
+	ctx := context.Background()
+
+	err := store.IndicatorSave(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, []IndicatorValueInterface{
+		newTestIndicatorValue(t, "2020-01-01 00:00:00", 0),
+		newTestIndicatorValue(t, "2020-01-01 00:01:00", 1),
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// the query times are normalized, whatever their format
+	list, err := store.IndicatorList(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, IndicatorValueQuery().
+		SetTimeGte("2020-01-01T00:01:00Z"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(list) != 1 || !list[0].TimeCarbon().StdTime().Equal(time.Date(2020, 1, 1, 0, 1, 0, 0, time.UTC)) {
+		t.Fatal("An ISO8601 query time MUST match the stored values, found:", len(list))
+	}
+
+	if _, err := store.IndicatorList(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, IndicatorValueQuery().SetTime("yesterday-ish")); err == nil {
+		t.Fatal("IndicatorList MUST reject a query time which is not a time")
+	}
+
+	// stores with epoch milliseconds keep the milliseconds of the values
+	millisStore, err := NewStore(NewStoreOptions{
+		DB:                       initDB(":memory:"),
+		PriceTableNamePrefix:     "price_",
+		InstrumentTableName:      "instrument",
+		IndicatorTableNamePrefix: "indicator_",
+		UseMultipleExchanges:     true,
+		AutomigrateEnabled:       true,
+		PriceTimeStorage:         PRICE_TIME_STORAGE_EPOCH_MILLIS,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := seedInstruments(millisStore); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := millisStore.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	err = millisStore.IndicatorSave(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, []IndicatorValueInterface{
+		newTestIndicatorValue(t, "2020-01-01T00:00:00.250Z", 0),
+		newTestIndicatorValue(t, "2020-01-01T00:00:00.500Z", 1),
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	list, err = millisStore.IndicatorList(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, IndicatorValueQuery().
+		SetTime("2020-01-01T00:00:00.250Z"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(list) != 1 || list[0].TimeCarbon().StdTime().Nanosecond() != 250*int(time.Millisecond) {
+		t.Fatal("A millisecond query time MUST match the stored value, found:", len(list))
+	}
+
+	if err := millisStore.IndicatorInvalidate(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, time.Date(2020, 1, 1, 0, 0, 0, 400*int(time.Millisecond), time.UTC)); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	list, err = millisStore.IndicatorList(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, IndicatorValueQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(list) != 1 {
+		t.Fatal("IndicatorInvalidate MUST delete only the values at or after its time, found:", len(list))
+	}
+}
+
+func TestStoreMigratePriceTablesRecreatesIndicatorTables(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		DB:                       initDB(":memory:"),
+		PriceTableNamePrefix:     "price_",
+		InstrumentTableName:      "instrument",
+		IndicatorTableNamePrefix: "indicator_",
+		UseMultipleExchanges:     true,
+		AutomigrateEnabled:       true,
+		PriceTimeStorage:         PRICE_TIME_STORAGE_EPOCH_MILLIS,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	if err := store.InstrumentCreate(ctx, NewInstrument().SetSymbol("AAPL").SetExchange("NASDAQ").SetTimeframes([]string{TIMEFRAME_1_DAY})); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	tableName := store.(*Store).IndicatorTableName("AAPL", "NASDAQ", TIMEFRAME_1_DAY)
+
+	// An indicator table created with the datetime time storage
+	_, err = store.DB().Exec(`CREATE TABLE "` + tableName + `"("id" TEXT(40) PRIMARY KEY NOT NULL, "name" TEXT(50) NOT NULL, "params_hash" TEXT(64) NOT NULL, "time" DATETIME NOT NULL, "indicator_values" TEXT, "created_at" TEXT(50))`)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.MigratePriceTables(ctx, nil); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	columns, err := store.(*Store).tableColumns(ctx, tableName)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, column := range columns {
+		if column.Name == COLUMN_TIME && column.Type != sb.COLUMN_TYPE_INTEGER {
+			t.Fatal("Indicator time column MUST be recreated as epoch milliseconds, got", column.Type)
+		}
+	}
+}
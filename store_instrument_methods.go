@@ -69,6 +69,10 @@ func (store *Store) InstrumentExists(ctx context.Context, options InstrumentQuer
 
 // InstrumentCreate creates a new instrument
 func (store *Store) InstrumentCreate(ctx context.Context, instrument InstrumentInterface) error {
+	if err := instrumentValidate(instrument); err != nil {
+		return err
+	}
+
 	data := instrument.Data()
 
 	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
@@ -147,6 +151,32 @@ func (store *Store) InstrumentFindByID(ctx context.Context, id string) (Instrume
 	return nil, nil
 }
 
+// instrumentFindBySymbol returns the instrument with the given symbol and exchange,
+// or nil if there is no such instrument. An empty exchange matches any exchange.
+func (store *Store) instrumentFindBySymbol(ctx context.Context, symbol string, exchange string) (InstrumentInterface, error) {
+	if symbol == "" {
+		return nil, errors.New("instrument symbol is empty")
+	}
+
+	query := NewInstrumentQuery().SetSymbol(symbol).SetLimit(1)
+
+	if exchange != "" {
+		query.SetExchange(exchange)
+	}
+
+	list, err := store.InstrumentList(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return nil, nil
+}
+
 // InstrumentList returns a list of instruments based on the given query options
 func (store *Store) InstrumentList(ctx context.Context, options InstrumentQueryInterface) ([]InstrumentInterface, error) {
 	q, columns, err := store.instrumentQuery(options)
@@ -180,6 +210,60 @@ func (store *Store) InstrumentList(ctx context.Context, options InstrumentQueryI
 	return list, nil
 }
 
+// InstrumentOptionChain returns the options written on the underlying instrument,
+// ordered by expiry, strike and option type unless the options specify an order.
+// The options may narrow the chain further, i.e. with SetExpiryBetween or SetStrikeBetween
+func (store *Store) InstrumentOptionChain(ctx context.Context, underlyingID string, options InstrumentQueryInterface) ([]InstrumentInterface, error) {
+	if underlyingID == "" {
+		return []InstrumentInterface{}, errors.New("instrument underlying id is empty")
+	}
+
+	if options == nil {
+		options = NewInstrumentQuery()
+	}
+
+	options.SetUnderlyingID(underlyingID)
+
+	if !options.IsAssetClassSet() {
+		options.SetAssetClass(ASSET_CLASS_OPTION)
+	}
+
+	q, columns, err := store.instrumentQuery(options)
+
+	if err != nil {
+		return []InstrumentInterface{}, err
+	}
+
+	if !options.IsOrderBySet() {
+		q = q.Order(
+			goqu.C(COLUMN_EXPIRY).Asc(),
+			goqu.C(COLUMN_STRIKE).Asc(),
+			goqu.C(COLUMN_OPTION_TYPE).Asc(),
+		)
+	}
+
+	sqlStr, sqlParams, errSql := q.Prepared(true).Select(columns...).ToSQL()
+
+	if errSql != nil {
+		return []InstrumentInterface{}, errSql
+	}
+
+	store.logSql("list", sqlStr, sqlParams...)
+
+	modelMaps, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+	if err != nil {
+		return []InstrumentInterface{}, err
+	}
+
+	list := []InstrumentInterface{}
+
+	lo.ForEach(modelMaps, func(modelMap map[string]string, index int) {
+		list = append(list, NewInstrumentFromExistingData(modelMap))
+	})
+
+	return list, nil
+}
+
 // InstrumentSoftDelete soft deletes an instrument
 func (store *Store) InstrumentSoftDelete(ctx context.Context, instrument InstrumentInterface) error {
 	if instrument == nil {
@@ -215,8 +299,8 @@ func (store *Store) InstrumentSoftDeleteByID(ctx context.Context, id string) err
 
 // InstrumentUpdate updates an instrument
 func (store *Store) InstrumentUpdate(ctx context.Context, instrument InstrumentInterface) error {
-	if instrument == nil {
-		return errors.New("instrument is nil")
+	if err := instrumentValidate(instrument); err != nil {
+		return err
 	}
 
 	dataChanged := instrument.DataChanged()
@@ -247,6 +331,102 @@ func (store *Store) InstrumentUpdate(ctx context.Context, instrument InstrumentI
 	return err
 }
 
+// instrumentValidate returns an error if the trading or derivative specification
+// of the instrument is invalid or, for an option, incomplete
+func instrumentValidate(instrument InstrumentInterface) error {
+	if instrument == nil {
+		return errors.New("instrument is nil")
+	}
+
+	if instrument.PricePrecision() < 0 || instrument.PricePrecision() > PRICE_PRECISION_MAX {
+		return errors.New("instrument price precision must be between 0 and " + strconv.Itoa(PRICE_PRECISION_MAX))
+	}
+
+	if instrument.VolumePrecision() < 0 || instrument.VolumePrecision() > VOLUME_PRECISION_MAX {
+		return errors.New("instrument volume precision must be between 0 and " + strconv.Itoa(VOLUME_PRECISION_MAX))
+	}
+
+	sizes := map[string]string{
+		"tick size":    instrument.TickSize(),
+		"lot size":     instrument.LotSize(),
+		"min notional": instrument.MinNotional(),
+	}
+
+	for name, size := range sizes {
+		if size == "" {
+			continue
+		}
+
+		value, err := cast.ToFloat64E(size)
+
+		if err != nil || value < 0 {
+			return errors.New("instrument " + name + " must be a non-negative number: " + size)
+		}
+	}
+
+	for _, field := range instrument.PriceFields() {
+		if !lo.Contains(priceFields, field) {
+			return errors.New("instrument price field is not supported: " + field)
+		}
+	}
+
+	if instrumentDecimalPlaces(instrument.TickSize()) > instrument.PricePrecision() {
+		return errors.New("instrument tick size " + instrument.TickSize() + " has more decimals than the price precision")
+	}
+
+	if instrument.Expiry() != "" {
+		if _, err := time.Parse(time.DateOnly, instrument.Expiry()); err != nil {
+			return errors.New("instrument expiry must be formatted as YYYY-MM-DD: " + instrument.Expiry())
+		}
+	}
+
+	if !lo.Contains([]string{"", OPTION_TYPE_CALL, OPTION_TYPE_PUT}, instrument.OptionType()) {
+		return errors.New("instrument option type is not supported: " + instrument.OptionType())
+	}
+
+	if !lo.Contains([]string{"", SETTLEMENT_TYPE_CASH, SETTLEMENT_TYPE_PHYSICAL}, instrument.SettlementType()) {
+		return errors.New("instrument settlement type is not supported: " + instrument.SettlementType())
+	}
+
+	if instrument.Multiplier() != "" && instrument.MultiplierFloat() <= 0 {
+		return errors.New("instrument multiplier must be greater than zero")
+	}
+
+	if instrument.IsSynthetic() {
+		definition, err := instrument.Synthetic()
+
+		if err != nil {
+			return errors.New("instrument synthetic definition is invalid: " + err.Error())
+		}
+
+		if err := syntheticDefinitionValidate(definition); err != nil {
+			return errors.New("instrument " + err.Error())
+		}
+	}
+
+	if instrument.AssetClass() != ASSET_CLASS_OPTION {
+		return nil
+	}
+
+	if instrument.UnderlyingID() == "" {
+		return errors.New("option instrument underlying id is empty")
+	}
+
+	if instrument.Expiry() == "" {
+		return errors.New("option instrument expiry is empty")
+	}
+
+	if instrument.OptionType() == "" {
+		return errors.New("option instrument option type is empty")
+	}
+
+	if instrument.StrikeFloat() <= 0 {
+		return errors.New("option instrument strike must be greater than zero")
+	}
+
+	return nil
+}
+
 // instrumentQuery returns a query for instruments based on the given query options
 func (store *Store) instrumentQuery(options InstrumentQueryInterface) (selectDataset *goqu.SelectDataset, columns []any, err error) {
 	if options == nil {
@@ -267,6 +447,11 @@ func (store *Store) instrumentQuery(options InstrumentQueryInterface) (selectDat
 		q = q.Where(goqu.C(COLUMN_EXCHANGE).Eq(options.Exchange()))
 	}
 
+	if options.IsExpiryBetweenSet() {
+		from, to := options.ExpiryBetween()
+		q = q.Where(goqu.C(COLUMN_EXPIRY).Gte(from), goqu.C(COLUMN_EXPIRY).Lte(to))
+	}
+
 	if options.IsIDSet() {
 		q = q.Where(goqu.C(COLUMN_ID).Eq(options.ID()))
 	}
@@ -275,10 +460,19 @@ func (store *Store) instrumentQuery(options InstrumentQueryInterface) (selectDat
 		q = q.Where(goqu.C(COLUMN_ID).In(options.IDIn()))
 	}
 
+	if options.IsOptionTypeSet() {
+		q = q.Where(goqu.C(COLUMN_OPTION_TYPE).Eq(options.OptionType()))
+	}
+
 	if options.IsStatusSet() {
 		q = q.Where(goqu.C(COLUMN_STATUS).Eq(options.Status()))
 	}
 
+	if options.IsStrikeBetweenSet() {
+		min, max := options.StrikeBetween()
+		q = q.Where(goqu.C(COLUMN_STRIKE).Gte(min), goqu.C(COLUMN_STRIKE).Lte(max))
+	}
+
 	if options.IsSymbolSet() {
 		q = q.Where(goqu.C(COLUMN_SYMBOL).Eq(options.Symbol()))
 	}
@@ -287,6 +481,10 @@ func (store *Store) instrumentQuery(options InstrumentQueryInterface) (selectDat
 		q = q.Where(goqu.C(COLUMN_SYMBOL).Like("%" + options.SymbolLike() + "%"))
 	}
 
+	if options.IsUnderlyingIDSet() {
+		q = q.Where(goqu.C(COLUMN_UNDERLYING_ID).Eq(options.UnderlyingID()))
+	}
+
 	if !options.IsCountOnly() {
 		if options.IsLimitSet() {
 			q = q.Limit(cast.ToUint(options.Limit()))
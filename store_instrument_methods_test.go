@@ -2,6 +2,7 @@ package tradingstore
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/dracory/sb"
@@ -692,3 +693,206 @@ func TestStoreInstrumentSoftDeleteByID(t *testing.T) {
 		t.Fatal("Instrument should be soft deleted (SoftDeletedAt should be set to now) after soft delete by ID", instrumentSoftDeleted.SoftDeletedAt())
 	}
 }
+
+func TestStoreAutoMigrateInstrumentsAddsMissingColumns(t *testing.T) {
+	db := initDB(":memory:")
+
+	// A table created by an older version, without the source timeframe column
+	_, err := db.Exec("CREATE TABLE instrument (id TEXT PRIMARY KEY, symbol TEXT, exchange TEXT)")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err := NewStore(NewStoreOptions{
+		DB:                   db,
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	exists, err := sb.TableColumnExists(store.(*Store).toQuerableContext(context.Background()), "instrument", COLUMN_SOURCE_TIMEFRAME)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !exists {
+		t.Fatal("Column", COLUMN_SOURCE_TIMEFRAME, "MUST be added by the auto migration")
+	}
+}
+
+func TestStoreInstrumentOptionChain(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	clearInstruments(t, store)
+
+	ctx := context.Background()
+
+	underlying := NewInstrument().
+		SetSymbol("SPY").
+		SetExchange(EXCHANGE_NYSE).
+		SetAssetClass(ASSET_CLASS_ETF)
+
+	if err := store.InstrumentCreate(ctx, underlying); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	options := []InstrumentInterface{
+		NewInstrument().SetSymbol("SPY_C_B").SetStrike("510").SetExpiry("2024-04-19").SetOptionType(OPTION_TYPE_CALL),
+		NewInstrument().SetSymbol("SPY_C_A").SetStrike("500").SetExpiry("2024-04-19").SetOptionType(OPTION_TYPE_CALL),
+		NewInstrument().SetSymbol("SPY_P_A").SetStrike("500").SetExpiry("2024-04-19").SetOptionType(OPTION_TYPE_PUT),
+		NewInstrument().SetSymbol("SPY_C_M").SetStrike("500").SetExpiry("2024-03-15").SetOptionType(OPTION_TYPE_CALL),
+	}
+
+	for _, option := range options {
+		option.SetAssetClass(ASSET_CLASS_OPTION).
+			SetExchange(EXCHANGE_NYSE).
+			SetUnderlyingID(underlying.ID()).
+			SetMultiplier("100").
+			SetSettlementType(SETTLEMENT_TYPE_PHYSICAL)
+
+		if err := store.InstrumentCreate(ctx, option); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	chain, err := store.InstrumentOptionChain(ctx, underlying.ID(), nil)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	symbols := []string{}
+	for _, option := range chain {
+		symbols = append(symbols, option.Symbol())
+	}
+
+	expected := "SPY_C_M,SPY_C_A,SPY_P_A,SPY_C_B"
+	if strings.Join(symbols, ",") != expected {
+		t.Fatal("Option chain MUST be ordered by expiry, strike and option type, expected", expected, "got", symbols)
+	}
+
+	if chain[0].MultiplierFloat() != 100 || chain[0].SettlementType() != SETTLEMENT_TYPE_PHYSICAL || chain[0].ExpiryCarbon().ToDateString() != "2024-03-15" {
+		t.Fatal("Option contract specification MUST round trip, got", chain[0].Data())
+	}
+
+	filtered, err := store.InstrumentOptionChain(ctx, underlying.ID(), NewInstrumentQuery().
+		SetExpiryBetween("2024-04-01", "2024-04-30").
+		SetStrikeBetween(495, 505).
+		SetOptionType(OPTION_TYPE_CALL))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(filtered) != 1 || filtered[0].Symbol() != "SPY_C_A" {
+		t.Fatal("Option chain filters MUST narrow the chain to SPY_C_A, got", len(filtered))
+	}
+
+	count, err := store.InstrumentCount(ctx, NewInstrumentQuery().SetUnderlyingID(underlying.ID()))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count != 4 {
+		t.Fatal("Expected 4 instruments on the underlying, got", count)
+	}
+}
+
+func TestStoreInstrumentCreateValidatesDerivatives(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	invalid := map[string]InstrumentInterface{
+		"option without underlying": NewInstrument().SetAssetClass(ASSET_CLASS_OPTION).
+			SetExpiry("2024-03-15").SetStrike("100").SetOptionType(OPTION_TYPE_CALL),
+		"unsupported option type": NewInstrument().SetOptionType("STRADDLE"),
+		"malformed expiry":        NewInstrument().SetAssetClass(ASSET_CLASS_FUTURE).SetExpiry("15/03/2024"),
+		"zero multiplier":         NewInstrument().SetMultiplier("0"),
+	}
+
+	for name, instrument := range invalid {
+		if err := store.InstrumentCreate(ctx, instrument); err == nil {
+			t.Fatal("InstrumentCreate MUST reject an instrument with", name)
+		}
+	}
+}
+
+func TestStoreInstrumentCreateValidatesTradingSpecification(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	invalid := map[string]InstrumentInterface{
+		"negative tick size":            NewInstrument().SetTickSize("-0.01"),
+		"non numeric lot size":          NewInstrument().SetLotSize("one"),
+		"negative min notional":         NewInstrument().SetMinNotional("-5"),
+		"price precision out of range":  NewInstrument().SetPricePrecision(PRICE_PRECISION_MAX + 1),
+		"tick size finer than decimals": NewInstrument().SetTickSize("0.001").SetPricePrecision(2),
+	}
+
+	for name, instrument := range invalid {
+		if err := store.InstrumentCreate(ctx, instrument); err == nil {
+			t.Fatal("InstrumentCreate MUST reject an instrument with", name)
+		}
+	}
+}
+
+func TestStoreInstrumentSymbolFitsOptionSymbols(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	columns, err := sb.TableColumns(store.(*Store).toQuerableContext(ctx), "instrument", true)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// OCC option symbols are 21 characters, i.e. "AAPL  240119C00190000"
+	for _, column := range columns {
+		if column.Name == COLUMN_SYMBOL && column.Length < 21 {
+			t.Fatal("Column", COLUMN_SYMBOL, "MUST fit OCC option symbols, found length:", column.Length)
+		}
+	}
+
+	option := NewInstrument().
+		SetSymbol("AAPL  240119C00190000").
+		SetExchange("OPRA")
+
+	if err := store.InstrumentCreate(ctx, option); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.InstrumentFindByID(ctx, option.ID())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if found == nil || found.Symbol() != "AAPL  240119C00190000" {
+		t.Fatal("Option symbol MUST be stored in full")
+	}
+}
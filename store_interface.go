@@ -3,24 +3,123 @@ package tradingstore
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 // StoreInterface defines the interface for a store
 type StoreInterface interface {
+	// AutoMigrateContinuousContracts automatically creates the continuous contract table if it does not exist
+	AutoMigrateContinuousContracts(ctx context.Context) error
+
+	// AutoMigrateCorporateActions automatically creates the corporate action table if it does not exist
+	AutoMigrateCorporateActions(ctx context.Context) error
+
+	// AutoMigrateExchangeCalendars automatically creates the exchange calendar table if it does not exist
+	AutoMigrateExchangeCalendars(ctx context.Context) error
+
 	// AutoMigrateInstruments automatically creates the schema if it does not exist
 	AutoMigrateInstruments(ctx context.Context) error
 
 	// AutoMigratePrices automatically creates the price tables if they do not exist
-	// It will create a price table for each instrument and each timeframe
+	// It will create a price table for each instrument and each timeframe,
+	// an indicator table if indicator persistence is enabled,
+	// a tick table for each instrument if tick storage is enabled,
+	// a quote table for each instrument if quote storage is enabled,
+	// and an order book snapshot table for each instrument if order book storage is enabled
 	// You will need to call this method when you create a new instrument
 	AutoMigratePrices(ctx context.Context) error
 
+	// ContinuousContractCount returns the number of continuous contracts that match the criteria
+	ContinuousContractCount(ctx context.Context, options ContinuousContractQueryInterface) (int64, error)
+
+	// ContinuousContractCreate creates a new continuous contract in the database
+	ContinuousContractCreate(ctx context.Context, contract ContinuousContractInterface) error
+
+	// ContinuousContractDelete deletes a continuous contract
+	ContinuousContractDelete(ctx context.Context, contract ContinuousContractInterface) error
+
+	// ContinuousContractDeleteByID deletes a continuous contract by ID
+	ContinuousContractDeleteByID(ctx context.Context, id string) error
+
+	// ContinuousContractFindByID finds a continuous contract by its ID
+	ContinuousContractFindByID(ctx context.Context, id string) (ContinuousContractInterface, error)
+
+	// ContinuousContractList returns a list of continuous contracts from the database based on criteria
+	ContinuousContractList(ctx context.Context, options ContinuousContractQueryInterface) ([]ContinuousContractInterface, error)
+
+	// ContinuousContractPriceList returns the stitched prices of a continuous contract, with the rolls between its contracts
+	ContinuousContractPriceList(ctx context.Context, contract ContinuousContractInterface, timeframe string, options PriceQueryInterface) ([]PriceInterface, []ContinuousContractRoll, error)
+
+	// ContinuousContractUpdate updates a continuous contract
+	ContinuousContractUpdate(ctx context.Context, contract ContinuousContractInterface) error
+
+	// CorporateActionCount returns the number of corporate actions that match the criteria
+	CorporateActionCount(ctx context.Context, options CorporateActionQueryInterface) (int64, error)
+
+	// CorporateActionCreate creates a new corporate action in the database
+	CorporateActionCreate(ctx context.Context, action CorporateActionInterface) error
+
+	// CorporateActionDelete deletes a corporate action
+	CorporateActionDelete(ctx context.Context, action CorporateActionInterface) error
+
+	// CorporateActionDeleteByID deletes a corporate action by ID
+	CorporateActionDeleteByID(ctx context.Context, id string) error
+
+	// CorporateActionFindByID finds a corporate action by its ID
+	CorporateActionFindByID(ctx context.Context, id string) (CorporateActionInterface, error)
+
+	// CorporateActionList returns a list of corporate actions from the database based on criteria
+	CorporateActionList(ctx context.Context, options CorporateActionQueryInterface) ([]CorporateActionInterface, error)
+
+	// CorporateActionUpdate updates a corporate action
+	CorporateActionUpdate(ctx context.Context, action CorporateActionInterface) error
+
 	// DB returns the underlying sql.DB connection
 	DB() *sql.DB
 
 	// EnableDebug enables debug mode
 	EnableDebug(bool)
 
+	// ExchangeCalendarCount returns the number of exchange calendars that match the criteria
+	ExchangeCalendarCount(ctx context.Context, options ExchangeCalendarQueryInterface) (int64, error)
+
+	// ExchangeCalendarCreate creates a new exchange calendar in the database
+	ExchangeCalendarCreate(ctx context.Context, calendar ExchangeCalendarInterface) error
+
+	// ExchangeCalendarDelete deletes an exchange calendar
+	ExchangeCalendarDelete(ctx context.Context, calendar ExchangeCalendarInterface) error
+
+	// ExchangeCalendarDeleteByID deletes an exchange calendar by ID
+	ExchangeCalendarDeleteByID(ctx context.Context, id string) error
+
+	// ExchangeCalendarFindByExchange finds the stored calendar of an exchange
+	ExchangeCalendarFindByExchange(ctx context.Context, exchange string) (ExchangeCalendarInterface, error)
+
+	// ExchangeCalendarFindByID finds an exchange calendar by its ID
+	ExchangeCalendarFindByID(ctx context.Context, id string) (ExchangeCalendarInterface, error)
+
+	// ExchangeCalendarFindByInstrument finds the calendar the instrument trades on,
+	// falling back to the built-in calendars
+	ExchangeCalendarFindByInstrument(ctx context.Context, instrument InstrumentInterface) (ExchangeCalendarInterface, error)
+
+	// ExchangeCalendarList returns a list of exchange calendars from the database based on criteria
+	ExchangeCalendarList(ctx context.Context, options ExchangeCalendarQueryInterface) ([]ExchangeCalendarInterface, error)
+
+	// ExchangeCalendarSeedBuiltins persists the built-in calendars of the exchanges without a stored calendar
+	ExchangeCalendarSeedBuiltins(ctx context.Context) error
+
+	// ExchangeCalendarUpdate updates an exchange calendar
+	ExchangeCalendarUpdate(ctx context.Context, calendar ExchangeCalendarInterface) error
+
+	// IndicatorInvalidate deletes the persisted indicator values of a price series at or after the given time
+	IndicatorInvalidate(ctx context.Context, symbol string, exchange string, timeframe string, from time.Time) error
+
+	// IndicatorList returns the persisted indicator values of a price series based on criteria
+	IndicatorList(ctx context.Context, symbol string, exchange string, timeframe string, options IndicatorValueQueryInterface) ([]IndicatorValueInterface, error)
+
+	// IndicatorSave persists indicator values of a price series, replacing the values at the same time
+	IndicatorSave(ctx context.Context, symbol string, exchange string, timeframe string, values []IndicatorValueInterface) error
+
 	// InstrumentCount returns the number of instruments that match the criteria
 	InstrumentCount(ctx context.Context, options InstrumentQueryInterface) (int64, error)
 
@@ -39,9 +138,15 @@ type StoreInterface interface {
 	// InstrumentFindByID finds an instrument by its ID
 	InstrumentFindByID(ctx context.Context, id string) (InstrumentInterface, error)
 
+	// InstrumentIsMarketOpen checks if the market of the instrument is open at the given time
+	InstrumentIsMarketOpen(ctx context.Context, instrument InstrumentInterface, t time.Time) (bool, error)
+
 	// InstrumentList returns a list of instruments from the database based on criteria
 	InstrumentList(ctx context.Context, options InstrumentQueryInterface) ([]InstrumentInterface, error)
 
+	// InstrumentOptionChain returns the options on an underlying instrument, ordered by expiry and strike
+	InstrumentOptionChain(ctx context.Context, underlyingID string, options InstrumentQueryInterface) ([]InstrumentInterface, error)
+
 	// InstrumentSoftDelete soft deletes an instrument
 	InstrumentSoftDelete(ctx context.Context, instrument InstrumentInterface) error
 
@@ -51,6 +156,40 @@ type StoreInterface interface {
 	// InstrumentUpdate updates an instrument
 	InstrumentUpdate(ctx context.Context, instrument InstrumentInterface) error
 
+	// MigratePriceTables rebuilds the existing price tables whose columns do not match
+	// the column precision of their instrument
+	MigratePriceTables(ctx context.Context, options InstrumentQueryInterface) error
+
+	// OrderBookSnapshotCount returns the number of order book snapshots that match the criteria
+	OrderBookSnapshotCount(ctx context.Context, symbol string, exchange string, options OrderBookSnapshotQueryInterface) (int64, error)
+
+	// OrderBookSnapshotCreate creates a new order book snapshot
+	OrderBookSnapshotCreate(ctx context.Context, symbol string, exchange string, snapshot OrderBookSnapshotInterface) error
+
+	// OrderBookSnapshotDelete deletes an order book snapshot
+	OrderBookSnapshotDelete(ctx context.Context, symbol string, exchange string, snapshot OrderBookSnapshotInterface) error
+
+	// OrderBookSnapshotDeleteByID deletes an order book snapshot by its ID
+	OrderBookSnapshotDeleteByID(ctx context.Context, symbol string, exchange string, id string) error
+
+	// OrderBookSnapshotExists returns true if an order book snapshot exists that matches the criteria
+	OrderBookSnapshotExists(ctx context.Context, symbol string, exchange string, options OrderBookSnapshotQueryInterface) (bool, error)
+
+	// OrderBookSnapshotFindByID returns an order book snapshot by its ID
+	OrderBookSnapshotFindByID(ctx context.Context, symbol string, exchange string, id string) (OrderBookSnapshotInterface, error)
+
+	// OrderBookSnapshotList returns a list of order book snapshots that match the criteria
+	OrderBookSnapshotList(ctx context.Context, symbol string, exchange string, options OrderBookSnapshotQueryInterface) ([]OrderBookSnapshotInterface, error)
+
+	// OrderBookSnapshotUpdate updates an order book snapshot
+	OrderBookSnapshotUpdate(ctx context.Context, symbol string, exchange string, snapshot OrderBookSnapshotInterface) error
+
+	// PriceBuildFromQuotes builds bid, ask or mid bars from the stored quotes and writes them to the price table
+	PriceBuildFromQuotes(ctx context.Context, symbol string, exchange string, timeframe string, options QuoteBarOptions, query QuoteQueryInterface) ([]PriceInterface, error)
+
+	// PriceBuildFromTicks builds time, tick, volume or dollar bars from the stored ticks and writes them to the price table
+	PriceBuildFromTicks(ctx context.Context, symbol string, exchange string, timeframe string, options TickBarOptions, query TickQueryInterface) ([]PriceInterface, error)
+
 	// PriceCount returns the number of prices that match the criteria
 	PriceCount(ctx context.Context, symbol string, exchange string, timeframe string, options PriceQueryInterface) (int64, error)
 
@@ -69,9 +208,90 @@ type StoreInterface interface {
 	// PriceFindByID finds a price by its ID
 	PriceFindByID(ctx context.Context, symbol string, exchange string, timeframe string, priceID string) (PriceInterface, error)
 
+	// PriceIterate returns an iterator streaming the prices that match the criteria in ascending time order
+	PriceIterate(ctx context.Context, symbol string, exchange string, timeframe string, options PriceQueryInterface) (PriceIteratorInterface, error)
+
 	// PriceList returns a list of prices from the database based on criteria
 	PriceList(ctx context.Context, symbol string, exchange string, timeframe string, options PriceQueryInterface) ([]PriceInterface, error)
 
+	// PriceListConsolidated returns the prices of a symbol on several exchanges merged into one series, with the contribution of each exchange
+	PriceListConsolidated(ctx context.Context, symbol string, timeframe string, exchanges []string, options PriceQueryInterface) ([]PriceInterface, []PriceContribution, error)
+
+	// PriceListInCurrency returns the prices that match the criteria converted into the given currency with the stored FX pairs
+	PriceListInCurrency(ctx context.Context, symbol string, exchange string, timeframe string, currency string, options PriceQueryInterface) ([]PriceInterface, error)
+
+	// PriceListWithWarmup returns the prices that match the criteria, and up to warmup prices preceding them
+	PriceListWithWarmup(ctx context.Context, symbol string, exchange string, timeframe string, warmup int, options PriceQueryInterface) (warmupPrices []PriceInterface, prices []PriceInterface, err error)
+
+	// PricePanel returns the prices of several instruments aligned on a common time index
+	PricePanel(ctx context.Context, instruments []InstrumentInterface, timeframe string, query PriceQueryInterface, options PricePanelOptions) (PricePanel, error)
+
+	// PriceResample returns the prices of the source timeframe aggregated into the target timeframe
+	PriceResample(ctx context.Context, symbol string, exchange string, sourceTimeframe string, targetTimeframe string, options PriceQueryInterface) ([]PriceInterface, error)
+
+	// PriceTransform transforms the stored prices into Heikin-Ashi, Renko or range bars, optionally storing them under a timeframe key
+	PriceTransform(ctx context.Context, symbol string, exchange string, sourceTimeframe string, targetTimeframe string, options PriceTransformOptions, query PriceQueryInterface) ([]PriceInterface, error)
+
 	// PriceUpdate updates a price
 	PriceUpdate(ctx context.Context, symbol string, exchange string, timeframe string, price PriceInterface) error
+
+	// QuoteCount returns the number of quotes that match the criteria
+	QuoteCount(ctx context.Context, symbol string, exchange string, options QuoteQueryInterface) (int64, error)
+
+	// QuoteCreate creates a new quote
+	QuoteCreate(ctx context.Context, symbol string, exchange string, quote QuoteInterface) error
+
+	// QuoteCreateMany appends quotes in bulk, in a single transaction
+	QuoteCreateMany(ctx context.Context, symbol string, exchange string, quotes []QuoteInterface) error
+
+	// QuoteDelete deletes a quote
+	QuoteDelete(ctx context.Context, symbol string, exchange string, quote QuoteInterface) error
+
+	// QuoteDeleteByID deletes a quote by its ID
+	QuoteDeleteByID(ctx context.Context, symbol string, exchange string, id string) error
+
+	// QuoteExists returns true if a quote exists that matches the criteria
+	QuoteExists(ctx context.Context, symbol string, exchange string, options QuoteQueryInterface) (bool, error)
+
+	// QuoteFindByID returns a quote by its ID
+	QuoteFindByID(ctx context.Context, symbol string, exchange string, id string) (QuoteInterface, error)
+
+	// QuoteIterate returns an iterator over the quotes that match the criteria, in ascending time order
+	QuoteIterate(ctx context.Context, symbol string, exchange string, options QuoteQueryInterface) (QuoteIteratorInterface, error)
+
+	// QuoteList returns a list of quotes that match the criteria
+	QuoteList(ctx context.Context, symbol string, exchange string, options QuoteQueryInterface) ([]QuoteInterface, error)
+
+	// QuoteUpdate updates a quote
+	QuoteUpdate(ctx context.Context, symbol string, exchange string, quote QuoteInterface) error
+
+	// TickCount returns the number of ticks that match the criteria
+	TickCount(ctx context.Context, symbol string, exchange string, options TickQueryInterface) (int64, error)
+
+	// TickCreate creates a new tick in the database
+	TickCreate(ctx context.Context, symbol string, exchange string, tick TickInterface) error
+
+	// TickCreateMany appends ticks in bulk, with multi-row inserts in a single transaction
+	TickCreateMany(ctx context.Context, symbol string, exchange string, ticks []TickInterface) error
+
+	// TickDelete deletes a tick
+	TickDelete(ctx context.Context, symbol string, exchange string, tick TickInterface) error
+
+	// TickDeleteByID deletes a tick by ID
+	TickDeleteByID(ctx context.Context, symbol string, exchange string, tickID string) error
+
+	// TickExists checks if a tick exists by checking a number of criteria
+	TickExists(ctx context.Context, symbol string, exchange string, options TickQueryInterface) (bool, error)
+
+	// TickFindByID finds a tick by its ID
+	TickFindByID(ctx context.Context, symbol string, exchange string, tickID string) (TickInterface, error)
+
+	// TickIterate returns an iterator streaming the ticks that match the criteria in ascending time order
+	TickIterate(ctx context.Context, symbol string, exchange string, options TickQueryInterface) (TickIteratorInterface, error)
+
+	// TickList returns a list of ticks from the database based on criteria
+	TickList(ctx context.Context, symbol string, exchange string, options TickQueryInterface) ([]TickInterface, error)
+
+	// TickUpdate updates a tick
+	TickUpdate(ctx context.Context, symbol string, exchange string, tick TickInterface) error
 }
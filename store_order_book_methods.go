@@ -0,0 +1,310 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/dracory/database"
+	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+)
+
+// OrderBookSnapshotCount returns the number of order book snapshots based on the given query options
+func (store *Store) OrderBookSnapshotCount(ctx context.Context, symbol string, exchange string, options OrderBookSnapshotQueryInterface) (int64, error) {
+	options.SetCountOnly(true)
+
+	q, _, err := store.orderBookSnapshotQuery(symbol, exchange, options)
+
+	if err != nil {
+		return -1, err
+	}
+
+	sqlStr, sqlParams, errSql := q.Prepared(true).
+		Limit(1).
+		Select(goqu.COUNT(goqu.Star()).As("count")).
+		ToSQL()
+
+	if errSql != nil {
+		return -1, errSql
+	}
+
+	store.logSql("count", sqlStr, sqlParams...)
+
+	mapped, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return -1, err
+	}
+
+	if len(mapped) < 1 {
+		return -1, nil
+	}
+
+	return strconv.ParseInt(mapped[0]["count"], 10, 64)
+}
+
+// OrderBookSnapshotCreate creates a new order book snapshot. If the store
+// keeps a number of levels (OrderBookLevels), only the top levels of each
+// side are stored.
+func (store *Store) OrderBookSnapshotCreate(ctx context.Context, symbol string, exchange string, snapshot OrderBookSnapshotInterface) error {
+	if snapshot == nil {
+		return errors.New("order book snapshot is nil")
+	}
+
+	if err := store.orderBookStorageCheck(); err != nil {
+		return err
+	}
+
+	if err := orderBookSnapshotValidate(snapshot.Data(), false); err != nil {
+		return err
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Insert(store.OrderBookTableName(symbol, exchange)).
+		Prepared(true).
+		Rows(store.orderBookSnapshotRecord(snapshot.Data())).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("create", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return err
+	}
+
+	snapshot.MarkAsNotDirty()
+
+	return nil
+}
+
+// OrderBookSnapshotDelete deletes an order book snapshot
+func (store *Store) OrderBookSnapshotDelete(ctx context.Context, symbol string, exchange string, snapshot OrderBookSnapshotInterface) error {
+	if snapshot == nil {
+		return errors.New("order book snapshot is nil")
+	}
+
+	return store.OrderBookSnapshotDeleteByID(ctx, symbol, exchange, snapshot.ID())
+}
+
+// OrderBookSnapshotDeleteByID deletes an order book snapshot by its ID
+func (store *Store) OrderBookSnapshotDeleteByID(ctx context.Context, symbol string, exchange string, id string) error {
+	if id == "" {
+		return errors.New("order book snapshot id is empty")
+	}
+
+	if err := store.orderBookStorageCheck(); err != nil {
+		return err
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Delete(store.OrderBookTableName(symbol, exchange)).
+		Prepared(true).
+		Where(goqu.C(COLUMN_ID).Eq(id)).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("delete", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	return err
+}
+
+// OrderBookSnapshotExists returns true if an order book snapshot exists based on the given query options
+func (store *Store) OrderBookSnapshotExists(ctx context.Context, symbol string, exchange string, options OrderBookSnapshotQueryInterface) (bool, error) {
+	count, err := store.OrderBookSnapshotCount(ctx, symbol, exchange, options)
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// OrderBookSnapshotFindByID returns an order book snapshot by its ID
+func (store *Store) OrderBookSnapshotFindByID(ctx context.Context, symbol string, exchange string, snapshotID string) (OrderBookSnapshotInterface, error) {
+	if snapshotID == "" {
+		return nil, errors.New("order book snapshot id is empty")
+	}
+
+	list, err := store.OrderBookSnapshotList(ctx, symbol, exchange, NewOrderBookSnapshotQuery().SetID(snapshotID).SetLimit(1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return nil, nil
+}
+
+// OrderBookSnapshotList returns a list of order book snapshots based on the
+// given query options, in ascending time order unless an order is set
+func (store *Store) OrderBookSnapshotList(ctx context.Context, symbol string, exchange string, options OrderBookSnapshotQueryInterface) ([]OrderBookSnapshotInterface, error) {
+	q, columns, err := store.orderBookSnapshotQuery(symbol, exchange, options)
+
+	if err != nil {
+		return []OrderBookSnapshotInterface{}, err
+	}
+
+	sqlStr, sqlParams, errSql := q.Prepared(true).Select(columns...).ToSQL()
+
+	if errSql != nil {
+		return []OrderBookSnapshotInterface{}, errSql
+	}
+
+	store.logSql("list", sqlStr, sqlParams...)
+
+	modelMaps, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return []OrderBookSnapshotInterface{}, err
+	}
+
+	list := []OrderBookSnapshotInterface{}
+
+	lo.ForEach(modelMaps, func(modelMap map[string]string, index int) {
+		if value, found := modelMap[COLUMN_TIME]; found {
+			modelMap[COLUMN_TIME] = tickTimeFromStorage(value)
+		}
+
+		list = append(list, NewOrderBookSnapshotFromExistingData(modelMap))
+	})
+
+	return list, nil
+}
+
+// OrderBookSnapshotUpdate updates an order book snapshot
+func (store *Store) OrderBookSnapshotUpdate(ctx context.Context, symbol string, exchange string, snapshot OrderBookSnapshotInterface) error {
+	if snapshot == nil {
+		return errors.New("order book snapshot is nil")
+	}
+
+	if err := store.orderBookStorageCheck(); err != nil {
+		return err
+	}
+
+	dataChanged := snapshot.DataChanged()
+
+	delete(dataChanged, COLUMN_ID) // ID is not updateable
+
+	if len(dataChanged) < 1 {
+		return nil
+	}
+
+	if err := orderBookSnapshotValidate(dataChanged, true); err != nil {
+		return err
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Update(store.OrderBookTableName(symbol, exchange)).
+		Prepared(true).
+		Set(store.orderBookSnapshotRecord(dataChanged)).
+		Where(goqu.C(COLUMN_ID).Eq(snapshot.ID())).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("update", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return err
+	}
+
+	snapshot.MarkAsNotDirty()
+
+	return nil
+}
+
+// orderBookSnapshotQuery builds the select of the order book snapshots matching the query options
+func (store *Store) orderBookSnapshotQuery(symbol string, exchange string, options OrderBookSnapshotQueryInterface) (selectDataset *goqu.SelectDataset, columns []any, err error) {
+	if options == nil {
+		return nil, nil, errors.New("order book snapshot options is nil")
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := store.orderBookStorageCheck(); err != nil {
+		return nil, nil, err
+	}
+
+	q, columns := store.timeRangeSelect(store.OrderBookTableName(symbol, exchange), options)
+
+	return q, columns, nil
+}
+
+// orderBookSnapshotRecord converts the data of an order book snapshot to a
+// record of the order book table, with the time as microseconds since the
+// Unix epoch, and the levels of each side cut to the levels kept by the store
+func (store *Store) orderBookSnapshotRecord(data map[string]string) goqu.Record {
+	record := goqu.Record{}
+
+	for column, value := range data {
+		record[column] = value
+	}
+
+	if value, found := data[COLUMN_TIME]; found {
+		record[COLUMN_TIME] = tickTimeQueryValue(value)
+	}
+
+	if store.orderBookLevels > 0 {
+		for _, column := range []string{COLUMN_BIDS, COLUMN_ASKS} {
+			if value, found := data[column]; found {
+				levels, _ := orderBookLevelsParse(value)
+				record[column] = orderBookLevelsFormat(levels[:min(len(levels), store.orderBookLevels)])
+			}
+		}
+	}
+
+	return record
+}
+
+// orderBookStorageCheck returns an error if order book storage is disabled
+func (store *Store) orderBookStorageCheck() error {
+	if store.orderBookTableNamePrefix == "" {
+		return errors.New("trading store: order book storage is disabled, OrderBookTableNamePrefix is not set")
+	}
+
+	return nil
+}
+
+// orderBookSnapshotValidate checks the time and the levels of the order book
+// snapshot data. Partial data, i.e. the changed values of an update, is
+// checked only for the values it contains
+func orderBookSnapshotValidate(data map[string]string, partial bool) error {
+	value, found := data[COLUMN_TIME]
+
+	if !found && !partial {
+		return errors.New("order book snapshot time is empty")
+	}
+
+	if found && carbon.Parse(value, carbon.UTC).Error != nil {
+		return errors.New("order book snapshot time is not a valid time: " + value)
+	}
+
+	for _, column := range []string{COLUMN_BIDS, COLUMN_ASKS} {
+		if _, err := orderBookLevelsParse(data[column]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func initOrderBookStore(t *testing.T, levels int) StoreInterface {
+	store, err := NewStore(NewStoreOptions{
+		DB:                       initDB(":memory:"),
+		PriceTableNamePrefix:     "price_",
+		OrderBookTableNamePrefix: "order_book_",
+		OrderBookLevels:          levels,
+		InstrumentTableName:      "instrument",
+		UseMultipleExchanges:     true,
+		AutomigrateEnabled:       true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	instrument := NewInstrument().
+		SetSymbol("BTCUSDT").
+		SetExchange("BINANCE").
+		SetTimeframes([]string{TIMEFRAME_1_MINUTE})
+
+	if err := store.InstrumentCreate(context.Background(), instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(context.Background()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func orderBookTestLevel(price int64, size int64) OrderBookLevel {
+	return OrderBookLevel{Price: NewDecimal(price, 1), Size: NewDecimal(size, 0)}
+}
+
+func TestStoreOrderBookSnapshotCreateAndList(t *testing.T) {
+	store := initOrderBookStore(t, 2)
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		snapshot := NewOrderBookSnapshot().
+			SetTimeT(start.Add(time.Duration(i) * time.Second)).
+			SetBids([]OrderBookLevel{orderBookTestLevel(999, 3), orderBookTestLevel(1000, 1), orderBookTestLevel(998, 5)}).
+			SetAsks([]OrderBookLevel{orderBookTestLevel(1010, 4), orderBookTestLevel(1001+int64(i), 2)})
+
+		if err := store.OrderBookSnapshotCreate(ctx, "BTCUSDT", "BINANCE", snapshot); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	list, err := store.OrderBookSnapshotList(ctx, "BTCUSDT", "BINANCE", NewOrderBookSnapshotQuery().
+		SetTimeGteT(start.Add(time.Second)))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(list) != 2 {
+		t.Fatal("Expected 2 snapshots in the time range, got", len(list))
+	}
+
+	bids := list[0].Bids()
+
+	if len(bids) != 2 || bids[0].Price.String() != "100.0" || bids[1].Price.String() != "99.9" {
+		t.Fatal("Stored bids MUST be the top 2 levels sorted by price descending, got", list[0].Data()[COLUMN_BIDS])
+	}
+
+	if list[0].Time() != "2024-01-02T00:00:01Z" {
+		t.Fatal("Unexpected snapshot time:", list[0].Time())
+	}
+
+	spreads := OrderBookSpreadSeries(list)
+
+	if len(spreads) != 2 || spreads[0].Spread.String() != "0.2" || spreads[1].Spread.String() != "0.3" {
+		t.Fatal("Unexpected spread series:", spreads)
+	}
+
+	if spreads[0].Mid.String() != "100.10" {
+		t.Fatal("Expected mid 100.10, got", spreads[0].Mid.String())
+	}
+
+	depths := OrderBookDepthSeries(list, 1)
+
+	// top level: bid 1, ask 2
+	if len(depths) != 2 || depths[0].BidDepth.String() != "1" || depths[0].AskDepth.String() != "2" || !depths[0].Imbalance.Equal(NewDecimal(-333333, 6)) {
+		t.Fatal("Unexpected depth series:", depths)
+	}
+
+	if err := store.OrderBookSnapshotCreate(ctx, "BTCUSDT", "BINANCE", NewOrderBookSnapshot()); err == nil {
+		t.Fatal("OrderBookSnapshotCreate MUST reject a snapshot without a time")
+	}
+}
+
+func TestOrderBookSnapshotEmptySide(t *testing.T) {
+	snapshot := NewOrderBookSnapshot().
+		SetTimeT(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)).
+		SetBids([]OrderBookLevel{orderBookTestLevel(1000, 1)})
+
+	if _, found := snapshot.Spread(); found {
+		t.Fatal("Spread MUST NOT be found without asks")
+	}
+
+	if len(OrderBookSpreadSeries([]OrderBookSnapshotInterface{snapshot})) != 0 {
+		t.Fatal("Spread series MUST skip snapshots with an empty side")
+	}
+
+	depths := OrderBookDepthSeries([]OrderBookSnapshotInterface{snapshot}, 0)
+
+	if len(depths) != 1 || !depths[0].Imbalance.Equal(NewDecimal(1, 0)) {
+		t.Fatal("Depth imbalance of a book with bids only MUST be 1, got", depths)
+	}
+}
+
+func TestStoreOrderBookStorageDisabled(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	_, err = store.OrderBookSnapshotList(context.Background(), "BTCUSDT", "BINANCE", NewOrderBookSnapshotQuery())
+
+	if err == nil {
+		t.Fatal("OrderBookSnapshotList MUST fail when order book storage is disabled")
+	}
+}
@@ -0,0 +1,191 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+)
+
+// priceAdjustment is the back-adjustment of the prices before an ex-date,
+// the prices are multiplied by priceMultiplier and divided by priceDivisor,
+// so the adjustment is exact until rounded to the price precision
+type priceAdjustment struct {
+	exStart         time.Time
+	priceMultiplier Decimal
+	priceDivisor    Decimal
+	volumeFactor    Decimal
+}
+
+// priceAdjust returns the prices back-adjusted for the corporate actions of
+// the instrument, according to the ADJUST_* flags. The adjusted prices are
+// new objects, the given prices and the stored data are not modified.
+//
+// Prices before the ex-date of a split are divided by the split ratio, and
+// their volumes multiplied by it. Prices before the ex-date of a cash dividend
+// are multiplied by 1 - dividend / close before the ex-date. Ex-dates start at
+// midnight in the time zone of the instrument exchange calendar.
+func (store *Store) priceAdjust(ctx context.Context, instrument InstrumentInterface, symbol string, exchange string, timeframe string, prices []PriceInterface, adjustment int) ([]PriceInterface, error) {
+	if adjustment == ADJUST_NONE || len(prices) < 1 || instrument == nil {
+		return prices, nil
+	}
+
+	bucketOptions, err := store.priceBucketOptions(ctx, instrument)
+
+	if err != nil {
+		return nil, err
+	}
+
+	location := bucketOptions.location()
+
+	for _, price := range prices {
+		if price.Time() == "" {
+			return nil, errors.New("price adjustment: prices must include the time column")
+		}
+	}
+
+	earliest := lo.MinBy(prices, func(a PriceInterface, b PriceInterface) bool {
+		return a.TimeCarbon().Lt(b.TimeCarbon())
+	})
+
+	actionTypes := []string{}
+
+	if adjustment&ADJUST_SPLITS != 0 {
+		actionTypes = append(actionTypes, CORPORATE_ACTION_TYPE_SPLIT, CORPORATE_ACTION_TYPE_STOCK_DIVIDEND)
+	}
+
+	if adjustment&ADJUST_DIVIDENDS != 0 {
+		actionTypes = append(actionTypes, CORPORATE_ACTION_TYPE_CASH_DIVIDEND)
+	}
+
+	actions, err := store.CorporateActionList(ctx, CorporateActionQuery().
+		SetInstrumentID(instrument.ID()).
+		SetActionTypeIn(actionTypes).
+		SetExDateGte(earliest.TimeCarbon().StdTime().In(location).Format(time.DateOnly)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	adjustments := []priceAdjustment{}
+
+	for _, action := range actions {
+		exStart, err := time.ParseInLocation(time.DateOnly, action.ExDate(), location)
+
+		if err != nil {
+			return nil, err
+		}
+
+		splitFactor := corporateActionSplitFactor(action)
+
+		adjustment := priceAdjustment{
+			exStart:         exStart,
+			priceMultiplier: NewDecimal(1, 0),
+			priceDivisor:    splitFactor,
+			volumeFactor:    splitFactor,
+		}
+
+		if action.ActionType() == CORPORATE_ACTION_TYPE_CASH_DIVIDEND {
+			previousClose, err := store.pricePreviousClose(ctx, instrument, symbol, exchange, timeframe, exStart)
+
+			if err != nil {
+				return nil, err
+			}
+
+			// the prices are multiplied by 1 - amount / previous close, as
+			// in DividendFactor, kept as a fraction to stay exact
+			amount := priceDecimal(action.Amount())
+
+			if amount.Sign() > 0 && previousClose.Cmp(amount) > 0 {
+				adjustment.priceMultiplier = previousClose.Sub(amount)
+				adjustment.priceDivisor = previousClose
+			}
+		}
+
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if len(adjustments) < 1 {
+		return prices, nil
+	}
+
+	precision := store.priceColumnPrecision(instrument)
+	one := NewDecimal(1, 0)
+
+	adjusted := make([]PriceInterface, 0, len(prices))
+
+	for _, price := range prices {
+		priceTime := price.TimeCarbon().StdTime()
+		priceMultiplier, priceDivisor, volumeFactor := one, one, one
+
+		for _, adjustment := range adjustments {
+			if priceTime.Before(adjustment.exStart) {
+				priceMultiplier = priceMultiplier.Mul(adjustment.priceMultiplier)
+				priceDivisor = priceDivisor.Mul(adjustment.priceDivisor)
+				volumeFactor = volumeFactor.Mul(adjustment.volumeFactor)
+			}
+		}
+
+		data := lo.Assign(price.Data())
+
+		if !priceMultiplier.Equal(priceDivisor) {
+			for _, column := range []string{COLUMN_OPEN, COLUMN_HIGH, COLUMN_LOW, COLUMN_CLOSE} {
+				if value, ok := data[column]; ok && value != "" {
+					adjustedValue := priceDecimal(value).Mul(priceMultiplier).Div(priceDivisor, int32(precision.Price))
+					data[column] = priceColumnFormat(column, adjustedValue, precision)
+				}
+			}
+		}
+
+		if value, ok := data[COLUMN_VOLUME]; ok && value != "" && !volumeFactor.Equal(one) {
+			data[COLUMN_VOLUME] = priceColumnFormat(COLUMN_VOLUME, priceDecimal(value).Mul(volumeFactor), precision)
+		}
+
+		adjusted = append(adjusted, NewPriceFromExistingData(data))
+	}
+
+	return adjusted, nil
+}
+
+// corporateActionSplitFactor returns the split factor of the action as in
+// SplitFactor, built from the ratio string so the adjustment stays exact
+// for ratios with more digits than a float can hold
+func corporateActionSplitFactor(action CorporateActionInterface) Decimal {
+	one := NewDecimal(1, 0)
+	ratio := priceDecimal(action.Ratio())
+
+	if ratio.Sign() <= 0 {
+		return one
+	}
+
+	switch action.ActionType() {
+	case CORPORATE_ACTION_TYPE_SPLIT:
+		return ratio
+	case CORPORATE_ACTION_TYPE_STOCK_DIVIDEND:
+		return one.Add(ratio)
+	}
+
+	return one
+}
+
+// pricePreviousClose returns the unadjusted close of the last stored price
+// before the given time, or 0 if there is none
+func (store *Store) pricePreviousClose(ctx context.Context, instrument InstrumentInterface, symbol string, exchange string, timeframe string, before time.Time) (Decimal, error) {
+	list, err := store.priceList(ctx, instrument, symbol, exchange, timeframe, PriceQuery().
+		SetTimeLte(carbon.CreateFromStdTime(before.Add(-time.Second), carbon.UTC).ToDateTimeString(carbon.UTC)).
+		SetOrderBy(COLUMN_TIME).
+		SetOrderDirection("desc").
+		SetLimit(1))
+
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	if len(list) < 1 {
+		return Decimal{}, nil
+	}
+
+	return list[0].CloseDecimal(), nil
+}
@@ -0,0 +1,89 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// PriceListConsolidated returns the prices of a symbol on several exchanges
+// merged into one series, with the contribution of each exchange.
+//
+// It requires UseMultipleExchanges, so each exchange has its own price
+// tables. The instruments of the symbol with the timeframe are merged,
+// only those of the given exchanges if any. See PriceConsolidate for how the
+// prices are merged.
+//
+// The time filters and the adjustment of the options are applied to the
+// prices of each exchange, the order, offset and limit to the consolidated
+// prices. Only ordering by time is supported.
+//
+// Parameters:
+// - ctx: the context
+// - symbol: the instrument symbol
+// - timeframe: the timeframe of the prices
+// - exchanges: the exchanges to include, all the exchanges of the symbol if empty
+// - options: the query options
+//
+// Returns:
+// - []PriceInterface: the consolidated prices
+// - []PriceContribution: the contribution of each exchange, over the time filters of the options
+// - error: if no instrument of the symbol has the timeframe
+func (store *Store) PriceListConsolidated(ctx context.Context, symbol string, timeframe string, exchanges []string, options PriceQueryInterface) ([]PriceInterface, []PriceContribution, error) {
+	if !store.useMultipleExchanges {
+		return nil, nil, errors.New("consolidated prices: the store does not use multiple exchanges")
+	}
+
+	if symbol == "" {
+		return nil, nil, errors.New("consolidated prices: symbol is empty")
+	}
+
+	if err := priceQueryMemoryValidate(options, "consolidated prices"); err != nil {
+		return nil, nil, err
+	}
+
+	instruments, err := store.InstrumentList(ctx, InstrumentQuery().SetSymbol(symbol))
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instruments = lo.Filter(instruments, func(instrument InstrumentInterface, _ int) bool {
+		if instrument.IsSynthetic() || !lo.Contains(instrument.Timeframes(), timeframe) {
+			return false
+		}
+
+		return len(exchanges) < 1 || lo.ContainsBy(exchanges, func(exchange string) bool {
+			return strings.EqualFold(exchange, instrument.Exchange())
+		})
+	})
+
+	if len(instruments) < 1 {
+		return nil, nil, errors.New("consolidated prices: no instrument " + symbol + " with timeframe " + timeframe + " on the exchanges")
+	}
+
+	source := priceQuerySource(options)
+	pricesByExchange := map[string][]PriceInterface{}
+	decimals := int32(0)
+
+	for _, instrument := range instruments {
+		prices, err := store.PriceList(ctx, symbol, instrument.Exchange(), timeframe, source)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pricesByExchange[instrument.Exchange()] = prices
+		decimals = max(decimals, int32(store.priceColumnPrecision(instrument).Price))
+	}
+
+	prices, contributions, err := PriceConsolidate(pricesByExchange, decimals)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return priceQueryMemoryPage(prices, options), contributions, nil
+}
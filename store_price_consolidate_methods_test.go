@@ -0,0 +1,92 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePriceListConsolidated(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		DB:                   initDB(":memory:"),
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	closes := map[string][]string{
+		"BINANCE":  {"100", "102"},
+		"COINBASE": {"104", "106"},
+		"KRAKEN":   {"200", "200"},
+	}
+
+	for exchange, exchangeCloses := range closes {
+		instrument := NewInstrument().
+			SetSymbol("BTC").
+			SetExchange(exchange).
+			SetTimeframes([]string{TIMEFRAME_1_MINUTE})
+
+		if err := store.InstrumentCreate(ctx, instrument); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if err := store.AutoMigratePrices(ctx); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		for index, close := range exchangeCloses {
+			price := NewPrice().
+				SetTime([]string{"2024-01-01 00:00:00", "2024-01-01 00:01:00"}[index]).
+				SetOpen(close).
+				SetHigh(close).
+				SetLow(close).
+				SetClose(close).
+				SetVolume("1")
+
+			if err := store.PriceCreate(ctx, "BTC", exchange, TIMEFRAME_1_MINUTE, price); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		}
+	}
+
+	prices, contributions, err := store.PriceListConsolidated(ctx, "BTC", TIMEFRAME_1_MINUTE, []string{"BINANCE", "COINBASE"}, NewPriceQuery().
+		SetOrderBy(COLUMN_TIME).
+		SetOrderDirection("desc").
+		SetLimit(1))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 1 {
+		t.Fatal("Expected the latest consolidated price, got", len(prices))
+	}
+
+	if prices[0].Time() != "2024-01-01T00:01:00Z" || prices[0].CloseFloat() != 104 || prices[0].VolumeFloat() != 2 {
+		t.Fatal("Unexpected consolidated price:", prices[0].Data())
+	}
+
+	if len(contributions) != 2 || contributions[0].Share.Float64() != 0.5 {
+		t.Fatal("Expected the contributions of the 2 filtered exchanges")
+	}
+
+	all, contributions, err := store.PriceListConsolidated(ctx, "BTC", TIMEFRAME_1_MINUTE, nil, NewPriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(all) != 2 || len(contributions) != 3 {
+		t.Fatal("Expected all the exchanges of the symbol")
+	}
+
+	if _, _, err := store.PriceListConsolidated(ctx, "ETH", TIMEFRAME_1_MINUTE, nil, NewPriceQuery()); err == nil {
+		t.Fatal("A symbol without instruments MUST fail")
+	}
+}
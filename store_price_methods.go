@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/dracory/database"
@@ -69,16 +70,65 @@ func (store *Store) PriceExists(ctx context.Context, symbol string, exchange str
 }
 
 // PriceCreate creates a new price
+// If the timeframe is the source timeframe of the instrument, the rollup bars
+// of the larger instrument timeframes are recomputed. Persisted indicator
+// values at or after the price time are invalidated. The price, the rollup
+// bars and the indicator values are written in a single transaction.
 func (store *Store) PriceCreate(ctx context.Context, symbol string, exchange string, timeframe string, price PriceInterface) error {
+	if price == nil {
+		return errors.New("price is nil")
+	}
+
+	instrument, err := store.instrumentFindBySymbol(ctx, symbol, exchange)
+
+	if err != nil {
+		return err
+	}
+
+	err = store.priceFormat(instrument, price)
+
+	if err != nil {
+		return err
+	}
+
+	rollup, err := store.priceRollupFind(ctx, instrument, timeframe)
+
+	if err != nil {
+		return err
+	}
+
+	return store.withTx(ctx, func(ctx database.QueryableContext) error {
+		err := store.priceCreate(ctx, symbol, exchange, timeframe, price)
+
+		if err != nil {
+			return err
+		}
+
+		// a backfilled bar changes the indicator values after it
+		err = store.indicatorInvalidate(ctx, symbol, exchange, timeframe, price.TimeCarbon().StdTime())
+
+		if err != nil {
+			return err
+		}
+
+		return store.priceRollupsRecompute(ctx, symbol, exchange, timeframe, rollup, price.TimeCarbon().StdTime())
+	})
+}
+
+// priceCreate inserts a new price, without updating the rollups
+func (store *Store) priceCreate(ctx context.Context, symbol string, exchange string, timeframe string, price PriceInterface) error {
+	record := goqu.Record{}
 
-	data := price.Data()
+	for column, value := range price.Data() {
+		record[column] = value
+	}
 
-	data[COLUMN_TIME] = price.TimeCarbon().ToDateTimeString(carbon.UTC)
+	record[COLUMN_TIME] = store.priceTimeToStorage(price.TimeT())
 
 	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
 		Insert(store.PriceTableName(symbol, exchange, timeframe)).
 		Prepared(true).
-		Rows(data).
+		Rows(record).
 		ToSQL()
 
 	if errSql != nil {
@@ -98,6 +148,79 @@ func (store *Store) PriceCreate(ctx context.Context, symbol string, exchange str
 	return nil
 }
 
+// priceFormat formats the changed values of the price with the column precision
+// of the instrument, and checks its extended price fields are enabled for the
+// instrument. The values are left unchanged if the instrument is unknown (nil)
+func (store *Store) priceFormat(instrument InstrumentInterface, price PriceInterface) error {
+	if instrument == nil {
+		return nil
+	}
+
+	symbol := instrument.Symbol()
+
+	if instrument.IsSynthetic() {
+		return errors.New("prices of synthetic instrument " + symbol + " are computed from its legs and can not be stored")
+	}
+
+	precision := store.priceColumnPrecision(instrument)
+
+	dataChanged := price.DataChanged()
+
+	for _, field := range priceFields {
+		if value, changed := dataChanged[field]; changed && value != "" && !lo.Contains(instrument.PriceFields(), field) {
+			return errors.New("price field " + field + " is not enabled for instrument " + symbol)
+		}
+	}
+
+	setters := map[string]func(string) PriceInterface{
+		COLUMN_OPEN:             price.SetOpen,
+		COLUMN_HIGH:             price.SetHigh,
+		COLUMN_LOW:              price.SetLow,
+		COLUMN_CLOSE:            price.SetClose,
+		COLUMN_VOLUME:           price.SetVolume,
+		COLUMN_VWAP:             price.SetVWAP,
+		COLUMN_BID_CLOSE:        price.SetBidClose,
+		COLUMN_ASK_CLOSE:        price.SetAskClose,
+		COLUMN_OPEN_INTEREST:    price.SetOpenInterest,
+		COLUMN_TAKER_BUY_VOLUME: price.SetTakerBuyVolume,
+	}
+
+	for column, setter := range setters {
+		value, changed := dataChanged[column]
+
+		if !changed || value == "" {
+			continue
+		}
+
+		decimal, err := NewDecimalFromString(value)
+
+		if err != nil {
+			return errors.New("price " + column + " is not a number: " + value)
+		}
+
+		setter(priceColumnFormat(column, decimal, precision))
+	}
+
+	return nil
+}
+
+// priceColumnFormat formats a value of the price column with the column
+// precision. Prices are rounded and padded to the price precision, volumes
+// are rounded, without padding whole volumes with zeros.
+func priceColumnFormat(column string, value Decimal, precision PriceColumnPrecision) string {
+	if column == COLUMN_VOLUME || column == COLUMN_OPEN_INTEREST || column == COLUMN_TAKER_BUY_VOLUME {
+		formatted := value.StringFixed(int32(precision.Volume))
+
+		if strings.Contains(formatted, ".") {
+			formatted = strings.TrimSuffix(strings.TrimRight(formatted, "0"), ".")
+		}
+
+		return formatted
+	}
+
+	return value.StringFixed(int32(precision.Price))
+}
+
 // PriceDelete deletes a price
 func (store *Store) PriceDelete(ctx context.Context, symbol string, exchange string, timeframe string, price PriceInterface) error {
 	if price == nil {
@@ -108,11 +231,61 @@ func (store *Store) PriceDelete(ctx context.Context, symbol string, exchange str
 }
 
 // PriceDeleteByID deletes a price by its ID
+// If the timeframe is the source timeframe of the instrument, the rollup bars
+// of the larger instrument timeframes are recomputed. Persisted indicator
+// values at or after the price time are invalidated. The price, the rollup
+// bars and the indicator values are written in a single transaction.
 func (store *Store) PriceDeleteByID(ctx context.Context, symbol string, exchange string, timeframe string, id string) error {
 	if id == "" {
 		return errors.New("price id is empty")
 	}
 
+	instrument, err := store.instrumentFindBySymbol(ctx, symbol, exchange)
+
+	if err != nil {
+		return err
+	}
+
+	rollup, err := store.priceRollupFind(ctx, instrument, timeframe)
+
+	if err != nil {
+		return err
+	}
+
+	return store.withTx(ctx, func(ctx database.QueryableContext) error {
+		var existing PriceInterface
+
+		if len(rollup.timeframes) > 0 || store.indicatorTableNamePrefix != "" {
+			var err error
+			existing, err = store.priceFindByID(ctx, instrument, symbol, exchange, timeframe, id)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		err := store.priceDeleteByID(ctx, symbol, exchange, timeframe, id)
+
+		if err != nil {
+			return err
+		}
+
+		if existing == nil {
+			return nil
+		}
+
+		err = store.indicatorInvalidate(ctx, symbol, exchange, timeframe, existing.TimeCarbon().StdTime())
+
+		if err != nil {
+			return err
+		}
+
+		return store.priceRollupsRecompute(ctx, symbol, exchange, timeframe, rollup, existing.TimeCarbon().StdTime())
+	})
+}
+
+// priceDeleteByID deletes a price by its ID, without updating the rollups
+func (store *Store) priceDeleteByID(ctx context.Context, symbol string, exchange string, timeframe string, id string) error {
 	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
 		Delete(store.PriceTableName(symbol, exchange, timeframe)).
 		Prepared(true).
@@ -136,9 +309,20 @@ func (store *Store) PriceFindByID(ctx context.Context, symbol string, exchange s
 		return nil, errors.New("price id is empty")
 	}
 
+	instrument, err := store.instrumentFindBySymbol(ctx, symbol, exchange)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return store.priceFindByID(ctx, instrument, symbol, exchange, timeframe, priceID)
+}
+
+// priceFindByID returns a price by its ID, for the already found instrument
+func (store *Store) priceFindByID(ctx context.Context, instrument InstrumentInterface, symbol string, exchange string, timeframe string, priceID string) (PriceInterface, error) {
 	query := NewPriceQuery().SetID(priceID).SetLimit(1)
 
-	list, err := store.PriceList(ctx, symbol, exchange, timeframe, query)
+	list, err := store.priceList(ctx, instrument, symbol, exchange, timeframe, query)
 
 	if err != nil {
 		return nil, err
@@ -151,8 +335,80 @@ func (store *Store) PriceFindByID(ctx context.Context, symbol string, exchange s
 	return nil, nil
 }
 
+// PriceIterate returns an iterator over the prices matching the query options,
+// in ascending time order. The prices are loaded in batches, so the iterator
+// can be used to stream series which do not fit in memory.
+//
+// The limit of the options caps the total number of prices, offsets and
+// custom ordering are not supported.
+//
+// Parameters:
+// - ctx: the context
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - timeframe: the timeframe of the prices
+// - options: the query options
+//
+// Returns:
+// - PriceIteratorInterface: the iterator, must be closed after use
+// - error: if the options are not supported
+func (store *Store) PriceIterate(ctx context.Context, symbol string, exchange string, timeframe string, options PriceQueryInterface) (PriceIteratorInterface, error) {
+	if options == nil {
+		return nil, errors.New("price options is nil")
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := keysetIteratorOptionsCheck("price", options); err != nil {
+		return nil, err
+	}
+
+	remaining := -1
+
+	if options.IsLimitSet() {
+		remaining = options.Limit()
+	}
+
+	load := func(timeGte string, limit int) ([]PriceInterface, error) {
+		query := priceQueryCopy(options).
+			SetCountOnly(false).
+			SetOrderBy(COLUMN_TIME).
+			SetOrderDirection("asc").
+			SetLimit(limit)
+
+		if timeGte != "" {
+			query.SetTimeGte(timeGte)
+		}
+
+		return store.PriceList(ctx, symbol, exchange, timeframe, query)
+	}
+
+	return &priceIterator{newKeysetIterator(priceIteratorBatchSize, remaining, load)}, nil
+}
+
 // PriceList returns a list of prices based on the given query options
+// If an adjustment is set, the prices are back-adjusted for the corporate
+// actions of the instrument on read. The prices of a synthetic instrument
+// are computed from the prices of its legs
 func (store *Store) PriceList(ctx context.Context, symbol string, exchange string, timeframe string, options PriceQueryInterface) ([]PriceInterface, error) {
+	instrument, err := store.instrumentFindBySymbol(ctx, symbol, exchange)
+
+	if err != nil {
+		return []PriceInterface{}, err
+	}
+
+	return store.priceList(ctx, instrument, symbol, exchange, timeframe, options)
+}
+
+// priceList returns a list of prices based on the given query options, for
+// the already found instrument, nil if the instrument is unknown
+func (store *Store) priceList(ctx context.Context, instrument InstrumentInterface, symbol string, exchange string, timeframe string, options PriceQueryInterface) ([]PriceInterface, error) {
+	if instrument != nil && instrument.IsSynthetic() {
+		return store.priceSyntheticList(ctx, instrument, timeframe, options)
+	}
+
 	q, columns, err := store.priceQuery(symbol, exchange, timeframe, options)
 
 	if err != nil {
@@ -177,18 +433,184 @@ func (store *Store) PriceList(ctx context.Context, symbol string, exchange strin
 	list := []PriceInterface{}
 
 	lo.ForEach(modelMaps, func(modelMap map[string]string, index int) {
+		if value, found := modelMap[COLUMN_TIME]; found {
+			modelMap[COLUMN_TIME] = store.priceTimeFromStorage(value)
+		}
+
 		model := NewPriceFromExistingData(modelMap)
 		list = append(list, model)
 	})
 
+	if options.IsAdjustmentSet() {
+		return store.priceAdjust(ctx, instrument, symbol, exchange, timeframe, list, options.Adjustment())
+	}
+
 	return list, nil
 }
 
+// PriceListWithWarmup returns the prices matching the query options, together
+// with up to warmup prices immediately preceding them. The warmup prices are
+// used to seed indicators, so they produce values from the first price of the
+// requested range.
+//
+// Parameters:
+// - ctx: the context
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - timeframe: the timeframe of the prices
+// - warmup: the number of prices to fetch before the requested range
+// - options: the query options of the requested range, ordered by time ascending
+//
+// Returns:
+// - warmupPrices: the prices before the requested range, in ascending time order
+// - prices: the prices of the requested range, in ascending time order
+// - err: if the prices could not be listed
+func (store *Store) PriceListWithWarmup(ctx context.Context, symbol string, exchange string, timeframe string, warmup int, options PriceQueryInterface) (warmupPrices []PriceInterface, prices []PriceInterface, err error) {
+	if options == nil {
+		return nil, nil, errors.New("price options is nil")
+	}
+
+	if options.IsOrderBySet() && !strings.EqualFold(options.OrderBy(), COLUMN_TIME) {
+		return nil, nil, errors.New("price warmup: only ordering by time is supported")
+	}
+
+	if options.IsOrderDirectionSet() && !strings.EqualFold(options.OrderDirection(), sb.ASC) {
+		return nil, nil, errors.New("price warmup: only ascending order is supported")
+	}
+
+	// as with the price tables, ordering by time without a direction would be descending
+	query := priceQueryCopy(options).
+		SetOrderBy(COLUMN_TIME).
+		SetOrderDirection(sb.ASC)
+
+	prices, err = store.PriceList(ctx, symbol, exchange, timeframe, query)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if warmup < 1 {
+		return []PriceInterface{}, prices, nil
+	}
+
+	var boundary *carbon.Carbon
+
+	if options.IsTimeGteSet() {
+		boundary = carbon.Parse(options.TimeGte(), carbon.UTC)
+	} else if len(prices) > 0 {
+		boundary = prices[0].TimeCarbon()
+	} else {
+		return []PriceInterface{}, prices, nil
+	}
+
+	// prices at the boundary time are part of the requested range
+	atBoundary := lo.CountBy(prices, func(price PriceInterface) bool {
+		return price.TimeCarbon().Eq(boundary)
+	})
+
+	previousQuery := PriceQuery().
+		SetTimeLte(boundary.ToDateTimeString(carbon.UTC)).
+		SetOrderBy(COLUMN_TIME).
+		SetOrderDirection(sb.DESC).
+		SetLimit(warmup + atBoundary)
+
+	if options.IsAdjustmentSet() {
+		previousQuery.SetAdjustment(options.Adjustment())
+	}
+
+	previous, err := store.PriceList(ctx, symbol, exchange, timeframe, previousQuery)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	warmupPrices = lo.Filter(previous, func(price PriceInterface, index int) bool {
+		return price.TimeCarbon().Lt(boundary)
+	})
+
+	if len(warmupPrices) > warmup {
+		warmupPrices = warmupPrices[:warmup]
+	}
+
+	return lo.Reverse(warmupPrices), prices, nil
+}
+
+// PriceUpdate updates a price
+// If the timeframe is the source timeframe of the instrument, the rollup bars
+// of the larger instrument timeframes are recomputed. Persisted indicator
+// values at or after the price time are invalidated. The price, the rollup
+// bars and the indicator values are written in a single transaction.
 func (store *Store) PriceUpdate(ctx context.Context, symbol string, exchange string, timeframe string, price PriceInterface) error {
 	if price == nil {
 		return errors.New("price is nil")
 	}
 
+	if len(price.DataChanged()) < 1 {
+		return nil
+	}
+
+	instrument, err := store.instrumentFindBySymbol(ctx, symbol, exchange)
+
+	if err != nil {
+		return err
+	}
+
+	err = store.priceFormat(instrument, price)
+
+	if err != nil {
+		return err
+	}
+
+	rollup, err := store.priceRollupFind(ctx, instrument, timeframe)
+
+	if err != nil {
+		return err
+	}
+
+	return store.withTx(ctx, func(ctx database.QueryableContext) error {
+		var existing PriceInterface
+
+		if len(rollup.timeframes) > 0 || store.indicatorTableNamePrefix != "" {
+			var err error
+			existing, err = store.priceFindByID(ctx, instrument, symbol, exchange, timeframe, price.ID())
+
+			if err != nil {
+				return err
+			}
+		}
+
+		err := store.priceUpdate(ctx, symbol, exchange, timeframe, price)
+
+		if err != nil {
+			return err
+		}
+
+		times := []time.Time{}
+
+		if existing != nil {
+			times = append(times, existing.TimeCarbon().StdTime())
+		}
+
+		if price.Time() != "" {
+			times = append(times, price.TimeCarbon().StdTime())
+		}
+
+		err = store.indicatorInvalidate(ctx, symbol, exchange, timeframe, times...)
+
+		if err != nil {
+			return err
+		}
+
+		if len(rollup.timeframes) < 1 {
+			return nil
+		}
+
+		return store.priceRollupsRecompute(ctx, symbol, exchange, timeframe, rollup, times...)
+	})
+}
+
+// priceUpdate updates a price, without updating the rollups
+func (store *Store) priceUpdate(ctx context.Context, symbol string, exchange string, timeframe string, price PriceInterface) error {
 	dataChanged := price.DataChanged()
 
 	delete(dataChanged, COLUMN_ID) // ID is not updateable
@@ -197,10 +619,20 @@ func (store *Store) PriceUpdate(ctx context.Context, symbol string, exchange str
 		return nil
 	}
 
+	record := goqu.Record{}
+
+	for column, value := range dataChanged {
+		record[column] = value
+	}
+
+	if _, changed := dataChanged[COLUMN_TIME]; changed {
+		record[COLUMN_TIME] = store.priceTimeToStorage(price.TimeT())
+	}
+
 	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
 		Update(store.PriceTableName(symbol, exchange, timeframe)).
 		Prepared(true).
-		Set(dataChanged).
+		Set(record).
 		Where(goqu.C(COLUMN_ID).Eq(price.ID())).
 		ToSQL()
 
@@ -238,18 +670,18 @@ func (store *Store) priceQuery(symbol string, exchange string, timeframe string,
 	}
 
 	if options.IsTimeSet() {
-		q = q.Where(goqu.C(COLUMN_TIME).Eq(options.Time()))
+		q = q.Where(goqu.C(COLUMN_TIME).Eq(store.priceTimeQueryValue(options.Time())))
 	}
 
 	if options.IsTimeGteSet() && options.IsTimeLteSet() {
 		q = q.Where(
-			goqu.C(COLUMN_TIME).Gte(options.TimeGte()),
-			goqu.C(COLUMN_TIME).Lte(options.TimeLte()),
+			goqu.C(COLUMN_TIME).Gte(store.priceTimeQueryValue(options.TimeGte())),
+			goqu.C(COLUMN_TIME).Lte(store.priceTimeQueryValue(options.TimeLte())),
 		)
 	} else if options.IsTimeGteSet() {
-		q = q.Where(goqu.C(COLUMN_TIME).Gte(options.TimeGte()))
+		q = q.Where(goqu.C(COLUMN_TIME).Gte(store.priceTimeQueryValue(options.TimeGte())))
 	} else if options.IsTimeLteSet() {
-		q = q.Where(goqu.C(COLUMN_TIME).Lte(options.TimeLte()))
+		q = q.Where(goqu.C(COLUMN_TIME).Lte(store.priceTimeQueryValue(options.TimeLte())))
 	}
 
 	if !options.IsCountOnly() {
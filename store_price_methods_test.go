@@ -2,9 +2,11 @@ package tradingstore
 
 import (
 	"context"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/dromara/carbon/v2"
 	_ "modernc.org/sqlite"
 )
 
@@ -549,3 +551,375 @@ func TestStorePriceUpdate(t *testing.T) {
 		t.Fatal("Price time should remain unchanged")
 	}
 }
+
+func TestStorePriceIterate(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	// two prices share a time, to check they are not skipped across batches
+	times := []string{
+		"2020-01-01 00:00:00",
+		"2020-01-01 00:01:00",
+		"2020-01-01 00:01:00",
+		"2020-01-01 00:02:00",
+		"2020-01-01 00:03:00",
+		"2020-01-01 00:04:00",
+	}
+
+	for _, priceTime := range times {
+		price := NewPrice().
+			SetTime(priceTime).
+			SetOpen("1").
+			SetHigh("1").
+			SetLow("1").
+			SetClose("1").
+			SetVolume("1")
+
+		err = store.PriceCreate(ctx, "AAPL", "NASDAQ", "1min", price)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	iterator, err := store.PriceIterate(ctx, "AAPL", "NASDAQ", "1min", NewPriceQuery().
+		SetTimeGte("2020-01-01 00:01:00"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	defer iterator.Close()
+
+	iterator.(*priceIterator).batchSize = 2
+
+	ids := map[string]bool{}
+	previous := ""
+
+	for iterator.Next() {
+		price := iterator.Price()
+
+		if ids[price.ID()] {
+			t.Fatal("Price returned twice:", price.ID())
+		}
+
+		ids[price.ID()] = true
+
+		if previous != "" && price.TimeCarbon().Lt(carbon.Parse(previous, carbon.UTC)) {
+			t.Fatal("Prices MUST BE in ascending time order")
+		}
+
+		previous = price.TimeCarbon().ToDateTimeString(carbon.UTC)
+	}
+
+	if iterator.Err() != nil {
+		t.Fatal("unexpected error:", iterator.Err())
+	}
+
+	if len(ids) != 5 {
+		t.Fatal("Prices count MUST BE 5, found:", len(ids))
+	}
+
+	limited, err := store.PriceIterate(ctx, "AAPL", "NASDAQ", "1min", NewPriceQuery().SetLimit(3))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	defer limited.Close()
+
+	count := 0
+
+	for limited.Next() {
+		count++
+	}
+
+	if count != 3 {
+		t.Fatal("Prices count MUST BE 3, found:", count)
+	}
+
+	first, err := store.PriceList(ctx, "AAPL", "NASDAQ", "1min", NewPriceQuery().SetLimit(1))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	byID, err := store.PriceIterate(ctx, "AAPL", "NASDAQ", "1min", NewPriceQuery().SetID(first[0].ID()))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	defer byID.Close()
+
+	count = 0
+
+	for byID.Next() {
+		count++
+
+		if byID.Price().ID() != first[0].ID() {
+			t.Fatal("Price ID MUST BE", first[0].ID(), ", found:", byID.Price().ID())
+		}
+	}
+
+	if count != 1 {
+		t.Fatal("Prices count MUST BE 1, found:", count)
+	}
+
+	_, err = store.PriceIterate(ctx, "AAPL", "NASDAQ", "1min", NewPriceQuery().SetOrderDirection("desc"))
+
+	if err == nil {
+		t.Fatal("Descending order MUST return an error")
+	}
+}
+
+func TestStorePriceListWithWarmup(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	for minute := 0; minute < 10; minute++ {
+		price := NewPrice().
+			SetTime(carbon.Parse("2020-01-01 00:00:00", carbon.UTC).AddMinutes(minute).ToDateTimeString(carbon.UTC)).
+			SetOpen("1").
+			SetHigh("1").
+			SetLow("1").
+			SetClose(strconv.Itoa(minute)).
+			SetVolume("1")
+
+		err = store.PriceCreate(ctx, "AAPL", "NASDAQ", "1min", price)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	warmup, prices, err := store.PriceListWithWarmup(ctx, "AAPL", "NASDAQ", "1min", 3, NewPriceQuery().
+		SetTimeGte("2020-01-01 00:05:00"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 5 {
+		t.Fatal("Prices count MUST BE 5, found:", len(prices))
+	}
+
+	if len(warmup) != 3 {
+		t.Fatal("Warmup count MUST BE 3, found:", len(warmup))
+	}
+
+	for index, expected := range []string{"2", "3", "4"} {
+		if warmup[index].Close() != expected {
+			t.Fatal("Warmup close MUST BE", expected, ", found:", warmup[index].Close())
+		}
+	}
+
+	// not enough history
+	warmup, _, err = store.PriceListWithWarmup(ctx, "AAPL", "NASDAQ", "1min", 10, NewPriceQuery().
+		SetTimeGte("2020-01-01 00:02:00"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(warmup) != 2 {
+		t.Fatal("Warmup count MUST BE 2, found:", len(warmup))
+	}
+
+	// ordering by time without a direction is still ascending
+	query := NewPriceQuery().
+		SetTimeGte("2020-01-01 00:05:00").
+		SetOrderBy(COLUMN_TIME).
+		SetLimit(2)
+
+	warmup, prices, err = store.PriceListWithWarmup(ctx, "AAPL", "NASDAQ", "1min", 2, query)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 || prices[0].Close() != "5" || prices[1].Close() != "6" {
+		t.Fatal("Prices MUST BE the closes 5 and 6 in ascending order, found:", len(prices), "prices starting at", prices[0].Close())
+	}
+
+	if len(warmup) != 2 || warmup[0].Close() != "3" || warmup[1].Close() != "4" {
+		t.Fatal("Warmup MUST BE the closes 3 and 4, found:", len(warmup), "prices")
+	}
+
+	if query.IsOrderDirectionSet() {
+		t.Fatal("The query of the caller MUST NOT be changed")
+	}
+}
+
+func TestStorePriceCreateFormatsWithPricePrecision(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instrument := NewInstrument().
+		SetSymbol("EURUSD").
+		SetExchange(EXCHANGE_FOREX).
+		SetAssetClass(ASSET_CLASS_FOREX).
+		SetBaseAsset("EUR").
+		SetQuoteCurrency("USD").
+		SetTickSize("0.00001").
+		SetPricePrecision(5).
+		SetTimeframes([]string{TIMEFRAME_1_MINUTE})
+
+	if err := store.InstrumentCreate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	price := NewPrice().
+		SetTime("2020-01-01 00:00:00").
+		SetOpen("1.123456789").
+		SetHigh("1.2").
+		SetLow("1.1").
+		SetClose("1.15").
+		SetVolume("1000")
+
+	if err := store.PriceCreate(ctx, "EURUSD", EXCHANGE_FOREX, TIMEFRAME_1_MINUTE, price); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if price.Open() != "1.12346" || price.High() != "1.20000" {
+		t.Fatal("PriceCreate MUST format the values with the price precision, got", price.Open(), price.High())
+	}
+
+	found, err := store.PriceFindByID(ctx, "EURUSD", EXCHANGE_FOREX, TIMEFRAME_1_MINUTE, price.ID())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if found == nil || found.OpenFloat() != 1.12346 {
+		t.Fatal("Stored open MUST be rounded to the price precision")
+	}
+
+	found.SetClose("1.149999")
+
+	if err := store.PriceUpdate(ctx, "EURUSD", EXCHANGE_FOREX, TIMEFRAME_1_MINUTE, found); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if found.Close() != "1.15000" {
+		t.Fatal("PriceUpdate MUST format the changed values with the price precision, got", found.Close())
+	}
+
+	found.SetClose("abc")
+
+	if err := store.PriceUpdate(ctx, "EURUSD", EXCHANGE_FOREX, TIMEFRAME_1_MINUTE, found); err == nil {
+		t.Fatal("PriceUpdate MUST reject values which are not numbers")
+	}
+
+	invalid := NewPrice().
+		SetTime("2020-01-01 00:01:00").
+		SetOpen("1.1").SetHigh("1.2").SetLow("1.1").SetClose("1.15").
+		SetVolume("1e999999999")
+
+	if err := store.PriceCreate(ctx, "EURUSD", EXCHANGE_FOREX, TIMEFRAME_1_MINUTE, invalid); err == nil {
+		t.Fatal("PriceCreate MUST reject values which are not numbers")
+	}
+}
+
+func TestStorePriceExtendedFields(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instrument := NewInstrument().
+		SetSymbol("BTCUSDT").
+		SetExchange("BINANCE").
+		SetPricePrecision(2).
+		SetTimeframes([]string{TIMEFRAME_1_MINUTE})
+
+	if err := store.InstrumentCreate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	price := NewPrice().
+		SetTime("2024-01-02 00:00:00").
+		SetOpen("42000").SetHigh("42100").SetLow("41900").SetClose("42050").SetVolume("12.5").
+		SetTrades("1500").
+		SetVWAP("42010.123")
+
+	if err := store.PriceCreate(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, price); err == nil {
+		t.Fatal("PriceCreate MUST reject price fields not enabled for the instrument")
+	}
+
+	// enabling the fields adds the columns to the existing table
+	instrument.SetPriceFields([]string{COLUMN_TRADES, COLUMN_VWAP, COLUMN_TAKER_BUY_VOLUME})
+
+	if err := store.InstrumentUpdate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PriceCreate(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, price); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	plain := NewPrice().
+		SetTime("2024-01-02 00:01:00").
+		SetOpen("42050").SetHigh("42060").SetLow("42040").SetClose("42055").SetVolume("1")
+
+	if err := store.PriceCreate(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, plain); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	prices, err := store.PriceList(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, NewPriceQuery().
+		SetColumns([]string{COLUMN_ID, COLUMN_TIME, COLUMN_CLOSE, COLUMN_TRADES, COLUMN_VWAP, COLUMN_TAKER_BUY_VOLUME}))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 {
+		t.Fatal("Expected 2 prices, got", len(prices))
+	}
+
+	if prices[0].TradesInt() != 1500 || prices[0].VWAP() != "42010.12" {
+		t.Fatal("Price fields MUST be stored with the instrument precision, got", prices[0].Trades(), prices[0].VWAP())
+	}
+
+	if prices[0].Open() != "" {
+		t.Fatal("Columns not selected MUST NOT be returned, got open", prices[0].Open())
+	}
+
+	if prices[1].Trades() != "" || prices[1].TakerBuyVolume() != "" {
+		t.Fatal("Price fields of bars without them MUST be empty, got", prices[1].Trades(), prices[1].TakerBuyVolume())
+	}
+
+	if err := store.InstrumentUpdate(ctx, instrument.SetPriceFields([]string{"funding_rate"})); err == nil {
+		t.Fatal("InstrumentUpdate MUST reject unsupported price fields")
+	}
+}
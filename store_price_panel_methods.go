@@ -0,0 +1,53 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+)
+
+// PricePanel returns the prices of several instruments aligned on a common
+// time index, so they can be iterated time by time across the instruments.
+//
+// The query options are applied to the prices of each instrument, so a
+// window is best given with SetTimeGte and SetTimeLte rather than a limit.
+// See PricePanelAlign for the join and fill options.
+//
+// Parameters:
+// - ctx: the context
+// - instruments: the instruments of the columns
+// - timeframe: the timeframe of the prices
+// - query: the query options of the prices of each instrument
+// - options: the join and fill options
+//
+// Returns:
+// - PricePanel: the aligned prices
+// - error: if the prices could not be listed or the options are not supported
+func (store *Store) PricePanel(ctx context.Context, instruments []InstrumentInterface, timeframe string, query PriceQueryInterface, options PricePanelOptions) (PricePanel, error) {
+	if query == nil {
+		return PricePanel{}, errors.New("price options is nil")
+	}
+
+	prices := [][]PriceInterface{}
+
+	for _, instrument := range instruments {
+		if instrument == nil {
+			return PricePanel{}, errors.New("price panel: instrument is nil")
+		}
+
+		series, err := store.PriceList(ctx, instrument.Symbol(), instrument.Exchange(), timeframe, query)
+
+		if err != nil {
+			return PricePanel{}, err
+		}
+
+		prices = append(prices, series)
+	}
+
+	rows, err := PricePanelAlign(prices, options)
+
+	if err != nil {
+		return PricePanel{}, err
+	}
+
+	return PricePanel{Instruments: instruments, Rows: rows}, nil
+}
@@ -0,0 +1,80 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePricePanel(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		DB:                   initDB(":memory:"),
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instruments := []InstrumentInterface{}
+
+	for _, symbol := range []string{"AAPL", "MSFT"} {
+		instrument := NewInstrument().
+			SetSymbol(symbol).
+			SetExchange("NASDAQ").
+			SetTimeframes([]string{TIMEFRAME_1_DAY})
+
+		if err := store.InstrumentCreate(ctx, instrument); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		instruments = append(instruments, instrument)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	closes := map[string]map[string]string{
+		"AAPL": {"2024-01-01 00:00:00": "180", "2024-01-02 00:00:00": "182"},
+		"MSFT": {"2024-01-02 00:00:00": "370", "2024-01-03 00:00:00": "372"},
+	}
+
+	for symbol, symbolCloses := range closes {
+		for _, price := range pricePanelTestPrices(symbolCloses) {
+			if err := store.PriceCreate(ctx, symbol, "NASDAQ", TIMEFRAME_1_DAY, price); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		}
+	}
+
+	panel, err := store.PricePanel(ctx, instruments, TIMEFRAME_1_DAY, NewPriceQuery(), PricePanelOptions{Fill: PRICE_PANEL_FILL_FORWARD})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(panel.Rows) != 3 {
+		t.Fatal("Expected 3 rows, got", len(panel.Rows))
+	}
+
+	msft := panel.Column(instruments[1].ID())
+
+	if msft != 1 || panel.Rows[0].Prices[msft] != nil || panel.Rows[2].Prices[0].CloseFloat() != 182 || !panel.Rows[2].Filled[0] {
+		t.Fatal("Unexpected panel rows")
+	}
+
+	inner, err := store.PricePanel(ctx, instruments, TIMEFRAME_1_DAY, NewPriceQuery(), PricePanelOptions{Join: PRICE_PANEL_JOIN_INNER})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(inner.Rows) != 1 || inner.Rows[0].Prices[msft].CloseFloat() != 370 {
+		t.Fatal("Expected the one common row")
+	}
+}
@@ -0,0 +1,249 @@
+package tradingstore
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dracory/sb"
+	"github.com/dromara/carbon/v2"
+)
+
+// PriceResample returns the prices of the source timeframe aggregated into
+// bars of the target timeframe. The stored data is not modified.
+//
+// Daily and larger bars are aligned to the time zone and trading day of the
+// instrument exchange calendar, if there is one.
+//
+// Parameters:
+// - ctx: the context
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - sourceTimeframe: the timeframe to read the prices from
+// - targetTimeframe: the timeframe to aggregate the prices into
+// - options: the query options for the source prices
+//
+// Returns:
+// - []PriceInterface: the aggregated bars
+// - error: if the prices could not be read or aggregated
+func (store *Store) PriceResample(ctx context.Context, symbol string, exchange string, sourceTimeframe string, targetTimeframe string, options PriceQueryInterface) ([]PriceInterface, error) {
+	instrument, err := store.instrumentFindBySymbol(ctx, symbol, exchange)
+
+	if err != nil {
+		return []PriceInterface{}, err
+	}
+
+	bucketOptions, err := store.priceBucketOptions(ctx, instrument)
+
+	if err != nil {
+		return []PriceInterface{}, err
+	}
+
+	if options == nil {
+		options = PriceQuery()
+	}
+
+	// aggregation requires the prices in ascending time order, the options
+	// of the caller are left unchanged
+	query := priceQueryCopy(options).
+		SetOrderBy(COLUMN_TIME).
+		SetOrderDirection(sb.ASC)
+
+	prices, err := store.priceList(ctx, instrument, symbol, exchange, sourceTimeframe, query)
+
+	if err != nil {
+		return []PriceInterface{}, err
+	}
+
+	return PriceAggregateWithOptions(prices, targetTimeframe, bucketOptions)
+}
+
+// priceBucketOptions returns the bucket options for the instrument, aligned to
+// its exchange calendar. Instruments without a calendar, and unknown
+// instruments (nil), are aligned to UTC.
+func (store *Store) priceBucketOptions(ctx context.Context, instrument InstrumentInterface) (TimeframeBucketOptions, error) {
+	if instrument == nil {
+		return TimeframeBucketOptions{}, nil
+	}
+
+	calendar, err := store.ExchangeCalendarFindByInstrument(ctx, instrument)
+
+	if err != nil {
+		return TimeframeBucketOptions{}, err
+	}
+
+	if calendar == nil {
+		return TimeframeBucketOptions{}, nil
+	}
+
+	return calendar.BucketOptions()
+}
+
+// priceRollup defines the timeframes rolled up from a source timeframe
+type priceRollup struct {
+	instrument    InstrumentInterface
+	timeframes    []string
+	bucketOptions TimeframeBucketOptions
+}
+
+// priceRollupFind returns the timeframes of the instrument which are rolled
+// up from the given timeframe, with the bucket options of the instrument
+// exchange calendar. The timeframes are empty unless the timeframe is the
+// source timeframe of the instrument, or if the instrument is unknown (nil).
+func (store *Store) priceRollupFind(ctx context.Context, instrument InstrumentInterface, timeframe string) (priceRollup, error) {
+	if instrument == nil || instrument.SourceTimeframe() == "" {
+		return priceRollup{}, nil
+	}
+
+	if !strings.EqualFold(instrument.SourceTimeframe(), timeframe) {
+		return priceRollup{}, nil
+	}
+
+	sourceDuration, err := TimeframeDuration(timeframe)
+
+	if err != nil {
+		return priceRollup{}, err
+	}
+
+	rollupTimeframes := []string{}
+
+	for _, instrumentTimeframe := range instrument.Timeframes() {
+		duration, err := TimeframeDuration(instrumentTimeframe)
+
+		if err != nil {
+			// custom timeframes are not rolled up
+			continue
+		}
+
+		if duration > sourceDuration {
+			rollupTimeframes = append(rollupTimeframes, instrumentTimeframe)
+		}
+	}
+
+	if len(rollupTimeframes) < 1 {
+		return priceRollup{}, nil
+	}
+
+	bucketOptions, err := store.priceBucketOptions(ctx, instrument)
+
+	if err != nil {
+		return priceRollup{}, err
+	}
+
+	return priceRollup{
+		instrument:    instrument,
+		timeframes:    rollupTimeframes,
+		bucketOptions: bucketOptions,
+	}, nil
+}
+
+// priceRollupsRecompute recomputes the bars of the rollup timeframes whose
+// buckets contain the given times, from the bars of the source timeframe
+func (store *Store) priceRollupsRecompute(ctx context.Context, symbol string, exchange string, sourceTimeframe string, rollup priceRollup, barTimes ...time.Time) error {
+	for _, rollupTimeframe := range rollup.timeframes {
+		recomputed := map[time.Time]bool{}
+
+		for _, barTime := range barTimes {
+			start, err := TimeframeBucketStartWithOptions(rollupTimeframe, barTime, rollup.bucketOptions)
+
+			if err != nil {
+				return err
+			}
+
+			if recomputed[start] {
+				continue
+			}
+
+			recomputed[start] = true
+
+			err = store.priceRollupRecompute(ctx, symbol, exchange, sourceTimeframe, rollupTimeframe, start, rollup)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// priceRollupRecompute recomputes a single rollup bar from the source bars
+// in its bucket. The rollup bar is created, updated or deleted as needed,
+// and the persisted indicator values of the rollup timeframe from the
+// bucket start are invalidated.
+func (store *Store) priceRollupRecompute(ctx context.Context, symbol string, exchange string, sourceTimeframe string, rollupTimeframe string, bucketStart time.Time, rollup priceRollup) error {
+	err := store.indicatorInvalidate(ctx, symbol, exchange, rollupTimeframe, bucketStart)
+
+	if err != nil {
+		return err
+	}
+
+	bucketEnd, err := TimeframeBucketEndWithOptions(rollupTimeframe, bucketStart, rollup.bucketOptions)
+
+	if err != nil {
+		return err
+	}
+
+	prices, err := store.priceList(ctx, rollup.instrument, symbol, exchange, sourceTimeframe, PriceQuery().
+		SetTimeGte(carbon.CreateFromStdTime(bucketStart, carbon.UTC).ToDateTimeString(carbon.UTC)).
+		SetTimeLte(carbon.CreateFromStdTime(bucketEnd.Add(-time.Second), carbon.UTC).ToDateTimeString(carbon.UTC)).
+		SetOrderBy(COLUMN_TIME).
+		SetOrderDirection(sb.ASC))
+
+	if err != nil {
+		return err
+	}
+
+	bars, err := PriceAggregateWithOptions(prices, rollupTimeframe, rollup.bucketOptions)
+
+	if err != nil {
+		return err
+	}
+
+	existingList, err := store.priceList(ctx, rollup.instrument, symbol, exchange, rollupTimeframe, PriceQuery().
+		SetTime(carbon.CreateFromStdTime(bucketStart, carbon.UTC).ToDateTimeString(carbon.UTC)).
+		SetLimit(1))
+
+	if err != nil {
+		return err
+	}
+
+	if len(bars) < 1 {
+		if len(existingList) < 1 {
+			return nil
+		}
+
+		return store.priceDeleteByID(ctx, symbol, exchange, rollupTimeframe, existingList[0].ID())
+	}
+
+	bar := bars[0]
+
+	if len(existingList) < 1 {
+		return store.priceCreate(ctx, symbol, exchange, rollupTimeframe, bar)
+	}
+
+	existing := existingList[0].
+		SetOpen(bar.Open()).
+		SetHigh(bar.High()).
+		SetLow(bar.Low()).
+		SetClose(bar.Close()).
+		SetVolume(bar.Volume())
+
+	// the extended fields are aggregated too, so they must not go stale
+	setters := map[string]func(string) PriceInterface{
+		COLUMN_TRADES:           existing.SetTrades,
+		COLUMN_VWAP:             existing.SetVWAP,
+		COLUMN_OPEN_INTEREST:    existing.SetOpenInterest,
+		COLUMN_TAKER_BUY_VOLUME: existing.SetTakerBuyVolume,
+		COLUMN_BID_CLOSE:        existing.SetBidClose,
+		COLUMN_ASK_CLOSE:        existing.SetAskClose,
+	}
+
+	for _, field := range priceFields {
+		if value := bar.Data()[field]; value != existing.Data()[field] {
+			setters[field](value)
+		}
+	}
+
+	return store.priceUpdate(ctx, symbol, exchange, rollupTimeframe, existing)
+}
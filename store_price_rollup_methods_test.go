@@ -0,0 +1,380 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+)
+
+func initRollupStore(t *testing.T) StoreInterface {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	instrument := NewInstrument().
+		SetSymbol("BTCUSDT").
+		SetExchange("BINANCE").
+		SetAssetClass(ASSET_CLASS_CRYPTO).
+		SetTimeframes([]string{TIMEFRAME_1_MINUTE, TIMEFRAME_5_MINUTES, TIMEFRAME_1_HOUR}).
+		SetSourceTimeframe(TIMEFRAME_1_MINUTE)
+
+	ctx := context.Background()
+
+	if err := store.InstrumentCreate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestStorePriceResample(t *testing.T) {
+	store := initRollupStore(t)
+	ctx := context.Background()
+
+	for _, price := range []PriceInterface{
+		NewPrice().SetTime("2020-01-01 00:00:00").SetOpen("10").SetHigh("12").SetLow("9").SetClose("11").SetVolume("100"),
+		NewPrice().SetTime("2020-01-01 00:06:00").SetOpen("11").SetHigh("15").SetLow("10").SetClose("14").SetVolume("200"),
+	} {
+		if err := store.PriceCreate(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	query := PriceQuery()
+
+	bars, err := store.PriceResample(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_MINUTE, TIMEFRAME_1_HOUR, query)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if query.IsOrderBySet() || query.IsOrderDirectionSet() {
+		t.Fatal("The query of the caller MUST NOT be changed, got:", query.OrderBy(), query.OrderDirection())
+	}
+
+	if len(bars) != 1 {
+		t.Fatal("Bars count MUST BE 1, found:", len(bars))
+	}
+
+	if bars[0].High() != "15" || bars[0].Close() != "14" || bars[0].Volume() != "300" {
+		t.Fatal("Unexpected bar:", bars[0].Data())
+	}
+
+	// AAPL has no source timeframe, so nothing is rolled up
+	count, err := store.PriceCount(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_HOUR, PriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count != 0 {
+		t.Fatal("Rollup count MUST BE 0, found:", count)
+	}
+}
+
+func TestStorePriceRollupsOnWrite(t *testing.T) {
+	store := initRollupStore(t)
+	ctx := context.Background()
+
+	first := NewPrice().SetTime("2020-01-01 00:00:00").SetOpen("10").SetHigh("12").SetLow("9").SetClose("11").SetVolume("100")
+	second := NewPrice().SetTime("2020-01-01 00:01:00").SetOpen("11").SetHigh("15").SetLow("10").SetClose("14").SetVolume("200")
+	third := NewPrice().SetTime("2020-01-01 00:07:00").SetOpen("14").SetHigh("16").SetLow("13").SetClose("15").SetVolume("50")
+
+	for _, price := range []PriceInterface{first, second, third} {
+		if err := store.PriceCreate(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	fiveMinutes, err := store.PriceList(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_5_MINUTES, PriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(fiveMinutes) != 2 {
+		t.Fatal("5min bars count MUST BE 2, found:", len(fiveMinutes))
+	}
+
+	if fiveMinutes[0].HighFloat() != 15 || fiveMinutes[0].CloseFloat() != 14 || fiveMinutes[0].VolumeFloat() != 300 {
+		t.Fatal("Unexpected 5min bar:", fiveMinutes[0].Data())
+	}
+
+	hours, err := store.PriceList(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_HOUR, PriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(hours) != 1 {
+		t.Fatal("1hour bars count MUST BE 1, found:", len(hours))
+	}
+
+	if hours[0].HighFloat() != 16 || hours[0].CloseFloat() != 15 || hours[0].VolumeFloat() != 350 {
+		t.Fatal("Unexpected 1hour bar:", hours[0].Data())
+	}
+
+	// Updating a source bar updates the rollups
+	second.SetHigh("20")
+
+	if err := store.PriceUpdate(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, second); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	hours, err = store.PriceList(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_HOUR, PriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if hours[0].HighFloat() != 20 {
+		t.Fatal("1hour high MUST BE 20, found:", hours[0].High())
+	}
+
+	// Deleting the only source bar of a bucket deletes the rollup bar
+	if err := store.PriceDelete(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, third); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	fiveMinutes, err = store.PriceList(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_5_MINUTES, PriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(fiveMinutes) != 1 {
+		t.Fatal("5min bars count MUST BE 1, found:", len(fiveMinutes))
+	}
+
+	hours, err = store.PriceList(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_HOUR, PriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if hours[0].CloseFloat() != 14 || hours[0].VolumeFloat() != 300 {
+		t.Fatal("Unexpected 1hour bar:", hours[0].Data())
+	}
+}
+
+func TestStorePriceRollupsOnWriteRollsBackAFailedRecompute(t *testing.T) {
+	store := initRollupStore(t)
+	ctx := context.Background()
+
+	// a missing rollup table makes the recompute of the rollups fail
+	_, err := store.(*Store).db.Exec("DROP TABLE " + store.(*Store).PriceTableName("BTCUSDT", "BINANCE", TIMEFRAME_1_HOUR))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	price := NewPrice().SetTime("2020-01-01 00:00:00").SetOpen("10").SetHigh("12").SetLow("9").SetClose("11").SetVolume("100")
+
+	if err := store.PriceCreate(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, price); err == nil {
+		t.Fatal("PriceCreate MUST fail if the rollups can not be recomputed")
+	}
+
+	for _, timeframe := range []string{TIMEFRAME_1_MINUTE, TIMEFRAME_5_MINUTES} {
+		count, err := store.PriceCount(ctx, "BTCUSDT", "BINANCE", timeframe, PriceQuery())
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if count != 0 {
+			t.Fatal("A failed rollup recompute MUST roll back the written bars, found", count, "bars for", timeframe)
+		}
+	}
+}
+
+func TestStorePriceRollupsOnWriteExtendedFields(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instrument := NewInstrument().
+		SetSymbol("BTCUSDT").
+		SetExchange("BINANCE").
+		SetAssetClass(ASSET_CLASS_CRYPTO).
+		SetTimeframes([]string{TIMEFRAME_1_MINUTE, TIMEFRAME_5_MINUTES}).
+		SetSourceTimeframe(TIMEFRAME_1_MINUTE).
+		SetPriceFields([]string{COLUMN_TRADES, COLUMN_VWAP})
+
+	if err := store.InstrumentCreate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// both source bars fall in the same 5min bucket, so the second one
+	// updates the rollup bar created by the first one
+	first := NewPrice().SetTime("2020-01-01 00:00:00").SetOpen("10").SetHigh("12").SetLow("9").SetClose("11").SetVolume("100").
+		SetTrades("5").SetVWAP("10")
+	second := NewPrice().SetTime("2020-01-01 00:01:00").SetOpen("11").SetHigh("15").SetLow("10").SetClose("14").SetVolume("200").
+		SetTrades("7").SetVWAP("13")
+
+	for _, price := range []PriceInterface{first, second} {
+		if err := store.PriceCreate(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	fiveMinutes, err := store.PriceList(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_5_MINUTES, PriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(fiveMinutes) != 1 {
+		t.Fatal("5min bars count MUST BE 1, found:", len(fiveMinutes))
+	}
+
+	if fiveMinutes[0].Trades() != "12" {
+		t.Fatal("5min trades MUST BE 12, found:", fiveMinutes[0].Trades())
+	}
+
+	if fiveMinutes[0].VWAPFloat() != 12 {
+		t.Fatal("5min vwap MUST BE 12, found:", fiveMinutes[0].VWAP())
+	}
+}
+
+func TestStorePriceResampleInExchangeTimezone(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instrument := NewInstrument().
+		SetSymbol("BHP").
+		SetExchange(EXCHANGE_ASX).
+		SetAssetClass(ASSET_CLASS_STOCK).
+		SetTimeframes([]string{TIMEFRAME_1_HOUR, TIMEFRAME_1_DAY})
+
+	if err := store.InstrumentCreate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// One Sydney trading day (March 13), spanning two UTC dates
+	for _, price := range []PriceInterface{
+		NewPrice().SetTime("2024-03-12 23:00:00").SetOpen("10").SetHigh("11").SetLow("9").SetClose("10").SetVolume("100"),
+		NewPrice().SetTime("2024-03-13 04:00:00").SetOpen("10").SetHigh("12").SetLow("10").SetClose("12").SetVolume("100"),
+	} {
+		if err := store.PriceCreate(ctx, "BHP", EXCHANGE_ASX, TIMEFRAME_1_HOUR, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	bars, err := store.PriceResample(ctx, "BHP", EXCHANGE_ASX, TIMEFRAME_1_HOUR, TIMEFRAME_1_DAY, PriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(bars) != 1 {
+		t.Fatal("Daily bars count MUST BE 1, found:", len(bars))
+	}
+
+	if bars[0].Time() != "2024-03-12T23:00:00Z" {
+		t.Fatal("Daily bar MUST start at the Sydney session open, found:", bars[0].Time())
+	}
+
+	if bars[0].Close() != "12" || bars[0].Volume() != "200" {
+		t.Fatal("Unexpected daily bar:", bars[0].Data())
+	}
+}
+
+func TestStorePriceResampleWithoutExchangeCalendarTable(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		DB:                   initDB(":memory:"),
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	// only the instrument and price tables are migrated
+	if err := store.AutoMigrateInstruments(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	instrument := NewInstrument().
+		SetSymbol("BHP").
+		SetExchange(EXCHANGE_ASX).
+		SetAssetClass(ASSET_CLASS_STOCK).
+		SetTimeframes([]string{TIMEFRAME_1_HOUR, TIMEFRAME_1_DAY})
+
+	if err := store.InstrumentCreate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, price := range []PriceInterface{
+		NewPrice().SetTime("2024-03-12 23:00:00").SetOpen("10").SetHigh("11").SetLow("9").SetClose("10").SetVolume("100"),
+		NewPrice().SetTime("2024-03-13 04:00:00").SetOpen("10").SetHigh("12").SetLow("10").SetClose("12").SetVolume("100"),
+	} {
+		if err := store.PriceCreate(ctx, "BHP", EXCHANGE_ASX, TIMEFRAME_1_HOUR, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	bars, err := store.PriceResample(ctx, "BHP", EXCHANGE_ASX, TIMEFRAME_1_HOUR, TIMEFRAME_1_DAY, PriceQuery())
+
+	if err != nil {
+		t.Fatal("Resampling MUST NOT require the exchange calendar table, got:", err)
+	}
+
+	if len(bars) != 1 || bars[0].Time() != "2024-03-12T23:00:00Z" {
+		t.Fatal("Daily bar MUST start at the Sydney session open of the built-in calendar")
+	}
+
+	if !store.(*Store).exchangeCalendarTableChecked.Load() || store.(*Store).exchangeCalendarTableFound.Load() {
+		t.Fatal("The missing exchange calendar table MUST be cached after the first lookup")
+	}
+
+	// migrating the table later MUST make its calendars visible
+	if err := store.AutoMigrateExchangeCalendars(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	custom := BuiltinExchangeCalendar(EXCHANGE_ASX).SetMemo("custom")
+
+	if err := store.ExchangeCalendarCreate(ctx, custom); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	calendar, err := store.ExchangeCalendarFindByInstrument(ctx, instrument)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if calendar == nil || calendar.Memo() != "custom" {
+		t.Fatal("The stored calendar MUST be found once the table is migrated, got:", calendar)
+	}
+}
@@ -0,0 +1,57 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+)
+
+// priceSyntheticList returns the prices of a synthetic instrument, computed
+// from the prices of its legs in the same timeframe.
+//
+// The time filters and the adjustment of the options are applied to the
+// legs, the order, offset and limit to the computed prices. Only ordering
+// by time is supported
+func (store *Store) priceSyntheticList(ctx context.Context, instrument InstrumentInterface, timeframe string, options PriceQueryInterface) ([]PriceInterface, error) {
+	if err := priceQueryMemoryValidate(options, "synthetic prices"); err != nil {
+		return []PriceInterface{}, err
+	}
+
+	definition, err := instrument.Synthetic()
+
+	if err != nil {
+		return []PriceInterface{}, err
+	}
+
+	legQuery := priceQuerySource(options)
+
+	legPrices := [][]PriceInterface{}
+
+	for _, leg := range definition.Legs {
+		legInstrument, err := store.instrumentFindBySymbol(ctx, leg.Symbol, leg.Exchange)
+
+		if err != nil {
+			return []PriceInterface{}, err
+		}
+
+		// nested synthetic instruments could reference each other in a cycle
+		if legInstrument != nil && legInstrument.IsSynthetic() {
+			return []PriceInterface{}, errors.New("synthetic prices: leg " + leg.Symbol + " is itself synthetic")
+		}
+
+		prices, err := store.PriceList(ctx, leg.Symbol, leg.Exchange, timeframe, legQuery)
+
+		if err != nil {
+			return []PriceInterface{}, err
+		}
+
+		legPrices = append(legPrices, prices)
+	}
+
+	prices, err := SyntheticPrices(definition, legPrices, int32(store.priceColumnPrecision(instrument).Price))
+
+	if err != nil {
+		return []PriceInterface{}, err
+	}
+
+	return priceQueryMemoryPage(prices, options), nil
+}
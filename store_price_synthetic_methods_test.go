@@ -0,0 +1,126 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePriceListSynthetic(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		DB:                   initDB(":memory:"),
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	for _, symbol := range []string{"GLD", "SLV"} {
+		instrument := NewInstrument().
+			SetSymbol(symbol).
+			SetExchange("NYSE").
+			SetTimeframes([]string{TIMEFRAME_1_DAY})
+
+		if err := store.InstrumentCreate(ctx, instrument); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	ratio := NewInstrument().
+		SetSymbol("GLDSLV").
+		SetPricePrecision(4).
+		SetTimeframes([]string{TIMEFRAME_1_DAY})
+
+	if err := ratio.SetSynthetic(SyntheticDefinition{
+		Operation: SYNTHETIC_OPERATION_RATIO,
+		Legs:      []SyntheticLeg{{Symbol: "GLD", Exchange: "NYSE"}, {Symbol: "SLV", Exchange: "NYSE"}},
+	}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.InstrumentCreate(ctx, ratio); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	closes := map[string][2]string{
+		"2024-01-01 00:00:00": {"200", "25"},
+		"2024-01-02 00:00:00": {"210", "20"},
+		"2024-01-03 00:00:00": {"220", "22"},
+	}
+
+	for time, close := range closes {
+		for index, symbol := range []string{"GLD", "SLV"} {
+			price := NewPrice().
+				SetTime(time).
+				SetOpen(close[index]).
+				SetHigh(close[index]).
+				SetLow(close[index]).
+				SetClose(close[index]).
+				SetVolume("1000")
+
+			if err := store.PriceCreate(ctx, symbol, "NYSE", TIMEFRAME_1_DAY, price); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		}
+	}
+
+	prices, err := store.PriceList(ctx, "GLDSLV", "", TIMEFRAME_1_DAY, NewPriceQuery().SetTimeGte("2024-01-02 00:00:00"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 {
+		t.Fatal("Expected 2 synthetic prices, got", len(prices))
+	}
+
+	if prices[0].Time() != "2024-01-02T00:00:00Z" || prices[0].CloseFloat() != 10.5 || prices[1].CloseFloat() != 10 {
+		t.Fatal("Unexpected synthetic prices:", prices[0].Data(), prices[1].Data())
+	}
+
+	latest, err := store.PriceList(ctx, "GLDSLV", "", TIMEFRAME_1_DAY, NewPriceQuery().SetOrderBy(COLUMN_TIME).SetOrderDirection("desc").SetLimit(1))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(latest) != 1 || latest[0].Time() != "2024-01-03T00:00:00Z" {
+		t.Fatal("Expected the latest synthetic price")
+	}
+
+	err = store.PriceCreate(ctx, "GLDSLV", "", TIMEFRAME_1_DAY, NewPrice().SetTime("2024-01-04 00:00:00").SetClose("1"))
+
+	if err == nil {
+		t.Fatal("Storing a price of a synthetic instrument MUST fail")
+	}
+}
+
+func TestStoreInstrumentCreateSyntheticInvalid(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	instrument := NewInstrument().SetSymbol("SPREAD")
+
+	if err := instrument.SetSynthetic(SyntheticDefinition{
+		Operation: SYNTHETIC_OPERATION_DIFFERENCE,
+		Legs:      []SyntheticLeg{{Symbol: "CLF25"}},
+	}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.InstrumentCreate(context.Background(), instrument); err == nil {
+		t.Fatal("A difference of one leg MUST be rejected")
+	}
+}
@@ -0,0 +1,335 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/dracory/database"
+	"github.com/dracory/sb"
+	"github.com/samber/lo"
+)
+
+// priceTableCopyBatchSize is the number of rows copied per statement when
+// the times of a price table are converted to another time storage format
+const priceTableCopyBatchSize = 500
+
+// MigratePriceTables migrates the existing price tables of the instruments
+// matching the query options to the column precision of their instrument,
+// i.e. tables created with DECIMAL(20,8) prices and an INTEGER volume.
+// A table which needs migrating is rebuilt: its rows are copied into a new
+// table with the expected columns, which then replaces it.
+// Tables which do not exist yet are left to AutoMigratePrices.
+//
+// The times of tables created with another time storage format, i.e. with a
+// DATETIME column for a store which stores them as epoch milliseconds, are
+// converted to the time storage format of the store. Their indicator tables
+// are recreated empty, as the persisted indicator values can be computed again.
+//
+// SQLite, MySQL and PostgreSQL are supported. The rebuild of a table runs in
+// a transaction, so it is atomic on SQLite and PostgreSQL. MySQL commits DDL
+// statements implicitly, so a rebuild failing there may leave the copy of
+// the table behind (named <table>_migrate), to be checked manually.
+func (store *Store) MigratePriceTables(ctx context.Context, options InstrumentQueryInterface) error {
+	if options == nil {
+		options = NewInstrumentQuery()
+	}
+
+	instruments, err := store.InstrumentList(ctx, options)
+
+	if err != nil {
+		return err
+	}
+
+	for _, instrument := range instruments {
+		precision := store.priceColumnPrecision(instrument)
+
+		for _, timeframe := range instrument.Timeframes() {
+			tableName := store.PriceTableName(instrument.Symbol(), instrument.Exchange(), timeframe)
+
+			err := store.priceTableMigrate(ctx, tableName, precision, instrument.PriceFields())
+
+			if err != nil {
+				return err
+			}
+
+			if store.indicatorTableNamePrefix == "" {
+				continue
+			}
+
+			err = store.indicatorTableMigrate(ctx, instrument.Symbol(), instrument.Exchange(), timeframe)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// priceColumnPrecision returns the decimals of the price table columns of the
+// instrument. The precision set on the instrument takes priority over the
+// precision configured for its asset class, which takes priority over the defaults.
+func (store *Store) priceColumnPrecision(instrument InstrumentInterface) PriceColumnPrecision {
+	precision := PriceColumnPrecision{
+		Price:  PRICE_PRECISION_DEFAULT,
+		Volume: VOLUME_PRECISION_DEFAULT,
+	}
+
+	if assetClassPrecision, found := store.priceColumnPrecisions[instrument.AssetClass()]; found {
+		precision = assetClassPrecision
+	}
+
+	if instrument.IsPricePrecisionSet() {
+		precision.Price = instrument.PricePrecision()
+	}
+
+	if instrument.IsVolumePrecisionSet() {
+		precision.Volume = instrument.VolumePrecision()
+	}
+
+	return precision
+}
+
+// priceTableMigrate rebuilds the price table if its columns do not match
+// the expected precision and price fields. Missing tables are skipped.
+func (store *Store) priceTableMigrate(ctx context.Context, tableName string, precision PriceColumnPrecision, fields []string) error {
+	existingColumns, err := store.tableColumns(ctx, tableName)
+
+	if err != nil {
+		return err
+	}
+
+	if len(existingColumns) < 1 {
+		return nil
+	}
+
+	expectedColumns := store.priceTableColumns(precision, fields)
+
+	if !priceTableNeedsMigrating(existingColumns, expectedColumns) {
+		return nil
+	}
+
+	migrateTableName := tableName + "_migrate"
+
+	createSqls := []string{}
+
+	dropSql, err := sb.TableDropIfExistsSql(store.toQuerableContext(ctx), migrateTableName)
+
+	if err != nil {
+		return err
+	}
+
+	createSqls = append(createSqls, dropSql)
+
+	createSql := store.sqlTablePriceCreate(migrateTableName, precision, fields)
+
+	if createSql == "" {
+		return errors.New("trading store: failed to build sql to create table " + migrateTableName)
+	}
+
+	createSqls = append(createSqls, createSql)
+
+	// only the columns present in both tables are copied
+	copyColumns := []any{}
+
+	for _, expected := range expectedColumns {
+		_, found := lo.Find(existingColumns, func(existing sb.Column) bool {
+			return strings.EqualFold(existing.Name, expected.Name)
+		})
+
+		if found {
+			copyColumns = append(copyColumns, expected.Name)
+		}
+	}
+
+	copySql, _, err := goqu.Dialect(store.dbDriverName).
+		Insert(migrateTableName).
+		Cols(copyColumns...).
+		FromQuery(goqu.From(tableName).Select(copyColumns...)).
+		ToSQL()
+
+	if err != nil {
+		return err
+	}
+
+	replaceSqls := []string{}
+
+	dropSql, err = sb.TableDropSql(store.toQuerableContext(ctx), tableName)
+
+	if err != nil {
+		return err
+	}
+
+	replaceSqls = append(replaceSqls, dropSql)
+
+	renameSql, err := sb.NewBuilder(sb.DatabaseDriverName(store.db)).
+		TableRename(migrateTableName, tableName)
+
+	if err != nil {
+		return err
+	}
+
+	replaceSqls = append(replaceSqls, renameSql)
+
+	// a table created with another time storage format can not be copied
+	// with a single statement, its times are converted row by row
+	convertTime := priceTableTimeChanged(existingColumns, store.priceTableTimeColumn())
+
+	// the rebuild runs in a transaction, so a failed step does not leave the
+	// table dropped on SQLite and PostgreSQL, which have transactional DDL.
+	// MySQL commits each DDL statement implicitly, so there it is not atomic.
+	return store.withTx(ctx, func(queryableCtx database.QueryableContext) error {
+		for _, sqlStr := range createSqls {
+			store.logSql("migrate", sqlStr)
+
+			if _, err := database.Execute(queryableCtx, sqlStr); err != nil {
+				return err
+			}
+		}
+
+		var err error
+
+		if convertTime {
+			err = store.priceTableCopyRows(queryableCtx, tableName, migrateTableName, copyColumns)
+		} else {
+			store.logSql("migrate", copySql)
+
+			_, err = database.Execute(queryableCtx, copySql)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for _, sqlStr := range replaceSqls {
+			store.logSql("migrate", sqlStr)
+
+			if _, err := database.Execute(queryableCtx, sqlStr); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// priceTableCopyRows copies the rows of a price table into its rebuilt
+// table in batches, converting the times to the time storage format of the
+// store. Empty values are copied as NULL.
+func (store *Store) priceTableCopyRows(ctx database.QueryableContext, fromTableName string, toTableName string, columns []any) error {
+	lastID := ""
+
+	for {
+		q := goqu.Dialect(store.dbDriverName).
+			From(fromTableName).
+			Prepared(true).
+			Select(columns...).
+			Order(goqu.C(COLUMN_ID).Asc()).
+			Limit(priceTableCopyBatchSize)
+
+		if lastID != "" {
+			q = q.Where(goqu.C(COLUMN_ID).Gt(lastID))
+		}
+
+		sqlStr, sqlParams, errSql := q.ToSQL()
+
+		if errSql != nil {
+			return errSql
+		}
+
+		store.logSql("select", sqlStr, sqlParams...)
+
+		rows, err := database.SelectToMapString(ctx, sqlStr, sqlParams...)
+
+		if err != nil {
+			return err
+		}
+
+		if len(rows) < 1 {
+			return nil
+		}
+
+		records := make([]any, 0, len(rows))
+
+		for _, row := range rows {
+			t, err := priceTimeParseStored(row[COLUMN_TIME])
+
+			if err != nil {
+				return errors.New("price " + row[COLUMN_ID] + " time can not be converted: " + row[COLUMN_TIME])
+			}
+
+			record := goqu.Record{}
+
+			for column, value := range row {
+				record[column] = lo.Ternary[any](value == "", nil, value)
+			}
+
+			record[COLUMN_TIME] = store.priceTimeToStorage(t)
+
+			records = append(records, record)
+		}
+
+		sqlStr, sqlParams, errSql = goqu.Dialect(store.dbDriverName).
+			Insert(toTableName).
+			Prepared(true).
+			Rows(records...).
+			ToSQL()
+
+		if errSql != nil {
+			return errSql
+		}
+
+		store.logSql("migrate", sqlStr, sqlParams...)
+
+		if _, err := database.Execute(ctx, sqlStr, sqlParams...); err != nil {
+			return err
+		}
+
+		lastID = rows[len(rows)-1][COLUMN_ID]
+	}
+}
+
+// priceTableTimeChanged returns true if the time column of the existing
+// table has another type than the time column of the store, i.e. a DATETIME
+// for a store which stores the times as epoch milliseconds
+func priceTableTimeChanged(existingColumns []sb.Column, expected sb.Column) bool {
+	existing, found := lo.Find(existingColumns, func(existing sb.Column) bool {
+		return strings.EqualFold(existing.Name, COLUMN_TIME)
+	})
+
+	return found && !strings.EqualFold(existing.Type, expected.Type)
+}
+
+// priceTableNeedsMigrating returns true if an expected price, volume or
+// price field column is missing, or has a different type or number of
+// decimals, or if the time column has another time storage format
+func priceTableNeedsMigrating(existingColumns []sb.Column, expectedColumns []sb.Column) bool {
+	if priceTableTimeChanged(existingColumns, lo.FindOrElse(expectedColumns, sb.Column{}, func(column sb.Column) bool {
+		return column.Name == COLUMN_TIME
+	})) {
+		return true
+	}
+
+	for _, expected := range expectedColumns {
+		if expected.Name == COLUMN_ID || expected.Name == COLUMN_TIME {
+			continue
+		}
+
+		existing, found := lo.Find(existingColumns, func(existing sb.Column) bool {
+			return strings.EqualFold(existing.Name, expected.Name)
+		})
+
+		if !found {
+			return true
+		}
+
+		if !strings.EqualFold(existing.Type, expected.Type) || existing.Decimals != expected.Decimals {
+			return true
+		}
+	}
+
+	return false
+}
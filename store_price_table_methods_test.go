@@ -0,0 +1,335 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dracory/sb"
+	_ "modernc.org/sqlite"
+)
+
+func TestStorePriceTablesUseAssetClassPrecision(t *testing.T) {
+	db := initDB(":memory:")
+
+	store, err := NewStore(NewStoreOptions{
+		DB:                   db,
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+		PriceColumnPrecisions: map[string]PriceColumnPrecision{
+			ASSET_CLASS_CRYPTO: {Price: 12, Volume: 6},
+		},
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instruments := []InstrumentInterface{
+		NewInstrument().SetSymbol("PEPEUSDT").SetExchange("BINANCE").SetAssetClass(ASSET_CLASS_CRYPTO),
+		NewInstrument().SetSymbol("BTCUSDT").SetExchange("BINANCE").SetAssetClass(ASSET_CLASS_CRYPTO).SetPricePrecision(2),
+		NewInstrument().SetSymbol("AAPL").SetExchange(EXCHANGE_NASDAQ).SetAssetClass(ASSET_CLASS_STOCK).SetVolumePrecision(0),
+	}
+
+	for _, instrument := range instruments {
+		instrument.SetTimeframes([]string{TIMEFRAME_1_DAY})
+
+		if err := store.InstrumentCreate(ctx, instrument); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expected := map[string]PriceColumnPrecision{
+		"PEPEUSDT": {Price: 12, Volume: 6},
+		"BTCUSDT":  {Price: 2, Volume: 6},
+		"AAPL":     {Price: PRICE_PRECISION_DEFAULT, Volume: 0},
+	}
+
+	for _, instrument := range instruments {
+		tableName := store.(*Store).PriceTableName(instrument.Symbol(), instrument.Exchange(), TIMEFRAME_1_DAY)
+		columns, err := sb.TableColumns(store.(*Store).toQuerableContext(ctx), tableName, true)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		for _, column := range columns {
+			if column.Name == COLUMN_CLOSE && column.Decimals != expected[instrument.Symbol()].Price {
+				t.Fatal(instrument.Symbol(), "close MUST have", expected[instrument.Symbol()].Price, "decimals, got", column.Decimals)
+			}
+
+			if column.Name == COLUMN_VOLUME && column.Decimals != expected[instrument.Symbol()].Volume {
+				t.Fatal(instrument.Symbol(), "volume MUST have", expected[instrument.Symbol()].Volume, "decimals, got", column.Type, column.Decimals)
+			}
+		}
+	}
+
+	price := NewPrice().
+		SetTime("2024-01-02 00:00:00").
+		SetOpen("0.0000012345678").
+		SetHigh("0.0000013").
+		SetLow("0.0000012").
+		SetClose("0.0000012999").
+		SetVolume("1234.56789012")
+
+	if err := store.PriceCreate(ctx, "PEPEUSDT", "BINANCE", TIMEFRAME_1_DAY, price); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if price.Open() != "0.000001234568" || price.Volume() != "1234.56789" {
+		t.Fatal("PriceCreate MUST format with the asset class precision, got", price.Open(), price.Volume())
+	}
+}
+
+func TestStoreMigratePriceTables(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instrument := NewInstrument().
+		SetSymbol("ETHUSDT").
+		SetExchange("BINANCE").
+		SetAssetClass(ASSET_CLASS_CRYPTO).
+		SetTimeframes([]string{TIMEFRAME_1_HOUR})
+
+	if err := store.InstrumentCreate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	tableName := store.(*Store).PriceTableName("ETHUSDT", "BINANCE", TIMEFRAME_1_HOUR)
+
+	// A table created by an older version, with an integer volume
+	_, err = store.DB().Exec(`CREATE TABLE "` + tableName + `"("id" TEXT(40) PRIMARY KEY NOT NULL, "open" DECIMAL(20,8) NOT NULL, "high" DECIMAL(20,8) NOT NULL, "low" DECIMAL(20,8) NOT NULL, "close" DECIMAL(20,8) NOT NULL, "volume" INTEGER(20) NOT NULL, "time" DATETIME NOT NULL)`)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	_, err = store.DB().Exec(`INSERT INTO "` + tableName + `" VALUES ('P1', 2000.5, 2010, 1990, 2005.25, 12, '2024-01-02 00:00:00')`)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.MigratePriceTables(ctx, NewInstrumentQuery().SetSymbol("ETHUSDT")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	columns, err := sb.TableColumns(store.(*Store).toQuerableContext(ctx), tableName, true)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, column := range columns {
+		if column.Name == COLUMN_VOLUME && (column.Type != sb.COLUMN_TYPE_DECIMAL || column.Decimals != VOLUME_PRECISION_DEFAULT) {
+			t.Fatal("Volume column MUST be migrated to a decimal, got", column.Type, column.Decimals)
+		}
+	}
+
+	price, err := store.PriceFindByID(ctx, "ETHUSDT", "BINANCE", TIMEFRAME_1_HOUR, "P1")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if price == nil || price.CloseFloat() != 2005.25 || price.VolumeFloat() != 12 {
+		t.Fatal("Migrated table MUST keep the existing prices")
+	}
+
+	price.SetVolume("12.75")
+
+	if err := store.PriceUpdate(ctx, "ETHUSDT", "BINANCE", TIMEFRAME_1_HOUR, price); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// migrating an up to date table is a no-op
+	if err := store.MigratePriceTables(ctx, nil); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	price, err = store.PriceFindByID(ctx, "ETHUSDT", "BINANCE", TIMEFRAME_1_HOUR, "P1")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if price == nil || price.VolumeFloat() != 12.75 {
+		t.Fatal("Fractional volume MUST be kept after the migration")
+	}
+}
+
+func TestStoreMigratePriceTablesRollsBackAFailedRebuild(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instrument := NewInstrument().
+		SetSymbol("ETHUSDT").
+		SetExchange("BINANCE").
+		SetAssetClass(ASSET_CLASS_CRYPTO).
+		SetTimeframes([]string{TIMEFRAME_1_HOUR})
+
+	if err := store.InstrumentCreate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	tableName := store.(*Store).PriceTableName("ETHUSDT", "BINANCE", TIMEFRAME_1_HOUR)
+
+	// A table created by an older version, with a row the rebuilt table rejects
+	_, err = store.DB().Exec(`CREATE TABLE "` + tableName + `"("id" TEXT(40) PRIMARY KEY NOT NULL, "open" DECIMAL(20,8) NOT NULL, "high" DECIMAL(20,8) NOT NULL, "low" DECIMAL(20,8) NOT NULL, "close" DECIMAL(20,8) NOT NULL, "volume" INTEGER(20), "time" DATETIME NOT NULL)`)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	_, err = store.DB().Exec(`INSERT INTO "` + tableName + `" VALUES ('P1', 2000.5, 2010, 1990, 2005.25, 12, '2024-01-02 00:00:00'), ('P2', 2005.25, 2020, 2000, 2015, NULL, '2024-01-02 01:00:00')`)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.MigratePriceTables(ctx, nil); err == nil {
+		t.Fatal("MigratePriceTables MUST fail to copy a row without a volume")
+	}
+
+	columns, err := store.(*Store).tableColumns(ctx, tableName)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, column := range columns {
+		if column.Name == COLUMN_VOLUME && column.Type != sb.COLUMN_TYPE_INTEGER {
+			t.Fatal("A failed rebuild MUST keep the original table, got a volume column of type", column.Type)
+		}
+	}
+
+	count, err := store.PriceCount(ctx, "ETHUSDT", "BINANCE", TIMEFRAME_1_HOUR, NewPriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count != 2 {
+		t.Fatal("A failed rebuild MUST keep the existing prices, got", count)
+	}
+
+	migrateColumns, err := store.(*Store).tableColumns(ctx, tableName+"_migrate")
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(migrateColumns) > 0 {
+		t.Fatal("A failed rebuild MUST NOT leave the migrate table behind")
+	}
+}
+
+func TestPostgresColumnType(t *testing.T) {
+	expected := map[string]string{
+		"numeric":                     sb.COLUMN_TYPE_DECIMAL,
+		"bigint":                      sb.COLUMN_TYPE_INTEGER,
+		"integer":                     sb.COLUMN_TYPE_INTEGER,
+		"character varying":           sb.COLUMN_TYPE_STRING,
+		"text":                        sb.COLUMN_TYPE_TEXT,
+		"double precision":            sb.COLUMN_TYPE_FLOAT,
+		"timestamp without time zone": sb.COLUMN_TYPE_DATETIME,
+	}
+
+	for dataType, columnType := range expected {
+		if postgresColumnType(dataType) != columnType {
+			t.Fatal("Column type of", dataType, "MUST BE", columnType, ", found:", postgresColumnType(dataType))
+		}
+	}
+}
+
+func TestStoreMigratePriceTablesConvertsTimeStorage(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		DB:                   initDB(":memory:"),
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+		PriceTimeStorage:     PRICE_TIME_STORAGE_EPOCH_MILLIS,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instrument := NewInstrument().
+		SetSymbol("ETHUSDT").
+		SetExchange("BINANCE").
+		SetAssetClass(ASSET_CLASS_CRYPTO).
+		SetPriceFields([]string{COLUMN_VWAP}).
+		SetTimeframes([]string{TIMEFRAME_1_HOUR})
+
+	if err := store.InstrumentCreate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	tableName := store.(*Store).PriceTableName("ETHUSDT", "BINANCE", TIMEFRAME_1_HOUR)
+
+	// A table created with the datetime time storage
+	_, err = store.DB().Exec(`CREATE TABLE "` + tableName + `"("id" TEXT(40) PRIMARY KEY NOT NULL, "open" DECIMAL(20,8) NOT NULL, "high" DECIMAL(20,8) NOT NULL, "low" DECIMAL(20,8) NOT NULL, "close" DECIMAL(20,8) NOT NULL, "volume" DECIMAL(20,8) NOT NULL, "time" DATETIME NOT NULL, "vwap" DECIMAL(20,8))`)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	_, err = store.DB().Exec(`INSERT INTO "` + tableName + `" VALUES ('P1', 2000.5, 2010, 1990, 2005.25, 12, '2024-01-02 00:00:00', 2001), ('P2', 2005.25, 2020, 2000, 2015, 3, '2024-01-02 01:00:00', NULL)`)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.MigratePriceTables(ctx, nil); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	columns, err := store.(*Store).tableColumns(ctx, tableName)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, column := range columns {
+		if column.Name == COLUMN_TIME && column.Type != sb.COLUMN_TYPE_INTEGER {
+			t.Fatal("Time column MUST be migrated to epoch milliseconds, got", column.Type)
+		}
+	}
+
+	prices, err := store.PriceList(ctx, "ETHUSDT", "BINANCE", TIMEFRAME_1_HOUR, NewPriceQuery().
+		SetTimeGteT(time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC)))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 1 || prices[0].ID() != "P2" || !prices[0].TimeT().Equal(time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC)) {
+		t.Fatal("Migrated times MUST be queried as epoch milliseconds, got", len(prices), "prices")
+	}
+
+	if prices[0].VWAP() != "" || prices[0].CloseFloat() != 2015 {
+		t.Fatal("Migrated values MUST be kept, got", prices[0].Data())
+	}
+}
@@ -0,0 +1,75 @@
+package tradingstore
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+)
+
+// priceTimeToStorage converts the time to the value stored in the time
+// column of the price tables, in the time storage format of the store
+func (store *Store) priceTimeToStorage(t time.Time) any {
+	if store.priceTimeStorage == PRICE_TIME_STORAGE_EPOCH_MILLIS {
+		return t.UnixMilli()
+	}
+
+	return carbon.CreateFromStdTime(t, carbon.UTC).ToDateTimeString(carbon.UTC)
+}
+
+// priceTimeFromStorage converts a value read from the time column of the
+// price tables to the time format of the price objects. The drivers return
+// datetimes in different formats, i.e. "2024-01-02 15:04:05 +0000 UTC" or
+// "2024-01-02T15:04:05Z", so they are all normalized. Values which can not be
+// parsed are returned unchanged.
+func (store *Store) priceTimeFromStorage(value string) string {
+	if value == "" {
+		return value
+	}
+
+	if store.priceTimeStorage == PRICE_TIME_STORAGE_EPOCH_MILLIS {
+		millis, err := strconv.ParseInt(value, 10, 64)
+
+		if err != nil {
+			return value
+		}
+
+		return priceTimeFormat(time.UnixMilli(millis))
+	}
+
+	parsed := carbon.Parse(value, carbon.UTC)
+
+	if parsed.Error != nil {
+		return value
+	}
+
+	return priceTimeFormat(parsed.StdTime())
+}
+
+// priceTimeQueryValue converts a time of the price query options to the value
+// stored in the time column, so the comparison is the same for every driver
+func (store *Store) priceTimeQueryValue(value string) any {
+	return store.priceTimeToStorage(carbon.Parse(value, carbon.UTC).StdTime())
+}
+
+// priceTimeParseStored parses a value read from the time column of a price
+// table in either time storage format, as tables created before the time
+// storage format of the store changed hold the times in the other format
+func priceTimeParseStored(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("time is empty")
+	}
+
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(millis).UTC(), nil
+	}
+
+	parsed := carbon.Parse(value, carbon.UTC)
+
+	if parsed.Error != nil {
+		return time.Time{}, parsed.Error
+	}
+
+	return parsed.StdTime(), nil
+}
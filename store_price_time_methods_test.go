@@ -0,0 +1,179 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestPriceTimeT(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+
+	if err != nil {
+		t.Skip("timezone data not available:", err)
+	}
+
+	price := NewPrice().SetTimeT(time.Date(2024, 1, 2, 9, 30, 0, 500_000_000, newYork))
+
+	if price.Time() != "2024-01-02T14:30:00.5Z" {
+		t.Fatal("SetTimeT MUST store the time in UTC, got", price.Time())
+	}
+
+	if !price.TimeT().Equal(time.Date(2024, 1, 2, 14, 30, 0, 500_000_000, time.UTC)) || price.TimeT().Location() != time.UTC {
+		t.Fatal("TimeT MUST return the time in UTC, got", price.TimeT())
+	}
+
+	price.SetTime("2024-01-02 14:30:00")
+
+	if price.Time() != "2024-01-02T14:30:00Z" {
+		t.Fatal("SetTime MUST store the canonical format, got", price.Time())
+	}
+
+	if err := NewPriceQuery().SetTimeGte("not a time").Validate(); err == nil {
+		t.Fatal("Validate MUST reject an invalid time")
+	}
+}
+
+func TestStorePriceQueryTimeT(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	if err := store.InstrumentCreate(ctx, NewInstrument().SetSymbol("AAPL").SetExchange(EXCHANGE_NASDAQ).SetTimeframes([]string{TIMEFRAME_1_HOUR})); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for hour := 13; hour <= 16; hour++ {
+		price := NewPrice().
+			SetTimeT(time.Date(2024, 1, 2, hour, 0, 0, 0, time.UTC)).
+			SetOpen("1").SetHigh("1").SetLow("1").SetClose("1").SetVolume("1")
+
+		if err := store.PriceCreate(ctx, "AAPL", EXCHANGE_NASDAQ, TIMEFRAME_1_HOUR, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	// 09:00 to 10:00 in New York is 14:00 to 15:00 UTC
+	newYork := time.FixedZone("EST", -5*60*60)
+
+	prices, err := store.PriceList(ctx, "AAPL", EXCHANGE_NASDAQ, TIMEFRAME_1_HOUR, NewPriceQuery().
+		SetTimeGteT(time.Date(2024, 1, 2, 9, 0, 0, 0, newYork)).
+		SetTimeLteT(time.Date(2024, 1, 2, 10, 0, 0, 0, newYork)).
+		SetOrderBy(COLUMN_TIME).
+		SetOrderDirection("asc"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 {
+		t.Fatal("Expected 2 prices, got", len(prices))
+	}
+
+	if prices[0].Time() != "2024-01-02T14:00:00Z" || prices[1].Time() != "2024-01-02T15:00:00Z" {
+		t.Fatal("Prices MUST be read in the canonical format, got", prices[0].Time(), prices[1].Time())
+	}
+
+	price := prices[1]
+	price.SetTimeT(time.Date(2024, 1, 2, 12, 0, 0, 0, newYork))
+
+	if err := store.PriceUpdate(ctx, "AAPL", EXCHANGE_NASDAQ, TIMEFRAME_1_HOUR, price); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	prices, err = store.PriceList(ctx, "AAPL", EXCHANGE_NASDAQ, TIMEFRAME_1_HOUR, NewPriceQuery().
+		SetTimeT(time.Date(2024, 1, 2, 17, 0, 0, 0, time.UTC)))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 1 || prices[0].ID() != price.ID() {
+		t.Fatal("Updated time MUST be stored in the same format as created prices")
+	}
+}
+
+func TestStorePriceTimeStorageEpochMillis(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		DB:                   initDB(":memory:"),
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+		PriceTimeStorage:     PRICE_TIME_STORAGE_EPOCH_MILLIS,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	if err := store.InstrumentCreate(ctx, NewInstrument().SetSymbol("BTCUSDT").SetExchange("BINANCE").SetTimeframes([]string{TIMEFRAME_1_MINUTE})); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		price := NewPrice().
+			SetTimeT(start.Add(time.Duration(i) * 250 * time.Millisecond)).
+			SetOpen("1").SetHigh("1").SetLow("1").SetClose("1").SetVolume("1")
+
+		if err := store.PriceCreate(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	var stored int64
+
+	row := store.DB().QueryRow(`SELECT time FROM "` + store.(*Store).PriceTableName("BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE) + `" ORDER BY time DESC LIMIT 1`)
+
+	if err := row.Scan(&stored); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if stored != start.UnixMilli()+500 {
+		t.Fatal("Time MUST be stored as epoch milliseconds, got", stored)
+	}
+
+	prices, err := store.PriceList(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, NewPriceQuery().
+		SetTimeGteT(start.Add(250*time.Millisecond)).
+		SetOrderBy(COLUMN_TIME).
+		SetOrderDirection("asc"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 {
+		t.Fatal("Expected 2 prices, got", len(prices))
+	}
+
+	if prices[0].Time() != "2024-01-02T00:00:00.25Z" || !prices[1].TimeT().Equal(start.Add(500*time.Millisecond)) {
+		t.Fatal("Millisecond times MUST round trip, got", prices[0].Time(), prices[1].Time())
+	}
+
+	if _, err := NewStore(NewStoreOptions{
+		DB:                   initDB(":memory:"),
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		PriceTimeStorage:     "unix",
+	}); err == nil {
+		t.Fatal("NewStore MUST reject an unsupported PriceTimeStorage")
+	}
+}
@@ -0,0 +1,98 @@
+package tradingstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/dracory/database"
+	"github.com/dracory/sb"
+)
+
+// PriceTransform transforms the stored prices of the source timeframe into
+// Heikin-Ashi, Renko or range bars.
+//
+// If a target timeframe is given, the bars are stored under it, so they can be
+// listed like any other series. The target is a timeframe key of the
+// instrument, i.e. "1day_ha" or "renko_10", whose price table is created by
+// AutoMigratePrices. The bars are path dependent, so the stored bars at or
+// after the first transformed bar are replaced.
+//
+// Parameters:
+// - ctx: the context
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - sourceTimeframe: the timeframe to read the prices from
+// - targetTimeframe: the timeframe key to store the bars under, empty to not store them
+// - options: the bar options
+// - query: the query options for the source prices, nil for all the prices
+//
+// Returns:
+// - []PriceInterface: the bars
+// - error: if the options are not supported, or the prices could not be read
+// or the bars stored
+func (store *Store) PriceTransform(ctx context.Context, symbol string, exchange string, sourceTimeframe string, targetTimeframe string, options PriceTransformOptions, query PriceQueryInterface) ([]PriceInterface, error) {
+	if query == nil {
+		query = PriceQuery()
+	}
+
+	// the transforms require the prices in ascending time order, the query
+	// of the caller is left unchanged
+	query = priceQueryCopy(query).
+		SetOrderBy(COLUMN_TIME).
+		SetOrderDirection(sb.ASC)
+
+	prices, err := store.PriceList(ctx, symbol, exchange, sourceTimeframe, query)
+
+	if err != nil {
+		return []PriceInterface{}, err
+	}
+
+	bars, err := PriceTransform(prices, options)
+
+	if err != nil {
+		return []PriceInterface{}, err
+	}
+
+	if targetTimeframe == "" || len(bars) < 1 {
+		return bars, nil
+	}
+
+	err = store.priceDeleteFrom(ctx, symbol, exchange, targetTimeframe, bars[0].TimeT())
+
+	if err != nil {
+		return []PriceInterface{}, err
+	}
+
+	for _, bar := range bars {
+		if err := store.PriceCreate(ctx, symbol, exchange, targetTimeframe, bar); err != nil {
+			return []PriceInterface{}, err
+		}
+	}
+
+	return bars, nil
+}
+
+// priceDeleteFrom deletes the prices at or after the given time, and
+// invalidates the persisted indicator values from that time
+func (store *Store) priceDeleteFrom(ctx context.Context, symbol string, exchange string, timeframe string, from time.Time) error {
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Delete(store.PriceTableName(symbol, exchange, timeframe)).
+		Prepared(true).
+		Where(goqu.C(COLUMN_TIME).Gte(store.priceTimeToStorage(from))).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("delete", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return err
+	}
+
+	return store.indicatorInvalidate(ctx, symbol, exchange, timeframe, from)
+}
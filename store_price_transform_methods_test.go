@@ -0,0 +1,90 @@
+package tradingstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePriceTransform(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		DB:                   initDB(":memory:"),
+		PriceTableNamePrefix: "price_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	instrument := NewInstrument().
+		SetSymbol("AAPL").
+		SetExchange("NASDAQ").
+		SetPricePrecision(2).
+		SetTimeframes([]string{TIMEFRAME_1_DAY, "1day_ha"})
+
+	if err := store.InstrumentCreate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, price := range priceTransformTestPrices([][4]string{
+		{"10", "12", "9", "11"},
+		{"11", "14", "10", "13"},
+		{"13", "15", "12", "14"},
+	}) {
+		if err := store.PriceCreate(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_DAY, price); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	// stored twice, the second time MUST replace the bars
+	for i := 0; i < 2; i++ {
+		_, err := store.PriceTransform(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_DAY, "1day_ha", PriceTransformOptions{Type: BAR_TYPE_HEIKIN_ASHI}, nil)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	query := NewPriceQuery().SetOrderBy(COLUMN_TIME).SetOrderDirection("desc")
+
+	if _, err := store.PriceTransform(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_DAY, "", PriceTransformOptions{Type: BAR_TYPE_HEIKIN_ASHI}, query); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if query.OrderDirection() != "desc" {
+		t.Fatal("The query of the caller MUST NOT be changed, got:", query.OrderDirection())
+	}
+
+	bars, err := store.PriceList(ctx, "AAPL", "NASDAQ", "1day_ha", NewPriceQuery().SetOrderBy(COLUMN_TIME).SetOrderDirection("asc"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(bars) != 3 {
+		t.Fatal("Expected 3 stored Heikin-Ashi bars, got", len(bars))
+	}
+
+	if bars[1].OpenFloat() != 10.5 || bars[1].CloseFloat() != 12 {
+		t.Fatal("Unexpected stored bar:", bars[1].Data())
+	}
+
+	// not stored without a target timeframe
+	renko, err := store.PriceTransform(ctx, "AAPL", "NASDAQ", TIMEFRAME_1_DAY, "", PriceTransformOptions{Type: BAR_TYPE_RENKO, BrickSize: NewDecimal(1, 0)}, nil)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(renko) != 3 {
+		t.Fatal("Expected 3 bricks, got", len(renko))
+	}
+}
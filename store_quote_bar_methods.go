@@ -0,0 +1,116 @@
+package tradingstore
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+)
+
+// PriceBuildFromQuotes builds bid, ask or mid OHLC bars from the stored quotes
+// of an instrument, and writes them into the price table of the timeframe
+// through PriceCreate and PriceUpdate, like PriceBuildFromTicks. A bar which
+// already exists at the same time is updated. The time range queried is
+// widened to whole buckets, so a rebuild never writes a partial bar over a
+// complete one.
+//
+// The bars default to the timeframe, aligned to the exchange calendar of the
+// instrument. The bid and ask close fields default to those enabled for the
+// instrument.
+//
+// Parameters:
+// - ctx: the context
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - timeframe: the timeframe of the price table to write the bars to
+// - options: the bar options
+// - query: the query options of the quotes, nil for all the quotes
+//
+// Returns:
+// - []PriceInterface: the bars written
+// - error: if the options are not supported, or the quotes could not be read
+// or the bars written
+func (store *Store) PriceBuildFromQuotes(ctx context.Context, symbol string, exchange string, timeframe string, options QuoteBarOptions, query QuoteQueryInterface) ([]PriceInterface, error) {
+	if query == nil {
+		query = NewQuoteQuery()
+	}
+
+	query = quoteQueryCopy(query)
+
+	instrument, err := store.instrumentFindBySymbol(ctx, symbol, exchange)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Timeframe == "" {
+		options.Timeframe = timeframe
+	}
+
+	if options.BucketOptions == (TimeframeBucketOptions{}) {
+		options.BucketOptions, err = store.priceBucketOptions(ctx, instrument)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	start, end, err := priceBuildBucketRange(options.Timeframe, options.BucketOptions, query.TimeGte(), query.TimeLte())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !start.IsZero() {
+		query.SetTimeGteT(start)
+	}
+
+	if !end.IsZero() {
+		query.SetTimeLteT(end)
+	}
+
+	if options.Fields == nil && instrument != nil {
+		options.Fields = lo.Intersect(instrument.PriceFields(), quoteBarFields)
+	}
+
+	builder, err := NewQuoteBarBuilder(options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := store.QuoteIterate(ctx, symbol, exchange, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer iterator.Close()
+
+	bars := []PriceInterface{}
+
+	for iterator.Next() {
+		completed, err := builder.Add(iterator.Quote())
+
+		if err != nil {
+			return nil, err
+		}
+
+		bars = append(bars, completed...)
+	}
+
+	if iterator.Err() != nil {
+		return nil, iterator.Err()
+	}
+
+	if bar := builder.Flush(); bar != nil {
+		bars = append(bars, bar)
+	}
+
+	for _, bar := range bars {
+		if err := store.priceUpsert(ctx, symbol, exchange, timeframe, bar); err != nil {
+			return nil, err
+		}
+	}
+
+	return bars, nil
+}
@@ -0,0 +1,377 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/dracory/database"
+	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+)
+
+// quoteCreateManyBatchSize is the number of quotes inserted per statement
+const quoteCreateManyBatchSize = 500
+
+// QuoteCount returns the number of quotes based on the given query options
+func (store *Store) QuoteCount(ctx context.Context, symbol string, exchange string, options QuoteQueryInterface) (int64, error) {
+	options.SetCountOnly(true)
+
+	q, _, err := store.quoteQuery(symbol, exchange, options)
+
+	if err != nil {
+		return -1, err
+	}
+
+	sqlStr, sqlParams, errSql := q.Prepared(true).
+		Limit(1).
+		Select(goqu.COUNT(goqu.Star()).As("count")).
+		ToSQL()
+
+	if errSql != nil {
+		return -1, errSql
+	}
+
+	store.logSql("count", sqlStr, sqlParams...)
+
+	mapped, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return -1, err
+	}
+
+	if len(mapped) < 1 {
+		return -1, nil
+	}
+
+	return strconv.ParseInt(mapped[0]["count"], 10, 64)
+}
+
+// QuoteCreate creates a new quote
+func (store *Store) QuoteCreate(ctx context.Context, symbol string, exchange string, quote QuoteInterface) error {
+	if quote == nil {
+		return errors.New("quote is nil")
+	}
+
+	return store.QuoteCreateMany(ctx, symbol, exchange, []QuoteInterface{quote})
+}
+
+// QuoteCreateMany appends quotes in bulk. The quotes are inserted with multi-row
+// inserts of up to 500 quotes, in a single transaction unless the context
+// already carries one, so either all the quotes are stored or none.
+//
+// Parameters:
+// - ctx: the context, optionally a database.QueryableContext with a transaction
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - quotes: the quotes to store
+//
+// Returns:
+// - error: if a quote is invalid or the insert fails
+func (store *Store) QuoteCreateMany(ctx context.Context, symbol string, exchange string, quotes []QuoteInterface) error {
+	if err := store.quoteStorageCheck(); err != nil {
+		return err
+	}
+
+	records := make([]any, 0, len(quotes))
+
+	for _, quote := range quotes {
+		if quote == nil {
+			return errors.New("quote is nil")
+		}
+
+		if err := quoteValidate(quote.Data(), false); err != nil {
+			return err
+		}
+
+		records = append(records, store.quoteRecord(quote.Data()))
+	}
+
+	err := store.recordsCreateMany(ctx, store.QuoteTableName(symbol, exchange), records, quoteCreateManyBatchSize)
+
+	if err != nil {
+		return err
+	}
+
+	for _, quote := range quotes {
+		quote.MarkAsNotDirty()
+	}
+
+	return nil
+}
+
+// QuoteDelete deletes a quote
+func (store *Store) QuoteDelete(ctx context.Context, symbol string, exchange string, quote QuoteInterface) error {
+	if quote == nil {
+		return errors.New("quote is nil")
+	}
+
+	return store.QuoteDeleteByID(ctx, symbol, exchange, quote.ID())
+}
+
+// QuoteDeleteByID deletes a quote by its ID
+func (store *Store) QuoteDeleteByID(ctx context.Context, symbol string, exchange string, id string) error {
+	if id == "" {
+		return errors.New("quote id is empty")
+	}
+
+	if err := store.quoteStorageCheck(); err != nil {
+		return err
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Delete(store.QuoteTableName(symbol, exchange)).
+		Prepared(true).
+		Where(goqu.C(COLUMN_ID).Eq(id)).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("delete", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	return err
+}
+
+// QuoteExists returns true if a quote exists based on the given query options
+func (store *Store) QuoteExists(ctx context.Context, symbol string, exchange string, options QuoteQueryInterface) (bool, error) {
+	count, err := store.QuoteCount(ctx, symbol, exchange, options)
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// QuoteFindByID returns a quote by its ID
+func (store *Store) QuoteFindByID(ctx context.Context, symbol string, exchange string, quoteID string) (QuoteInterface, error) {
+	if quoteID == "" {
+		return nil, errors.New("quote id is empty")
+	}
+
+	list, err := store.QuoteList(ctx, symbol, exchange, NewQuoteQuery().SetID(quoteID).SetLimit(1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return nil, nil
+}
+
+// QuoteIterate returns an iterator over the quotes matching the query options,
+// in ascending time order. The quotes are loaded in batches, so the iterator
+// can be used to stream days of quotes which do not fit in memory.
+//
+// The limit of the options caps the total number of quotes, offsets and
+// custom ordering are not supported.
+func (store *Store) QuoteIterate(ctx context.Context, symbol string, exchange string, options QuoteQueryInterface) (QuoteIteratorInterface, error) {
+	if options == nil {
+		return nil, errors.New("quote options is nil")
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := store.quoteStorageCheck(); err != nil {
+		return nil, err
+	}
+
+	if err := keysetIteratorOptionsCheck("quote", options); err != nil {
+		return nil, err
+	}
+
+	remaining := -1
+
+	if options.IsLimitSet() {
+		remaining = options.Limit()
+	}
+
+	load := func(timeGte string, limit int) ([]QuoteInterface, error) {
+		query := quoteQueryCopy(options).
+			SetCountOnly(false).
+			SetOrderBy(COLUMN_TIME).
+			SetOrderDirection("asc").
+			SetLimit(limit)
+
+		if timeGte != "" {
+			query.SetTimeGte(timeGte)
+		}
+
+		return store.QuoteList(ctx, symbol, exchange, query)
+	}
+
+	return &quoteIterator{newKeysetIterator(quoteIteratorBatchSize, remaining, load)}, nil
+}
+
+// QuoteList returns a list of quotes based on the given query options,
+// in ascending time order unless an order is set
+func (store *Store) QuoteList(ctx context.Context, symbol string, exchange string, options QuoteQueryInterface) ([]QuoteInterface, error) {
+	q, columns, err := store.quoteQuery(symbol, exchange, options)
+
+	if err != nil {
+		return []QuoteInterface{}, err
+	}
+
+	sqlStr, sqlParams, errSql := q.Prepared(true).Select(columns...).ToSQL()
+
+	if errSql != nil {
+		return []QuoteInterface{}, errSql
+	}
+
+	store.logSql("list", sqlStr, sqlParams...)
+
+	modelMaps, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return []QuoteInterface{}, err
+	}
+
+	list := []QuoteInterface{}
+
+	lo.ForEach(modelMaps, func(modelMap map[string]string, index int) {
+		if value, found := modelMap[COLUMN_TIME]; found {
+			modelMap[COLUMN_TIME] = tickTimeFromStorage(value)
+		}
+
+		list = append(list, NewQuoteFromExistingData(modelMap))
+	})
+
+	return list, nil
+}
+
+// QuoteUpdate updates a quote
+func (store *Store) QuoteUpdate(ctx context.Context, symbol string, exchange string, quote QuoteInterface) error {
+	if quote == nil {
+		return errors.New("quote is nil")
+	}
+
+	if err := store.quoteStorageCheck(); err != nil {
+		return err
+	}
+
+	dataChanged := quote.DataChanged()
+
+	delete(dataChanged, COLUMN_ID) // ID is not updateable
+
+	if len(dataChanged) < 1 {
+		return nil
+	}
+
+	if err := quoteValidate(dataChanged, true); err != nil {
+		return err
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Update(store.QuoteTableName(symbol, exchange)).
+		Prepared(true).
+		Set(store.quoteRecord(dataChanged)).
+		Where(goqu.C(COLUMN_ID).Eq(quote.ID())).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("update", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return err
+	}
+
+	quote.MarkAsNotDirty()
+
+	return nil
+}
+
+// quoteQuery builds the select of the quotes matching the query options
+func (store *Store) quoteQuery(symbol string, exchange string, options QuoteQueryInterface) (selectDataset *goqu.SelectDataset, columns []any, err error) {
+	if options == nil {
+		return nil, nil, errors.New("quote options is nil")
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := store.quoteStorageCheck(); err != nil {
+		return nil, nil, err
+	}
+
+	q, columns := store.timeRangeSelect(store.QuoteTableName(symbol, exchange), options)
+
+	return q, columns, nil
+}
+
+// quoteRecord converts the data of a quote to a record of the quote table,
+// with the time as microseconds since the Unix epoch
+func (store *Store) quoteRecord(data map[string]string) goqu.Record {
+	record := goqu.Record{}
+
+	for column, value := range data {
+		record[column] = value
+	}
+
+	if value, found := data[COLUMN_TIME]; found {
+		record[COLUMN_TIME] = tickTimeQueryValue(value)
+	}
+
+	// unknown sizes are stored as NULL
+	for _, column := range []string{COLUMN_BID_SIZE, COLUMN_ASK_SIZE} {
+		if value, found := data[column]; found && value == "" {
+			record[column] = nil
+		}
+	}
+
+	return record
+}
+
+// quoteStorageCheck returns an error if quote storage is disabled
+func (store *Store) quoteStorageCheck() error {
+	if store.quoteTableNamePrefix == "" {
+		return errors.New("trading store: quote storage is disabled, QuoteTableNamePrefix is not set")
+	}
+
+	return nil
+}
+
+// quoteValidate checks the time, bid, ask and sizes of the quote data.
+// Partial data, i.e. the changed values of an update, is checked only
+// for the values it contains
+func quoteValidate(data map[string]string, partial bool) error {
+	for _, column := range []string{COLUMN_TIME, COLUMN_BID, COLUMN_ASK} {
+		if _, found := data[column]; !found && !partial {
+			return errors.New("quote " + column + " is empty")
+		}
+	}
+
+	if value, found := data[COLUMN_TIME]; found && carbon.Parse(value, carbon.UTC).Error != nil {
+		return errors.New("quote time is not a valid time: " + value)
+	}
+
+	for _, column := range []string{COLUMN_BID, COLUMN_ASK, COLUMN_BID_SIZE, COLUMN_ASK_SIZE} {
+		value, found := data[column]
+
+		// the sizes are optional
+		if !found || (value == "" && (column == COLUMN_BID_SIZE || column == COLUMN_ASK_SIZE)) {
+			continue
+		}
+
+		if _, err := NewDecimalFromString(value); err != nil {
+			return errors.New("quote " + column + " must be a number: " + value)
+		}
+	}
+
+	return nil
+}
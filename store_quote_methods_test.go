@@ -0,0 +1,260 @@
+package tradingstore
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func initQuoteStore(t *testing.T) StoreInterface {
+	store, err := NewStore(NewStoreOptions{
+		DB:                   initDB(":memory:"),
+		PriceTableNamePrefix: "price_",
+		QuoteTableNamePrefix: "quote_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	instrument := NewInstrument().
+		SetSymbol("EURUSD").
+		SetExchange("FOREX").
+		SetPricePrecision(5).
+		SetVolumePrecision(2).
+		SetPriceFields([]string{COLUMN_BID_CLOSE, COLUMN_ASK_CLOSE}).
+		SetTimeframes([]string{TIMEFRAME_1_MINUTE})
+
+	if err := store.InstrumentCreate(context.Background(), instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(context.Background()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestStoreQuoteCreateAndList(t *testing.T) {
+	store := initQuoteStore(t)
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	quote := NewQuote().
+		SetTimeT(start).
+		SetBid("1.10001").
+		SetAsk("1.10004").
+		SetBidSize("1000000")
+
+	if err := store.QuoteCreate(ctx, "EURUSD", "FOREX", quote); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.QuoteFindByID(ctx, "EURUSD", "FOREX", quote.ID())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if found == nil || found.Time() != quote.Time() || found.BidFloat() != 1.10001 || found.AskFloat() != 1.10004 || found.BidSizeFloat() != 1000000 || found.AskSize() != "" {
+		t.Fatal("Stored quote MUST round trip, got", found)
+	}
+
+	if !found.Spread().Equal(NewDecimal(3, 5)) {
+		t.Fatal("Expected spread 0.00003, got", found.Spread().String())
+	}
+
+	if !found.Mid().Equal(NewDecimal(1100025, 6)) {
+		t.Fatal("Expected mid 1.100025, got", found.Mid().String())
+	}
+
+	if err := store.QuoteCreate(ctx, "EURUSD", "FOREX", NewQuote().SetTimeT(start).SetBid("1.1")); err == nil {
+		t.Fatal("QuoteCreate MUST reject a quote without an ask")
+	}
+
+	if err := store.QuoteCreate(ctx, "EURUSD", "FOREX", NewQuote().SetTimeT(start).SetBid("1.1").SetAsk("x")); err == nil {
+		t.Fatal("QuoteCreate MUST reject an ask which is not a number")
+	}
+}
+
+func TestStoreQuoteCreateManyAndIterate(t *testing.T) {
+	store := initQuoteStore(t)
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	quotes := []QuoteInterface{}
+
+	for i := 0; i < 1500; i++ {
+		quotes = append(quotes, NewQuote().
+			SetTimeT(start.Add(time.Duration(i)*time.Millisecond)).
+			SetBid("1.1000"+strconv.Itoa(i%10)).
+			SetAsk("1.1001"+strconv.Itoa(i%10)))
+	}
+
+	if err := store.QuoteCreateMany(ctx, "EURUSD", "FOREX", quotes); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	iterator, err := store.QuoteIterate(ctx, "EURUSD", "FOREX", NewQuoteQuery().
+		SetTimeGteT(start.Add(100*time.Millisecond)))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	defer iterator.Close()
+
+	count := 0
+	previous := time.Time{}
+
+	for iterator.Next() {
+		if iterator.Quote().TimeT().Before(previous) {
+			t.Fatal("Quotes MUST be iterated in ascending time order")
+		}
+
+		previous = iterator.Quote().TimeT()
+		count++
+	}
+
+	if iterator.Err() != nil {
+		t.Fatal("unexpected error:", iterator.Err())
+	}
+
+	if count != 1400 {
+		t.Fatal("Expected 1400 quotes, got", count)
+	}
+
+	byID, err := store.QuoteIterate(ctx, "EURUSD", "FOREX", NewQuoteQuery().SetID(quotes[5].ID()))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	defer byID.Close()
+
+	ids := []string{}
+
+	for byID.Next() {
+		ids = append(ids, byID.Quote().ID())
+	}
+
+	if byID.Err() != nil {
+		t.Fatal("unexpected error:", byID.Err())
+	}
+
+	if len(ids) != 1 || ids[0] != quotes[5].ID() {
+		t.Fatal("The iterator MUST filter by the id of the query, got", ids)
+	}
+}
+
+func TestStorePriceBuildFromQuotes(t *testing.T) {
+	store := initQuoteStore(t)
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	quotes := []QuoteInterface{
+		NewQuote().SetTimeT(start.Add(5 * time.Second)).SetBid("1.10000").SetAsk("1.10002"),
+		NewQuote().SetTimeT(start.Add(20 * time.Second)).SetBid("1.10010").SetAsk("1.10014"),
+		NewQuote().SetTimeT(start.Add(40 * time.Second)).SetBid("1.09990").SetAsk("1.09992"),
+		NewQuote().SetTimeT(start.Add(70 * time.Second)).SetBid("1.10020").SetAsk("1.10022"),
+	}
+
+	if err := store.QuoteCreateMany(ctx, "EURUSD", "FOREX", quotes); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		bars, err := store.PriceBuildFromQuotes(ctx, "EURUSD", "FOREX", TIMEFRAME_1_MINUTE, QuoteBarOptions{Source: QUOTE_BAR_SOURCE_MID}, nil)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if len(bars) != 2 {
+			t.Fatal("Expected 2 bars, got", len(bars))
+		}
+	}
+
+	prices, err := store.PriceList(ctx, "EURUSD", "FOREX", TIMEFRAME_1_MINUTE, NewPriceQuery().SetOrderBy(COLUMN_TIME).SetOrderDirection("asc"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 {
+		t.Fatal("Rebuilding MUST update the existing bars, got", len(prices))
+	}
+
+	first := prices[0]
+
+	if first.OpenFloat() != 1.10001 || first.HighFloat() != 1.10012 || first.LowFloat() != 1.09991 || first.CloseFloat() != 1.09991 || first.VolumeFloat() != 3 {
+		t.Fatal("Unexpected mid bar:", first.Data())
+	}
+
+	if first.BidCloseFloat() != 1.0999 || first.AskCloseFloat() != 1.09992 {
+		t.Fatal("Bid and ask close MUST be the last quote of the bar, got", first.BidClose(), first.AskClose())
+	}
+
+	// a rebuild starting mid-bucket MUST NOT overwrite the bar with a partial one
+	query := NewQuoteQuery().SetTimeGteT(start.Add(30 * time.Second))
+	timeGte := query.TimeGte()
+
+	_, err = store.PriceBuildFromQuotes(ctx, "EURUSD", "FOREX", TIMEFRAME_1_MINUTE, QuoteBarOptions{Source: QUOTE_BAR_SOURCE_MID}, query)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	prices, err = store.PriceList(ctx, "EURUSD", "FOREX", TIMEFRAME_1_MINUTE, NewPriceQuery().SetOrderBy(COLUMN_TIME).SetOrderDirection("asc"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 || prices[0].OpenFloat() != 1.10001 || prices[0].VolumeFloat() != 3 {
+		t.Fatal("A rebuild starting mid-bucket MUST keep the complete first minute bar, got:", prices[0].Data())
+	}
+
+	if query.TimeGte() != timeGte {
+		t.Fatal("The query of the caller MUST NOT be changed, got:", query.TimeGte())
+	}
+
+	// a rebuild ending mid-bucket MUST NOT overwrite the bar with a partial one
+	_, err = store.PriceBuildFromQuotes(ctx, "EURUSD", "FOREX", TIMEFRAME_1_MINUTE, QuoteBarOptions{Source: QUOTE_BAR_SOURCE_MID}, NewQuoteQuery().
+		SetTimeLteT(start.Add(10*time.Second)))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	prices, err = store.PriceList(ctx, "EURUSD", "FOREX", TIMEFRAME_1_MINUTE, NewPriceQuery().SetOrderBy(COLUMN_TIME).SetOrderDirection("asc"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(prices) != 2 || prices[0].CloseFloat() != 1.09991 || prices[0].VolumeFloat() != 3 {
+		t.Fatal("A rebuild ending mid-bucket MUST keep the complete first minute bar, got:", prices[0].Data())
+	}
+
+	if _, err := QuoteAggregate(quotes, QuoteBarOptions{Source: "LAST", Timeframe: TIMEFRAME_1_MINUTE}); err == nil {
+		t.Fatal("QuoteAggregate MUST reject an unsupported source")
+	}
+
+	bids, err := QuoteAggregate(quotes, QuoteBarOptions{Source: QUOTE_BAR_SOURCE_BID, Timeframe: TIMEFRAME_1_MINUTE})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if bids[0].High() != "1.10010" || bids[0].Low() != "1.09990" {
+		t.Fatal("Unexpected bid bar:", bids[0].Data())
+	}
+}
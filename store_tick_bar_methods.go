@@ -0,0 +1,240 @@
+package tradingstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/dracory/sb"
+	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+)
+
+// PriceBuildFromTicks builds bars from the stored ticks of an instrument, and
+// writes them into the price table of the timeframe through PriceCreate and
+// PriceUpdate, so they are formatted, rolled up and invalidate the persisted
+// indicators like any other price.
+//
+// A time bar which already exists at the same time is updated, so a range of
+// ticks can be built again. The time range queried is widened to whole
+// buckets, so a rebuild never writes a partial bar over a complete one.
+//
+// Tick, volume and dollar bars are path dependent: a rebuild starts at the
+// stored bar holding the first tick queried, and the stored bars at or after
+// the first bar built are replaced. The last bar is written even if it is
+// not complete yet.
+//
+// Time bars default to the timeframe, aligned to the exchange calendar of
+// the instrument. The extended price fields default to the fields enabled
+// for the instrument which can be built from ticks.
+//
+// Parameters:
+// - ctx: the context
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - timeframe: the timeframe of the price table to write the bars to,
+// i.e. TIMEFRAME_1_MINUTE or a custom timeframe such as "1000tick"
+// - options: the bar options
+// - query: the query options of the ticks, nil for all the ticks
+//
+// Returns:
+// - []PriceInterface: the bars written
+// - error: if the options are not supported, or the ticks could not be read
+// or the bars written
+func (store *Store) PriceBuildFromTicks(ctx context.Context, symbol string, exchange string, timeframe string, options TickBarOptions, query TickQueryInterface) ([]PriceInterface, error) {
+	if query == nil {
+		query = NewTickQuery()
+	}
+
+	query = tickQueryCopy(query)
+
+	instrument, err := store.instrumentFindBySymbol(ctx, symbol, exchange)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Type == BAR_TYPE_TIME && options.Timeframe == "" {
+		options.Timeframe = timeframe
+	}
+
+	if options.Type == BAR_TYPE_TIME && options.BucketOptions == (TimeframeBucketOptions{}) {
+		options.BucketOptions, err = store.priceBucketOptions(ctx, instrument)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.Type == BAR_TYPE_TIME {
+		start, end, err := priceBuildBucketRange(options.Timeframe, options.BucketOptions, query.TimeGte(), query.TimeLte())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !start.IsZero() {
+			query.SetTimeGteT(start)
+		}
+
+		if !end.IsZero() {
+			query.SetTimeLteT(end)
+		}
+	} else if query.IsTimeGteSet() {
+		start, err := store.priceBuildBarStart(ctx, symbol, exchange, timeframe, carbon.Parse(query.TimeGte(), carbon.UTC).StdTime())
+
+		if err != nil {
+			return nil, err
+		}
+
+		query.SetTimeGteT(start)
+	}
+
+	if options.Fields == nil && instrument != nil {
+		options.Fields = lo.Intersect(instrument.PriceFields(), tickBarFields)
+	}
+
+	builder, err := NewTickBarBuilder(options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := store.TickIterate(ctx, symbol, exchange, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer iterator.Close()
+
+	bars := []PriceInterface{}
+
+	for iterator.Next() {
+		completed, err := builder.Add(iterator.Tick())
+
+		if err != nil {
+			return nil, err
+		}
+
+		bars = append(bars, completed...)
+	}
+
+	if iterator.Err() != nil {
+		return nil, iterator.Err()
+	}
+
+	if bar := builder.Flush(); bar != nil {
+		bars = append(bars, bar)
+	}
+
+	if options.Type == BAR_TYPE_TIME {
+		for _, bar := range bars {
+			if err := store.priceUpsert(ctx, symbol, exchange, timeframe, bar); err != nil {
+				return nil, err
+			}
+		}
+
+		return bars, nil
+	}
+
+	if len(bars) < 1 {
+		return bars, nil
+	}
+
+	err = store.priceDeleteFrom(ctx, symbol, exchange, timeframe, bars[0].TimeT())
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bar := range bars {
+		if err := store.PriceCreate(ctx, symbol, exchange, timeframe, bar); err != nil {
+			return nil, err
+		}
+	}
+
+	return bars, nil
+}
+
+// priceBuildBucketRange returns the time range of a rebuild of time bars,
+// widened to whole buckets of the timeframe, as a rebuild starting or ending
+// mid-bucket would overwrite the stored bar of the bucket with a partial one.
+// The end is one microsecond before the end of its bucket, the precision
+// ticks and quotes are stored with. The bounds which are not set are zero.
+func priceBuildBucketRange(timeframe string, options TimeframeBucketOptions, timeGte string, timeLte string) (start time.Time, end time.Time, err error) {
+	if timeGte != "" {
+		start, err = TimeframeBucketStartWithOptions(timeframe, carbon.Parse(timeGte, carbon.UTC).StdTime(), options)
+
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	if timeLte != "" {
+		bucketStart, err := TimeframeBucketStartWithOptions(timeframe, carbon.Parse(timeLte, carbon.UTC).StdTime(), options)
+
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+
+		bucketEnd, err := TimeframeBucketEndWithOptions(timeframe, bucketStart, options)
+
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+
+		end = bucketEnd.Add(-time.Microsecond)
+	}
+
+	return start, end, nil
+}
+
+// priceBuildBarStart returns the time of the stored bar holding the tick at
+// the given time, so a rebuild of path dependent bars starts where the bar
+// started, or the time itself if there is no stored bar before it
+func (store *Store) priceBuildBarStart(ctx context.Context, symbol string, exchange string, timeframe string, t time.Time) (time.Time, error) {
+	list, err := store.PriceList(ctx, symbol, exchange, timeframe, NewPriceQuery().
+		SetTimeLteT(t).
+		SetColumns([]string{COLUMN_ID, COLUMN_TIME}).
+		SetOrderBy(COLUMN_TIME).
+		SetOrderDirection(sb.DESC).
+		SetLimit(1))
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(list) < 1 {
+		return t, nil
+	}
+
+	return list[0].TimeT(), nil
+}
+
+// priceUpsert updates the stored price at the time of the bar, or creates
+// the bar if there is none
+func (store *Store) priceUpsert(ctx context.Context, symbol string, exchange string, timeframe string, bar PriceInterface) error {
+	var existing PriceInterface
+
+	list, err := store.PriceList(ctx, symbol, exchange, timeframe, NewPriceQuery().
+		SetTime(bar.Time()).
+		SetColumns([]string{COLUMN_ID}).
+		SetLimit(1))
+
+	if err != nil {
+		return err
+	}
+
+	if len(list) > 0 {
+		existing = list[0]
+	}
+
+	if existing == nil {
+		return store.PriceCreate(ctx, symbol, exchange, timeframe, bar)
+	}
+
+	// all the values of the bar are changed, so they replace the stored values
+	bar.SetID(existing.ID())
+
+	return store.PriceUpdate(ctx, symbol, exchange, timeframe, bar)
+}
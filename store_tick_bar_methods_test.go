@@ -0,0 +1,159 @@
+package tradingstore
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestStorePriceBuildFromTicks(t *testing.T) {
+	store := initTickStore(t)
+	ctx := context.Background()
+
+	instruments, err := store.InstrumentList(ctx, InstrumentQuery().SetSymbol("BTCUSDT"))
+
+	if err != nil || len(instruments) != 1 {
+		t.Fatal("unexpected error:", err)
+	}
+
+	instrument := instruments[0]
+
+	instrument.SetTimeframes([]string{TIMEFRAME_1_MINUTE, "tick_3"}).
+		SetPriceFields([]string{COLUMN_TRADES, COLUMN_VWAP})
+
+	if err := store.InstrumentUpdate(ctx, instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	ticks := []TickInterface{}
+
+	for i := 0; i < 10; i++ {
+		ticks = append(ticks, NewTick().
+			SetTimeT(start.Add(time.Duration(i*15)*time.Second)).
+			SetPrice(strconv.Itoa(100+i)).
+			SetSize("1"))
+	}
+
+	if err := store.TickCreateMany(ctx, "BTCUSDT", "BINANCE", ticks); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// built twice, the second build MUST update the same bars
+	for i := 0; i < 2; i++ {
+		bars, err := store.PriceBuildFromTicks(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, TickBarOptions{Type: BAR_TYPE_TIME}, nil)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if len(bars) != 3 {
+			t.Fatal("Expected 3 minute bars, got", len(bars))
+		}
+
+		_, err = store.PriceBuildFromTicks(ctx, "BTCUSDT", "BINANCE", "tick_3", TickBarOptions{Type: BAR_TYPE_TICK, Threshold: NewDecimal(3, 0)}, nil)
+
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	minutes, err := store.PriceList(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, NewPriceQuery().SetOrderBy(COLUMN_TIME).SetOrderDirection("asc"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(minutes) != 3 {
+		t.Fatal("Expected 3 stored minute bars, got", len(minutes))
+	}
+
+	if minutes[0].OpenFloat() != 100 || minutes[0].CloseFloat() != 103 || minutes[0].VolumeFloat() != 4 || minutes[0].TradesInt() != 4 {
+		t.Fatal("Unexpected first minute bar:", minutes[0].Data())
+	}
+
+	if minutes[0].VWAPFloat() != 101.5 {
+		t.Fatal("Expected VWAP 101.5, got", minutes[0].VWAP())
+	}
+
+	// a rebuild starting mid-bucket MUST NOT overwrite the bar with a partial one
+	_, err = store.PriceBuildFromTicks(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, TickBarOptions{Type: BAR_TYPE_TIME}, NewTickQuery().
+		SetTimeGteT(start.Add(30*time.Second)))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	minutes, err = store.PriceList(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, NewPriceQuery().SetOrderBy(COLUMN_TIME).SetOrderDirection("asc"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(minutes) != 3 || minutes[0].OpenFloat() != 100 || minutes[0].VolumeFloat() != 4 {
+		t.Fatal("A rebuild starting mid-bucket MUST keep the complete first minute bar, got:", minutes[0].Data())
+	}
+
+	tickBars, err := store.PriceCount(ctx, "BTCUSDT", "BINANCE", "tick_3", NewPriceQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if tickBars != 4 {
+		t.Fatal("Expected 4 stored tick bars, got", tickBars)
+	}
+
+	// a rebuild ending mid-bucket MUST NOT overwrite the bar with a partial one
+	query := NewTickQuery().SetTimeLteT(start.Add(75 * time.Second))
+	timeLte := query.TimeLte()
+
+	_, err = store.PriceBuildFromTicks(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, TickBarOptions{Type: BAR_TYPE_TIME}, query)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	minutes, err = store.PriceList(ctx, "BTCUSDT", "BINANCE", TIMEFRAME_1_MINUTE, NewPriceQuery().SetOrderBy(COLUMN_TIME).SetOrderDirection("asc"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(minutes) != 3 || minutes[1].CloseFloat() != 107 || minutes[1].VolumeFloat() != 4 {
+		t.Fatal("A rebuild ending mid-bucket MUST keep the complete second minute bar, got:", minutes[1].Data())
+	}
+
+	if query.TimeLte() != timeLte {
+		t.Fatal("The query of the caller MUST NOT be changed, got:", query.TimeLte())
+	}
+
+	// a rebuild of tick bars starting mid-bar MUST NOT leave overlapping bars
+	_, err = store.PriceBuildFromTicks(ctx, "BTCUSDT", "BINANCE", "tick_3", TickBarOptions{Type: BAR_TYPE_TICK, Threshold: NewDecimal(3, 0)}, NewTickQuery().
+		SetTimeGteT(start.Add(60*time.Second)))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	bars, err := store.PriceList(ctx, "BTCUSDT", "BINANCE", "tick_3", NewPriceQuery().SetOrderBy(COLUMN_TIME).SetOrderDirection("asc"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(bars) != 4 {
+		t.Fatal("Expected 4 stored tick bars after the rebuild, got", len(bars))
+	}
+
+	for index, bar := range bars {
+		if !bar.TimeT().Equal(start.Add(time.Duration(index*45)*time.Second)) || bar.VolumeFloat() != float64(min(3, 10-index*3)) {
+			t.Fatal("Unexpected tick bar after the rebuild:", bar.Data())
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package tradingstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/dracory/database"
+	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+)
+
+// tickCreateManyBatchSize is the number of ticks inserted per statement
+const tickCreateManyBatchSize = 500
+
+// TickCount returns the number of ticks based on the given query options
+func (store *Store) TickCount(ctx context.Context, symbol string, exchange string, options TickQueryInterface) (int64, error) {
+	options.SetCountOnly(true)
+
+	q, _, err := store.tickQuery(symbol, exchange, options)
+
+	if err != nil {
+		return -1, err
+	}
+
+	sqlStr, sqlParams, errSql := q.Prepared(true).
+		Limit(1).
+		Select(goqu.COUNT(goqu.Star()).As("count")).
+		ToSQL()
+
+	if errSql != nil {
+		return -1, errSql
+	}
+
+	store.logSql("count", sqlStr, sqlParams...)
+
+	mapped, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return -1, err
+	}
+
+	if len(mapped) < 1 {
+		return -1, nil
+	}
+
+	return strconv.ParseInt(mapped[0]["count"], 10, 64)
+}
+
+// TickCreate creates a new tick
+func (store *Store) TickCreate(ctx context.Context, symbol string, exchange string, tick TickInterface) error {
+	if tick == nil {
+		return errors.New("tick is nil")
+	}
+
+	return store.TickCreateMany(ctx, symbol, exchange, []TickInterface{tick})
+}
+
+// TickCreateMany appends ticks in bulk. The ticks are inserted with multi-row
+// inserts of up to 500 ticks, in a single transaction unless the context
+// already carries one, so either all the ticks are stored or none.
+//
+// Parameters:
+// - ctx: the context, optionally a database.QueryableContext with a transaction
+// - symbol: the instrument symbol
+// - exchange: the instrument exchange
+// - ticks: the ticks to store
+//
+// Returns:
+// - error: if a tick is invalid or the insert fails
+func (store *Store) TickCreateMany(ctx context.Context, symbol string, exchange string, ticks []TickInterface) error {
+	if err := store.tickStorageCheck(); err != nil {
+		return err
+	}
+
+	records := make([]any, 0, len(ticks))
+
+	for _, tick := range ticks {
+		if tick == nil {
+			return errors.New("tick is nil")
+		}
+
+		if err := tickValidate(tick.Data(), false); err != nil {
+			return err
+		}
+
+		records = append(records, store.tickRecord(tick.Data()))
+	}
+
+	err := store.recordsCreateMany(ctx, store.TickTableName(symbol, exchange), records, tickCreateManyBatchSize)
+
+	if err != nil {
+		return err
+	}
+
+	for _, tick := range ticks {
+		tick.MarkAsNotDirty()
+	}
+
+	return nil
+}
+
+// TickDelete deletes a tick
+func (store *Store) TickDelete(ctx context.Context, symbol string, exchange string, tick TickInterface) error {
+	if tick == nil {
+		return errors.New("tick is nil")
+	}
+
+	return store.TickDeleteByID(ctx, symbol, exchange, tick.ID())
+}
+
+// TickDeleteByID deletes a tick by its ID
+func (store *Store) TickDeleteByID(ctx context.Context, symbol string, exchange string, id string) error {
+	if id == "" {
+		return errors.New("tick id is empty")
+	}
+
+	if err := store.tickStorageCheck(); err != nil {
+		return err
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Delete(store.TickTableName(symbol, exchange)).
+		Prepared(true).
+		Where(goqu.C(COLUMN_ID).Eq(id)).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("delete", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	return err
+}
+
+// TickExists returns true if a tick exists based on the given query options
+func (store *Store) TickExists(ctx context.Context, symbol string, exchange string, options TickQueryInterface) (bool, error) {
+	count, err := store.TickCount(ctx, symbol, exchange, options)
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// TickFindByID returns a tick by its ID
+func (store *Store) TickFindByID(ctx context.Context, symbol string, exchange string, tickID string) (TickInterface, error) {
+	if tickID == "" {
+		return nil, errors.New("tick id is empty")
+	}
+
+	list, err := store.TickList(ctx, symbol, exchange, NewTickQuery().SetID(tickID).SetLimit(1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return nil, nil
+}
+
+// TickIterate returns an iterator over the ticks matching the query options,
+// in ascending time order. The ticks are loaded in batches, so the iterator
+// can be used to stream days of ticks which do not fit in memory.
+//
+// The limit of the options caps the total number of ticks, offsets and
+// custom ordering are not supported.
+func (store *Store) TickIterate(ctx context.Context, symbol string, exchange string, options TickQueryInterface) (TickIteratorInterface, error) {
+	if options == nil {
+		return nil, errors.New("tick options is nil")
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := store.tickStorageCheck(); err != nil {
+		return nil, err
+	}
+
+	if err := keysetIteratorOptionsCheck("tick", options); err != nil {
+		return nil, err
+	}
+
+	remaining := -1
+
+	if options.IsLimitSet() {
+		remaining = options.Limit()
+	}
+
+	load := func(timeGte string, limit int) ([]TickInterface, error) {
+		query := tickQueryCopy(options).
+			SetCountOnly(false).
+			SetOrderBy(COLUMN_TIME).
+			SetOrderDirection("asc").
+			SetLimit(limit)
+
+		if timeGte != "" {
+			query.SetTimeGte(timeGte)
+		}
+
+		return store.TickList(ctx, symbol, exchange, query)
+	}
+
+	return &tickIterator{newKeysetIterator(tickIteratorBatchSize, remaining, load)}, nil
+}
+
+// TickList returns a list of ticks based on the given query options,
+// in ascending time order unless an order is set
+func (store *Store) TickList(ctx context.Context, symbol string, exchange string, options TickQueryInterface) ([]TickInterface, error) {
+	q, columns, err := store.tickQuery(symbol, exchange, options)
+
+	if err != nil {
+		return []TickInterface{}, err
+	}
+
+	sqlStr, sqlParams, errSql := q.Prepared(true).Select(columns...).ToSQL()
+
+	if errSql != nil {
+		return []TickInterface{}, errSql
+	}
+
+	store.logSql("list", sqlStr, sqlParams...)
+
+	modelMaps, err := database.SelectToMapString(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return []TickInterface{}, err
+	}
+
+	list := []TickInterface{}
+
+	lo.ForEach(modelMaps, func(modelMap map[string]string, index int) {
+		if value, found := modelMap[COLUMN_TIME]; found {
+			modelMap[COLUMN_TIME] = tickTimeFromStorage(value)
+		}
+
+		list = append(list, NewTickFromExistingData(modelMap))
+	})
+
+	return list, nil
+}
+
+// TickUpdate updates a tick
+func (store *Store) TickUpdate(ctx context.Context, symbol string, exchange string, tick TickInterface) error {
+	if tick == nil {
+		return errors.New("tick is nil")
+	}
+
+	if err := store.tickStorageCheck(); err != nil {
+		return err
+	}
+
+	dataChanged := tick.DataChanged()
+
+	delete(dataChanged, COLUMN_ID) // ID is not updateable
+
+	if len(dataChanged) < 1 {
+		return nil
+	}
+
+	if err := tickValidate(dataChanged, true); err != nil {
+		return err
+	}
+
+	sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+		Update(store.TickTableName(symbol, exchange)).
+		Prepared(true).
+		Set(store.tickRecord(dataChanged)).
+		Where(goqu.C(COLUMN_ID).Eq(tick.ID())).
+		ToSQL()
+
+	if errSql != nil {
+		return errSql
+	}
+
+	store.logSql("update", sqlStr, sqlParams...)
+
+	_, err := database.Execute(store.toQuerableContext(ctx), sqlStr, sqlParams...)
+
+	if err != nil {
+		return err
+	}
+
+	tick.MarkAsNotDirty()
+
+	return nil
+}
+
+// tickQuery builds the select of the ticks matching the query options
+func (store *Store) tickQuery(symbol string, exchange string, options TickQueryInterface) (selectDataset *goqu.SelectDataset, columns []any, err error) {
+	if options == nil {
+		return nil, nil, errors.New("tick options is nil")
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := store.tickStorageCheck(); err != nil {
+		return nil, nil, err
+	}
+
+	q, columns := store.timeRangeSelect(store.TickTableName(symbol, exchange), options)
+
+	if options.IsSideSet() {
+		q = q.Where(goqu.C(COLUMN_SIDE).Eq(options.Side()))
+	}
+
+	if options.IsTradeIDSet() {
+		q = q.Where(goqu.C(COLUMN_TRADE_ID).Eq(options.TradeID()))
+	}
+
+	return q, columns, nil
+}
+
+// tickRecord converts the data of a tick to a record of the tick table,
+// with the time as microseconds since the Unix epoch
+func (store *Store) tickRecord(data map[string]string) goqu.Record {
+	record := goqu.Record{}
+
+	for column, value := range data {
+		record[column] = value
+	}
+
+	if value, found := data[COLUMN_TIME]; found {
+		record[COLUMN_TIME] = tickTimeQueryValue(value)
+	}
+
+	return record
+}
+
+// tickStorageCheck returns an error if tick storage is disabled
+func (store *Store) tickStorageCheck() error {
+	if store.tickTableNamePrefix == "" {
+		return errors.New("trading store: tick storage is disabled, TickTableNamePrefix is not set")
+	}
+
+	return nil
+}
+
+// tickTimeFromStorage converts the microseconds stored in the time column
+// to the time format of the ticks. Values which can not be parsed are
+// returned unchanged.
+func tickTimeFromStorage(value string) string {
+	micros, err := strconv.ParseInt(value, 10, 64)
+
+	if err != nil {
+		return value
+	}
+
+	return tickTimeFormat(time.UnixMicro(micros))
+}
+
+// tickTimeQueryValue converts a tick time to the microseconds stored in the time column
+func tickTimeQueryValue(value string) int64 {
+	return carbon.Parse(value, carbon.UTC).StdTime().UnixMicro()
+}
+
+// tickValidate checks the time, price, size and side of the tick data.
+// Partial data, i.e. the changed values of an update, is checked only
+// for the values it contains
+func tickValidate(data map[string]string, partial bool) error {
+	for _, column := range []string{COLUMN_TIME, COLUMN_PRICE, COLUMN_SIZE} {
+		if _, found := data[column]; !found && !partial {
+			return errors.New("tick " + column + " is empty")
+		}
+	}
+
+	if value, found := data[COLUMN_TIME]; found && carbon.Parse(value, carbon.UTC).Error != nil {
+		return errors.New("tick time is not a valid time: " + value)
+	}
+
+	for _, column := range []string{COLUMN_PRICE, COLUMN_SIZE} {
+		if value, found := data[column]; found {
+			if _, err := NewDecimalFromString(value); err != nil {
+				return errors.New("tick " + column + " must be a number: " + value)
+			}
+		}
+	}
+
+	if !lo.Contains([]string{"", TICK_SIDE_BUY, TICK_SIDE_SELL}, data[COLUMN_SIDE]) {
+		return errors.New("tick side is not supported: " + data[COLUMN_SIDE])
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package tradingstore
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dracory/database"
+	_ "modernc.org/sqlite"
+)
+
+func initTickStore(t *testing.T) StoreInterface {
+	store, err := NewStore(NewStoreOptions{
+		DB:                   initDB(":memory:"),
+		PriceTableNamePrefix: "price_",
+		TickTableNamePrefix:  "tick_",
+		InstrumentTableName:  "instrument",
+		UseMultipleExchanges: true,
+		AutomigrateEnabled:   true,
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	instrument := NewInstrument().
+		SetSymbol("BTCUSDT").
+		SetExchange("BINANCE").
+		SetPricePrecision(2).
+		SetVolumePrecision(6).
+		SetTimeframes([]string{TIMEFRAME_1_MINUTE})
+
+	if err := store.InstrumentCreate(context.Background(), instrument); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.AutoMigratePrices(context.Background()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestStoreTickCreateAndList(t *testing.T) {
+	store := initTickStore(t)
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tick := NewTick().
+		SetTimeT(start.Add(1500 * time.Nanosecond)).
+		SetPrice("42000.5").
+		SetSize("0.015").
+		SetSide(TICK_SIDE_BUY).
+		SetTradeID("T1")
+
+	if tick.Time() != "2024-01-02T00:00:00.000001Z" {
+		t.Fatal("Tick time MUST be truncated to microseconds, got", tick.Time())
+	}
+
+	if err := store.TickCreate(ctx, "BTCUSDT", "BINANCE", tick); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.TickFindByID(ctx, "BTCUSDT", "BINANCE", tick.ID())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if found == nil || found.Time() != tick.Time() || found.PriceFloat() != 42000.5 || found.SizeFloat() != 0.015 || found.Side() != TICK_SIDE_BUY || found.TradeID() != "T1" {
+		t.Fatal("Stored tick MUST round trip, got", found)
+	}
+
+	found.SetSide(TICK_SIDE_SELL).SetSize("0.02")
+
+	if err := store.TickUpdate(ctx, "BTCUSDT", "BINANCE", found); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	exists, err := store.TickExists(ctx, "BTCUSDT", "BINANCE", NewTickQuery().SetSide(TICK_SIDE_SELL).SetTradeID("T1"))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !exists {
+		t.Fatal("Updated tick MUST be found by side and trade id")
+	}
+
+	if err := store.TickCreate(ctx, "BTCUSDT", "BINANCE", NewTick().SetTimeT(start).SetPrice("1").SetSize("1").SetSide("BID")); err == nil {
+		t.Fatal("TickCreate MUST reject an unsupported side")
+	}
+
+	if err := store.TickCreate(ctx, "BTCUSDT", "BINANCE", NewTick().SetTimeT(start).SetSize("1")); err == nil {
+		t.Fatal("TickCreate MUST reject a tick without a price")
+	}
+
+	if err := store.TickDelete(ctx, "BTCUSDT", "BINANCE", found); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	count, err := store.TickCount(ctx, "BTCUSDT", "BINANCE", NewTickQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count != 0 {
+		t.Fatal("Expected 0 ticks after delete, got", count)
+	}
+}
+
+func TestStoreTickCreateManyAndIterate(t *testing.T) {
+	store := initTickStore(t)
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	ticks := []TickInterface{}
+
+	// more ticks than a batch of the bulk insert and of the iterator,
+	// with pairs of ticks sharing the same microsecond
+	for i := 0; i < 2400; i++ {
+		ticks = append(ticks, NewTick().
+			SetTimeT(start.Add(time.Duration(i/2)*time.Microsecond)).
+			SetPrice(strconv.Itoa(42000+i%10)).
+			SetSize("0.001").
+			SetTradeID(strconv.Itoa(i)))
+	}
+
+	if err := store.TickCreateMany(ctx, "BTCUSDT", "BINANCE", ticks); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	count, err := store.TickCount(ctx, "BTCUSDT", "BINANCE", NewTickQuery().
+		SetTimeGteT(start.Add(100*time.Microsecond)).
+		SetTimeLteT(start.Add(199*time.Microsecond)))
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count != 200 {
+		t.Fatal("Expected 200 ticks in the microsecond range, got", count)
+	}
+
+	iterator, err := store.TickIterate(ctx, "BTCUSDT", "BINANCE", NewTickQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	defer iterator.Close()
+
+	seen := map[string]bool{}
+	lastTime := time.Time{}
+
+	for iterator.Next() {
+		tick := iterator.Tick()
+
+		if tick.TimeT().Before(lastTime) {
+			t.Fatal("Ticks MUST be iterated in ascending time order")
+		}
+
+		lastTime = tick.TimeT()
+		seen[tick.ID()] = true
+	}
+
+	if iterator.Err() != nil {
+		t.Fatal("unexpected error:", iterator.Err())
+	}
+
+	if len(seen) != len(ticks) {
+		t.Fatal("Iterator MUST return every tick once, got", len(seen))
+	}
+
+	// a failing bulk insert stores none of the ticks
+	duplicate := []TickInterface{
+		NewTick().SetTimeT(start).SetPrice("1").SetSize("1"),
+		ticks[0],
+	}
+
+	if err := store.TickCreateMany(ctx, "BTCUSDT", "BINANCE", duplicate); err == nil {
+		t.Fatal("TickCreateMany MUST fail on a duplicate id")
+	}
+
+	count, err = store.TickCount(ctx, "BTCUSDT", "BINANCE", NewTickQuery())
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count != int64(len(ticks)) {
+		t.Fatal("TickCreateMany MUST roll back on failure, got", count)
+	}
+}
+
+func TestStoreTickTableTimeIndex(t *testing.T) {
+	store := initTickStore(t)
+	ctx := context.Background()
+
+	// migrating again MUST NOT fail on the existing index
+	if err := store.AutoMigratePrices(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	tableName := store.(*Store).TickTableName("BTCUSDT", "BINANCE")
+
+	rows, err := database.SelectToMapString(store.(*Store).toQuerableContext(ctx),
+		"SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ?", tableName)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found := false
+
+	for _, row := range rows {
+		if row["name"] == "idx_"+tableName+"_"+COLUMN_TIME {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Fatal("Tick table MUST have an index on the time, found:", rows)
+	}
+}
+
+func TestStoreTickStorageDisabled(t *testing.T) {
+	store, err := initStore()
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	tick := NewTick().SetTime("2024-01-02 00:00:00").SetPrice("1").SetSize("1")
+
+	if err := store.TickCreate(context.Background(), "BTCUSDT", "BINANCE", tick); err == nil {
+		t.Fatal("TickCreate MUST return an error when tick storage is disabled")
+	}
+}
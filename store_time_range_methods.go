@@ -0,0 +1,102 @@
+package tradingstore
+
+import (
+	"context"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/dracory/database"
+	"github.com/dracory/sb"
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+)
+
+// timeRangeSelect builds the select of the rows of a tick, quote or order
+// book table matching the id, time range, paging and order of the query
+// options, with the times as microseconds since the Unix epoch. The rows are
+// in ascending time order unless an order is set.
+func (store *Store) timeRangeSelect(tableName string, options timeRangeQueryInterface) (selectDataset *goqu.SelectDataset, columns []any) {
+	q := goqu.Dialect(store.dbDriverName).From(tableName)
+
+	if options.IsIDSet() {
+		q = q.Where(goqu.C(COLUMN_ID).Eq(options.ID()))
+	}
+
+	if options.IsIDInSet() {
+		q = q.Where(goqu.C(COLUMN_ID).In(options.IDIn()))
+	}
+
+	if options.IsTimeSet() {
+		q = q.Where(goqu.C(COLUMN_TIME).Eq(tickTimeQueryValue(options.Time())))
+	}
+
+	if options.IsTimeGteSet() {
+		q = q.Where(goqu.C(COLUMN_TIME).Gte(tickTimeQueryValue(options.TimeGte())))
+	}
+
+	if options.IsTimeLteSet() {
+		q = q.Where(goqu.C(COLUMN_TIME).Lte(tickTimeQueryValue(options.TimeLte())))
+	}
+
+	if !options.IsCountOnly() {
+		if options.IsLimitSet() {
+			q = q.Limit(cast.ToUint(options.Limit()))
+		}
+
+		if options.IsOffsetSet() {
+			q = q.Offset(cast.ToUint(options.Offset()))
+		}
+	}
+
+	if options.IsOrderBySet() {
+		sort := lo.Ternary(options.IsOrderDirectionSet(), options.OrderDirection(), sb.DESC)
+		if strings.EqualFold(sort, sb.ASC) {
+			q = q.Order(goqu.I(options.OrderBy()).Asc())
+		} else {
+			q = q.Order(goqu.I(options.OrderBy()).Desc())
+		}
+	} else {
+		q = q.Order(goqu.I(COLUMN_TIME).Asc(), goqu.I(COLUMN_ID).Asc())
+	}
+
+	columns = []any{}
+
+	for _, column := range options.Columns() {
+		columns = append(columns, column)
+	}
+
+	return q, columns
+}
+
+// recordsCreateMany inserts the records into the table with multi-row
+// inserts of up to batchSize records, in a single transaction unless the
+// context already carries one, so either all the records are stored or none
+func (store *Store) recordsCreateMany(ctx context.Context, tableName string, records []any, batchSize int) error {
+	if len(records) < 1 {
+		return nil
+	}
+
+	return store.withTx(ctx, func(queryableCtx database.QueryableContext) error {
+		for _, chunk := range lo.Chunk(records, batchSize) {
+			sqlStr, sqlParams, errSql := goqu.Dialect(store.dbDriverName).
+				Insert(tableName).
+				Prepared(true).
+				Rows(chunk...).
+				ToSQL()
+
+			if errSql != nil {
+				return errSql
+			}
+
+			store.logSql("create", sqlStr, sqlParams...)
+
+			_, err := database.Execute(queryableCtx, sqlStr, sqlParams...)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
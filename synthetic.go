@@ -0,0 +1,271 @@
+package tradingstore
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/samber/lo"
+)
+
+// SyntheticLeg is an instrument the prices of a synthetic instrument are
+// computed from
+type SyntheticLeg struct {
+	// Symbol is the symbol of the leg instrument
+	Symbol string
+
+	// Exchange is the exchange of the leg instrument, any exchange if empty
+	Exchange string
+
+	// Weight multiplies the prices of the leg, a zero weight is a weight of 1
+	Weight Decimal
+}
+
+// SyntheticDefinition defines how the prices of a synthetic instrument are
+// computed from the prices of its legs
+type SyntheticDefinition struct {
+	// Operation is SYNTHETIC_OPERATION_DIFFERENCE, SYNTHETIC_OPERATION_RATIO
+	// or SYNTHETIC_OPERATION_WEIGHTED_SUM
+	Operation string
+
+	// Legs are the instruments combined, in order
+	Legs []SyntheticLeg
+}
+
+// syntheticLegJSON is a leg as stored on the instrument, the weight
+// is kept as a string to stay exact
+type syntheticLegJSON struct {
+	Symbol   string `json:"symbol"`
+	Exchange string `json:"exchange,omitempty"`
+	Weight   string `json:"weight,omitempty"`
+}
+
+// syntheticDefinitionJSON is a definition as stored on the instrument
+type syntheticDefinitionJSON struct {
+	Operation string             `json:"operation"`
+	Legs      []syntheticLegJSON `json:"legs"`
+}
+
+// SyntheticPrices computes the prices of a synthetic instrument from the
+// prices of its legs.
+//
+// The legs are aligned by time, a price is computed only for the times at
+// which every leg has a price. The open and close are computed from the opens
+// and closes of the legs. The high and low of a spread are not known from the
+// highs and lows of the legs, so they are the highest and lowest of the
+// computed open, high, low and close. The volume is 0.
+//
+// Parameters:
+// - definition: the synthetic definition
+// - legPrices: the prices of each leg, in the order of the legs
+// - decimals: the number of decimals of the ratios
+//
+// Returns:
+// - []PriceInterface: the synthetic prices, sorted by time ascending
+// - error: if the definition is invalid or a price is missing
+func SyntheticPrices(definition SyntheticDefinition, legPrices [][]PriceInterface, decimals int32) ([]PriceInterface, error) {
+	if err := syntheticDefinitionValidate(definition); err != nil {
+		return nil, err
+	}
+
+	if len(legPrices) != len(definition.Legs) {
+		return nil, errors.New("synthetic: expected prices for " + strconv.Itoa(len(definition.Legs)) + " legs, got " + strconv.Itoa(len(legPrices)))
+	}
+
+	// prices of each leg by time
+	legPricesByTime := make([]map[string]PriceInterface, len(legPrices))
+
+	for index, prices := range legPrices {
+		legPricesByTime[index] = map[string]PriceInterface{}
+
+		for _, price := range prices {
+			if price == nil {
+				return nil, errors.New("synthetic: price is nil")
+			}
+
+			legPricesByTime[index][price.Time()] = price
+		}
+	}
+
+	prices := []PriceInterface{}
+
+	for _, first := range legPrices[0] {
+		legs := []PriceInterface{}
+
+		for _, pricesByTime := range legPricesByTime {
+			if price, found := pricesByTime[first.Time()]; found {
+				legs = append(legs, price)
+			}
+		}
+
+		if len(legs) != len(definition.Legs) {
+			continue
+		}
+
+		values := make([]Decimal, 4)
+
+		for field, value := range []func(PriceInterface) Decimal{
+			PriceInterface.OpenDecimal,
+			PriceInterface.HighDecimal,
+			PriceInterface.LowDecimal,
+			PriceInterface.CloseDecimal,
+		} {
+			combined, err := syntheticCombine(definition, lo.Map(legs, func(price PriceInterface, _ int) Decimal {
+				return value(price)
+			}), decimals)
+
+			if err != nil {
+				return nil, errors.New("synthetic: " + err.Error() + " at " + first.Time())
+			}
+
+			values[field] = combined
+		}
+
+		open, close := values[0], values[3]
+		high := values[0].Max(values[1]).Max(values[2]).Max(values[3])
+		low := values[0].Min(values[1]).Min(values[2]).Min(values[3])
+
+		prices = append(prices, NewPrice().
+			SetTime(first.Time()).
+			SetOpenDecimal(open).
+			SetHighDecimal(high).
+			SetLowDecimal(low).
+			SetCloseDecimal(close).
+			SetVolume("0"))
+	}
+
+	return prices, nil
+}
+
+// syntheticCombine applies the operation of the definition to one value
+// of each leg
+func syntheticCombine(definition SyntheticDefinition, values []Decimal, decimals int32) (Decimal, error) {
+	weighted := make([]Decimal, len(values))
+
+	for index, value := range values {
+		weighted[index] = value.Mul(syntheticLegWeight(definition.Legs[index]))
+	}
+
+	switch definition.Operation {
+	case SYNTHETIC_OPERATION_DIFFERENCE:
+		result := weighted[0]
+		for _, value := range weighted[1:] {
+			result = result.Sub(value)
+		}
+		return result, nil
+	case SYNTHETIC_OPERATION_RATIO:
+		if weighted[1].IsZero() {
+			return Decimal{}, errors.New("division by zero")
+		}
+		return weighted[0].Div(weighted[1], decimals), nil
+	}
+
+	result := Decimal{}
+	for _, value := range weighted {
+		result = result.Add(value)
+	}
+
+	return result, nil
+}
+
+// syntheticLegWeight returns the weight of the leg, 1 if not set
+func syntheticLegWeight(leg SyntheticLeg) Decimal {
+	if leg.Weight.IsZero() {
+		return NewDecimal(1, 0)
+	}
+
+	return leg.Weight
+}
+
+// syntheticDefinitionValidate returns an error if the operation is not
+// supported or the legs do not suit the operation
+func syntheticDefinitionValidate(definition SyntheticDefinition) error {
+	minimumLegs := map[string]int{
+		SYNTHETIC_OPERATION_DIFFERENCE:   2,
+		SYNTHETIC_OPERATION_RATIO:        2,
+		SYNTHETIC_OPERATION_WEIGHTED_SUM: 1,
+	}
+
+	minimum, supported := minimumLegs[definition.Operation]
+
+	if !supported {
+		return errors.New("synthetic operation is not supported: " + definition.Operation)
+	}
+
+	if len(definition.Legs) < minimum {
+		return errors.New("synthetic operation " + definition.Operation + " requires at least " + strconv.Itoa(minimum) + " legs")
+	}
+
+	if definition.Operation == SYNTHETIC_OPERATION_RATIO && len(definition.Legs) != 2 {
+		return errors.New("synthetic operation " + definition.Operation + " requires exactly 2 legs")
+	}
+
+	for _, leg := range definition.Legs {
+		if leg.Symbol == "" {
+			return errors.New("synthetic leg symbol is empty")
+		}
+	}
+
+	return nil
+}
+
+// syntheticDefinitionFormat returns the definition as stored on the
+// instrument, an empty string for an empty definition
+func syntheticDefinitionFormat(definition SyntheticDefinition) (string, error) {
+	if definition.Operation == "" && len(definition.Legs) == 0 {
+		return "", nil
+	}
+
+	stored := syntheticDefinitionJSON{
+		Operation: definition.Operation,
+		Legs: lo.Map(definition.Legs, func(leg SyntheticLeg, _ int) syntheticLegJSON {
+			weight := ""
+
+			if !leg.Weight.IsZero() {
+				weight = leg.Weight.String()
+			}
+
+			return syntheticLegJSON{Symbol: leg.Symbol, Exchange: leg.Exchange, Weight: weight}
+		}),
+	}
+
+	bytes, err := json.Marshal(stored)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+// syntheticDefinitionParse parses a definition stored on the instrument
+func syntheticDefinitionParse(str string) (SyntheticDefinition, error) {
+	if str == "" {
+		return SyntheticDefinition{}, nil
+	}
+
+	var stored syntheticDefinitionJSON
+
+	if err := json.Unmarshal([]byte(str), &stored); err != nil {
+		return SyntheticDefinition{}, err
+	}
+
+	definition := SyntheticDefinition{Operation: stored.Operation}
+
+	for _, leg := range stored.Legs {
+		weight := Decimal{}
+
+		if leg.Weight != "" {
+			var err error
+			weight, err = NewDecimalFromString(leg.Weight)
+
+			if err != nil {
+				return SyntheticDefinition{}, err
+			}
+		}
+
+		definition.Legs = append(definition.Legs, SyntheticLeg{Symbol: leg.Symbol, Exchange: leg.Exchange, Weight: weight})
+	}
+
+	return definition, nil
+}
@@ -0,0 +1,128 @@
+package tradingstore
+
+import (
+	"testing"
+)
+
+func syntheticTestPrices(closes map[string]string) []PriceInterface {
+	prices := []PriceInterface{}
+
+	for _, time := range []string{"2024-01-01 00:00:00", "2024-01-02 00:00:00", "2024-01-03 00:00:00"} {
+		close, found := closes[time]
+
+		if !found {
+			continue
+		}
+
+		prices = append(prices, NewPrice().
+			SetTime(time).
+			SetOpen(close).
+			SetHigh(close).
+			SetLow(close).
+			SetClose(close).
+			SetVolume("100"))
+	}
+
+	return prices
+}
+
+func TestSyntheticPrices(t *testing.T) {
+	a := syntheticTestPrices(map[string]string{
+		"2024-01-01 00:00:00": "100",
+		"2024-01-02 00:00:00": "110",
+		"2024-01-03 00:00:00": "120",
+	})
+
+	// no price on the second day
+	b := syntheticTestPrices(map[string]string{
+		"2024-01-01 00:00:00": "40",
+		"2024-01-03 00:00:00": "30",
+	})
+
+	difference, err := SyntheticPrices(SyntheticDefinition{
+		Operation: SYNTHETIC_OPERATION_DIFFERENCE,
+		Legs:      []SyntheticLeg{{Symbol: "A"}, {Symbol: "B", Weight: NewDecimal(2, 0)}},
+	}, [][]PriceInterface{a, b}, 8)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(difference) != 2 {
+		t.Fatal("Expected 2 prices at the times of both legs, got", len(difference))
+	}
+
+	if difference[0].CloseFloat() != 20 || difference[1].CloseFloat() != 60 {
+		t.Fatal("Unexpected difference closes:", difference[0].Close(), difference[1].Close())
+	}
+
+	if difference[1].Time() != "2024-01-03T00:00:00Z" || difference[1].VolumeFloat() != 0 {
+		t.Fatal("Unexpected difference price:", difference[1].Data())
+	}
+
+	ratio, err := SyntheticPrices(SyntheticDefinition{
+		Operation: SYNTHETIC_OPERATION_RATIO,
+		Legs:      []SyntheticLeg{{Symbol: "A"}, {Symbol: "B"}},
+	}, [][]PriceInterface{a, b}, 4)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if ratio[0].Close() != "2.5000" || ratio[1].Close() != "4.0000" {
+		t.Fatal("Unexpected ratio closes:", ratio[0].Close(), ratio[1].Close())
+	}
+
+	basket, err := SyntheticPrices(SyntheticDefinition{
+		Operation: SYNTHETIC_OPERATION_WEIGHTED_SUM,
+		Legs:      []SyntheticLeg{{Symbol: "A", Weight: NewDecimal(5, 1)}, {Symbol: "B", Weight: NewDecimal(5, 1)}},
+	}, [][]PriceInterface{a, b}, 8)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if basket[0].CloseFloat() != 70 || basket[1].CloseFloat() != 75 {
+		t.Fatal("Unexpected basket closes:", basket[0].Close(), basket[1].Close())
+	}
+
+	_, err = SyntheticPrices(SyntheticDefinition{
+		Operation: SYNTHETIC_OPERATION_RATIO,
+		Legs:      []SyntheticLeg{{Symbol: "A"}},
+	}, [][]PriceInterface{a}, 8)
+
+	if err == nil {
+		t.Fatal("A ratio of one leg MUST be rejected")
+	}
+}
+
+func TestSyntheticDefinitionFormat(t *testing.T) {
+	definition := SyntheticDefinition{
+		Operation: SYNTHETIC_OPERATION_WEIGHTED_SUM,
+		Legs:      []SyntheticLeg{{Symbol: "A", Exchange: "NYSE", Weight: NewDecimal(25, 2)}, {Symbol: "B"}},
+	}
+
+	str, err := syntheticDefinitionFormat(definition)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	parsed, err := syntheticDefinitionParse(str)
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if parsed.Operation != definition.Operation || len(parsed.Legs) != 2 {
+		t.Fatal("Unexpected parsed definition:", str)
+	}
+
+	if parsed.Legs[0].Exchange != "NYSE" || parsed.Legs[0].Weight.String() != "0.25" || !parsed.Legs[1].Weight.IsZero() {
+		t.Fatal("Unexpected parsed legs:", str)
+	}
+
+	if str, _ := syntheticDefinitionFormat(SyntheticDefinition{}); str != "" {
+		t.Fatal("An empty definition MUST be stored as an empty string, got", str)
+	}
+}
@@ -0,0 +1,149 @@
+package tradingstore
+
+import (
+	"time"
+
+	"github.com/dracory/dataobject"
+	"github.com/dracory/uid"
+	"github.com/dromara/carbon/v2"
+	"github.com/spf13/cast"
+)
+
+// == CLASS ====================================================================
+
+// tickImplementation represents a single trade (tick) of an instrument
+type tickImplementation struct {
+	dataobject.DataObject
+}
+
+// == CONSTRUCTORS =============================================================
+
+func NewTick() TickInterface {
+	o := (&tickImplementation{}).
+		SetID(uid.HumanUid())
+
+	o.SetSide("")
+	o.SetTradeID("")
+
+	return o
+}
+
+func NewTickFromExistingData(data map[string]string) TickInterface {
+	o := &tickImplementation{}
+	o.Hydrate(data)
+	return o
+}
+
+// == SETTERS & GETTERS ========================================================
+
+func (tick *tickImplementation) ID() string {
+	return tick.Get(COLUMN_ID)
+}
+
+func (tick *tickImplementation) SetID(id string) TickInterface {
+	tick.Set(COLUMN_ID, id)
+	return tick
+}
+
+// Price returns the price the trade was executed at
+func (tick *tickImplementation) Price() string {
+	return tick.Get(COLUMN_PRICE)
+}
+
+func (tick *tickImplementation) PriceFloat() float64 {
+	return cast.ToFloat64(tick.Price())
+}
+
+// PriceDecimal returns the price as an exact decimal, 0 if it is not a number
+func (tick *tickImplementation) PriceDecimal() Decimal {
+	return priceDecimal(tick.Price())
+}
+
+func (tick *tickImplementation) SetPrice(price string) TickInterface {
+	tick.Set(COLUMN_PRICE, price)
+	return tick
+}
+
+func (tick *tickImplementation) SetPriceDecimal(price Decimal) TickInterface {
+	return tick.SetPrice(price.String())
+}
+
+// Side returns the side of the aggressor, TICK_SIDE_BUY or TICK_SIDE_SELL,
+// empty if unknown
+func (tick *tickImplementation) Side() string {
+	return tick.Get(COLUMN_SIDE)
+}
+
+func (tick *tickImplementation) SetSide(side string) TickInterface {
+	tick.Set(COLUMN_SIDE, side)
+	return tick
+}
+
+// Size returns the traded quantity
+func (tick *tickImplementation) Size() string {
+	return tick.Get(COLUMN_SIZE)
+}
+
+func (tick *tickImplementation) SizeFloat() float64 {
+	return cast.ToFloat64(tick.Size())
+}
+
+// SizeDecimal returns the size as an exact decimal, 0 if it is not a number
+func (tick *tickImplementation) SizeDecimal() Decimal {
+	return priceDecimal(tick.Size())
+}
+
+func (tick *tickImplementation) SetSize(size string) TickInterface {
+	tick.Set(COLUMN_SIZE, size)
+	return tick
+}
+
+func (tick *tickImplementation) SetSizeDecimal(size Decimal) TickInterface {
+	return tick.SetSize(size.String())
+}
+
+// Time returns the time of the trade as ISO8601 in UTC, with microseconds
+// if there are any (i.e. 2024-01-02T15:04:05.123456Z)
+func (tick *tickImplementation) Time() string {
+	return tick.Get(COLUMN_TIME)
+}
+
+func (tick *tickImplementation) TimeCarbon() *carbon.Carbon {
+	return carbon.Parse(tick.Time(), carbon.UTC)
+}
+
+// TimeT returns the time of the trade as a time.Time in UTC
+func (tick *tickImplementation) TimeT() time.Time {
+	return tick.TimeCarbon().StdTime().UTC()
+}
+
+// SetTime sets the time of the trade, must be in UTC.
+// The time is truncated to microseconds.
+func (tick *tickImplementation) SetTime(timeUtc string) TickInterface {
+	return tick.SetTimeT(carbon.Parse(timeUtc, carbon.UTC).StdTime())
+}
+
+// SetTimeT sets the time of the trade from a time.Time in any location.
+// The time is truncated to microseconds and stored in UTC.
+func (tick *tickImplementation) SetTimeT(t time.Time) TickInterface {
+	tick.Set(COLUMN_TIME, tickTimeFormat(t))
+	return tick
+}
+
+// TradeID returns the trade ID assigned by the exchange
+func (tick *tickImplementation) TradeID() string {
+	return tick.Get(COLUMN_TRADE_ID)
+}
+
+func (tick *tickImplementation) SetTradeID(tradeID string) TickInterface {
+	tick.Set(COLUMN_TRADE_ID, tradeID)
+	return tick
+}
+
+// == PRIVATE FUNCTIONS ========================================================
+
+// tickTimeFormat formats the time of a tick as ISO8601 in UTC,
+// truncated to microseconds, the precision of the tick tables
+func tickTimeFormat(t time.Time) string {
+	return priceTimeFormat(t.Truncate(time.Microsecond))
+}
@@ -0,0 +1,236 @@
+package tradingstore
+
+import (
+	"errors"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// TickBarOptions define the bars built from ticks
+type TickBarOptions struct {
+	// Type is how the bars are closed, one of the BAR_TYPE_* constants
+	Type string
+
+	// Timeframe is the timeframe of time bars, one of the TIMEFRAME_* constants
+	Timeframe string
+
+	// BucketOptions align daily and larger time bars
+	BucketOptions TimeframeBucketOptions
+
+	// Threshold closes tick, volume and dollar bars once reached: the number
+	// of ticks, the traded size, or the traded value (price times size)
+	Threshold Decimal
+
+	// Fields are the extended price fields set on the bars, any of
+	// COLUMN_TRADES, COLUMN_VWAP and COLUMN_TAKER_BUY_VOLUME
+	Fields []string
+}
+
+// tickBarFields are the extended price fields which can be built from ticks
+var tickBarFields = []string{COLUMN_TRADES, COLUMN_VWAP, COLUMN_TAKER_BUY_VOLUME}
+
+// TickBarBuilder builds OHLCV bars from ticks added in ascending time order.
+//
+// Time bars start at their bucket start. Tick, volume and dollar bars start
+// at the time of their first tick, and take its ID, so rebuilding the same
+// ticks produces the same bars.
+type TickBarBuilder struct {
+	options TickBarOptions
+
+	// the bar being built
+	ticks          int64
+	bucketStart    time.Time
+	firstTick      TickInterface
+	lastTick       TickInterface
+	high           Decimal
+	low            Decimal
+	volume         Decimal
+	value          Decimal
+	takerBuyVolume Decimal
+	priceScale     int32
+}
+
+// NewTickBarBuilder creates a bar builder
+//
+// Parameters:
+// - options: the bar options
+//
+// Returns:
+// - *TickBarBuilder: the builder
+// - error: if the options are not supported
+func NewTickBarBuilder(options TickBarOptions) (*TickBarBuilder, error) {
+	switch options.Type {
+	case BAR_TYPE_TIME:
+		if _, err := TimeframeDuration(options.Timeframe); err != nil {
+			return nil, err
+		}
+
+		if _, err := options.BucketOptions.anchor(); err != nil {
+			return nil, err
+		}
+	case BAR_TYPE_TICK, BAR_TYPE_VOLUME, BAR_TYPE_DOLLAR:
+		if options.Threshold.Sign() <= 0 {
+			return nil, errors.New("tick bar builder: threshold must be greater than zero")
+		}
+	default:
+		return nil, errors.New("tick bar builder: bar type is not supported: " + options.Type)
+	}
+
+	for _, field := range options.Fields {
+		if !lo.Contains(tickBarFields, field) {
+			return nil, errors.New("tick bar builder: field can not be built from ticks: " + field)
+		}
+	}
+
+	return &TickBarBuilder{options: options}, nil
+}
+
+// Add adds the next tick to the bar being built.
+//
+// Returns:
+// - []PriceInterface: the bars completed by the tick, if any
+// - error: if the tick is nil or older than the previous tick
+func (builder *TickBarBuilder) Add(tick TickInterface) ([]PriceInterface, error) {
+	if tick == nil {
+		return nil, errors.New("tick bar builder: tick is nil")
+	}
+
+	if builder.lastTick != nil && tick.TimeT().Before(builder.lastTick.TimeT()) {
+		return nil, errors.New("tick bar builder: ticks must be added in ascending time order")
+	}
+
+	completed := []PriceInterface{}
+
+	if builder.options.Type == BAR_TYPE_TIME {
+		start, err := TimeframeBucketStartWithOptions(builder.options.Timeframe, tick.TimeT(), builder.options.BucketOptions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if builder.ticks > 0 && !start.Equal(builder.bucketStart) {
+			completed = append(completed, builder.Flush())
+		}
+
+		builder.bucketStart = start
+	}
+
+	builder.add(tick)
+
+	if builder.thresholdReached() {
+		completed = append(completed, builder.Flush())
+	}
+
+	return completed, nil
+}
+
+// Flush returns the bar being built, nil if it has no ticks, and starts a new bar
+func (builder *TickBarBuilder) Flush() PriceInterface {
+	if builder.ticks < 1 {
+		return nil
+	}
+
+	bar := NewPrice().
+		SetOpen(builder.firstTick.Price()).
+		SetHighDecimal(builder.high).
+		SetLowDecimal(builder.low).
+		SetClose(builder.lastTick.Price()).
+		SetVolumeDecimal(builder.volume)
+
+	if builder.options.Type == BAR_TYPE_TIME {
+		bar.SetTimeT(builder.bucketStart)
+	} else {
+		bar.SetID(builder.firstTick.ID()).SetTimeT(builder.firstTick.TimeT())
+	}
+
+	if lo.Contains(builder.options.Fields, COLUMN_TRADES) {
+		bar.SetTrades(NewDecimal(builder.ticks, 0).String())
+	}
+
+	if lo.Contains(builder.options.Fields, COLUMN_VWAP) && !builder.volume.IsZero() {
+		bar.SetVWAPDecimal(builder.value.Div(builder.volume, max(builder.priceScale, PRICE_PRECISION_DEFAULT)))
+	}
+
+	if lo.Contains(builder.options.Fields, COLUMN_TAKER_BUY_VOLUME) {
+		bar.SetTakerBuyVolumeDecimal(builder.takerBuyVolume)
+	}
+
+	*builder = TickBarBuilder{options: builder.options, lastTick: builder.lastTick}
+
+	return bar
+}
+
+// add adds the tick to the bar being built
+func (builder *TickBarBuilder) add(tick TickInterface) {
+	price := tick.PriceDecimal()
+	size := tick.SizeDecimal()
+
+	if builder.ticks == 0 {
+		builder.firstTick = tick
+		builder.high = price
+		builder.low = price
+	}
+
+	builder.ticks++
+	builder.lastTick = tick
+	builder.high = builder.high.Max(price)
+	builder.low = builder.low.Min(price)
+	builder.volume = builder.volume.Add(size)
+	builder.value = builder.value.Add(price.Mul(size))
+	builder.priceScale = max(builder.priceScale, price.Scale())
+
+	if tick.Side() == TICK_SIDE_BUY {
+		builder.takerBuyVolume = builder.takerBuyVolume.Add(size)
+	}
+}
+
+// thresholdReached returns true if the tick, volume or dollar bar is complete
+func (builder *TickBarBuilder) thresholdReached() bool {
+	switch builder.options.Type {
+	case BAR_TYPE_TICK:
+		return NewDecimal(builder.ticks, 0).Cmp(builder.options.Threshold) >= 0
+	case BAR_TYPE_VOLUME:
+		return builder.volume.Cmp(builder.options.Threshold) >= 0
+	case BAR_TYPE_DOLLAR:
+		return builder.value.Cmp(builder.options.Threshold) >= 0
+	}
+
+	return false
+}
+
+// TickAggregate builds the bars of the ticks, which must be sorted by time
+// in ascending order. The last bar is included even if it is not complete.
+//
+// Parameters:
+// - ticks: the ticks, sorted by time ascending
+// - options: the bar options
+//
+// Returns:
+// - []PriceInterface: the bars
+// - error: if the options are not supported or the ticks are not sorted
+func TickAggregate(ticks []TickInterface, options TickBarOptions) ([]PriceInterface, error) {
+	builder, err := NewTickBarBuilder(options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bars := []PriceInterface{}
+
+	for _, tick := range ticks {
+		completed, err := builder.Add(tick)
+
+		if err != nil {
+			return nil, err
+		}
+
+		bars = append(bars, completed...)
+	}
+
+	if bar := builder.Flush(); bar != nil {
+		bars = append(bars, bar)
+	}
+
+	return bars, nil
+}
@@ -0,0 +1,125 @@
+package tradingstore
+
+import (
+	"testing"
+	"time"
+)
+
+func tickBarTestTicks() []TickInterface {
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	return []TickInterface{
+		NewTick().SetTimeT(start.Add(10 * time.Second)).SetPrice("10").SetSize("1").SetSide(TICK_SIDE_BUY),
+		NewTick().SetTimeT(start.Add(20 * time.Second)).SetPrice("12").SetSize("2").SetSide(TICK_SIDE_SELL),
+		NewTick().SetTimeT(start.Add(50 * time.Second)).SetPrice("9").SetSize("1").SetSide(TICK_SIDE_BUY),
+		NewTick().SetTimeT(start.Add(70 * time.Second)).SetPrice("11").SetSize("3").SetSide(TICK_SIDE_SELL),
+		NewTick().SetTimeT(start.Add(80 * time.Second)).SetPrice("13").SetSize("1").SetSide(TICK_SIDE_BUY),
+	}
+}
+
+func TestTickAggregateTimeBars(t *testing.T) {
+	bars, err := TickAggregate(tickBarTestTicks(), TickBarOptions{
+		Type:      BAR_TYPE_TIME,
+		Timeframe: TIMEFRAME_1_MINUTE,
+		Fields:    []string{COLUMN_TRADES, COLUMN_VWAP, COLUMN_TAKER_BUY_VOLUME},
+	})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(bars) != 2 {
+		t.Fatal("Expected 2 bars, got", len(bars))
+	}
+
+	first := bars[0]
+
+	if first.Time() != "2024-01-02T00:00:00Z" {
+		t.Fatal("Time bar MUST start at its bucket start, got", first.Time())
+	}
+
+	if first.OpenFloat() != 10 || first.HighFloat() != 12 || first.LowFloat() != 9 || first.CloseFloat() != 9 || first.VolumeFloat() != 4 {
+		t.Fatal("Unexpected first bar OHLCV:", first.Open(), first.High(), first.Low(), first.Close(), first.Volume())
+	}
+
+	if first.TradesInt() != 3 {
+		t.Fatal("Expected 3 trades, got", first.Trades())
+	}
+
+	// (10*1 + 12*2 + 9*1) / 4
+	if !first.VWAPDecimal().Equal(NewDecimal(1075, 2)) {
+		t.Fatal("Expected VWAP 10.75, got", first.VWAP())
+	}
+
+	if first.TakerBuyVolumeFloat() != 2 {
+		t.Fatal("Expected taker buy volume 2, got", first.TakerBuyVolume())
+	}
+
+	second := bars[1]
+
+	if second.Time() != "2024-01-02T00:01:00Z" || second.OpenFloat() != 11 || second.CloseFloat() != 13 || second.VolumeFloat() != 4 {
+		t.Fatal("Unexpected second bar:", second.Time(), second.Open(), second.Close(), second.Volume())
+	}
+}
+
+func TestTickAggregateThresholdBars(t *testing.T) {
+	ticks := tickBarTestTicks()
+
+	bars, err := TickAggregate(ticks, TickBarOptions{Type: BAR_TYPE_TICK, Threshold: NewDecimal(2, 0)})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(bars) != 3 {
+		t.Fatal("Expected 3 tick bars, the last one partial, got", len(bars))
+	}
+
+	if bars[1].ID() != ticks[2].ID() || bars[1].Time() != ticks[2].Time() {
+		t.Fatal("Tick bar MUST take the ID and time of its first tick")
+	}
+
+	bars, err = TickAggregate(ticks, TickBarOptions{Type: BAR_TYPE_VOLUME, Threshold: NewDecimal(3, 0)})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// volumes 1+2, 1+3, 1
+	if len(bars) != 3 || bars[0].VolumeFloat() != 3 || bars[1].VolumeFloat() != 4 || bars[2].VolumeFloat() != 1 {
+		t.Fatal("Unexpected volume bars:", bars)
+	}
+
+	bars, err = TickAggregate(ticks, TickBarOptions{Type: BAR_TYPE_DOLLAR, Threshold: NewDecimal(40, 0)})
+
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// values 10+24+9, 33+13
+	if len(bars) != 2 || bars[0].CloseFloat() != 9 || bars[1].OpenFloat() != 11 {
+		t.Fatal("Unexpected dollar bars:", bars)
+	}
+}
+
+func TestTickAggregateErrors(t *testing.T) {
+	ticks := tickBarTestTicks()
+
+	if _, err := TickAggregate(ticks, TickBarOptions{Type: BAR_TYPE_VOLUME}); err == nil {
+		t.Fatal("TickAggregate MUST reject a threshold bar without a threshold")
+	}
+
+	if _, err := TickAggregate(ticks, TickBarOptions{Type: BAR_TYPE_TIME, Timeframe: "unknown"}); err == nil {
+		t.Fatal("TickAggregate MUST reject an unsupported timeframe")
+	}
+
+	if _, err := TickAggregate(ticks, TickBarOptions{Type: BAR_TYPE_TICK, Threshold: NewDecimal(2, 0), Fields: []string{COLUMN_OPEN_INTEREST}}); err == nil {
+		t.Fatal("TickAggregate MUST reject a field which can not be built from ticks")
+	}
+
+	unsorted := []TickInterface{ticks[1], ticks[0]}
+
+	if _, err := TickAggregate(unsorted, TickBarOptions{Type: BAR_TYPE_TICK, Threshold: NewDecimal(5, 0)}); err == nil {
+		t.Fatal("TickAggregate MUST reject ticks which are not in ascending time order")
+	}
+}
@@ -0,0 +1,44 @@
+package tradingstore
+
+import (
+	"time"
+
+	"github.com/dromara/carbon/v2"
+)
+
+type TickInterface interface {
+	// from dataobject
+
+	Data() map[string]string
+	DataChanged() map[string]string
+	MarkAsNotDirty()
+
+	// setters and getters
+
+	ID() string
+	SetID(id string) TickInterface
+
+	Price() string
+	PriceFloat() float64
+	PriceDecimal() Decimal
+	SetPrice(price string) TickInterface
+	SetPriceDecimal(price Decimal) TickInterface
+
+	Side() string
+	SetSide(side string) TickInterface
+
+	Size() string
+	SizeFloat() float64
+	SizeDecimal() Decimal
+	SetSize(size string) TickInterface
+	SetSizeDecimal(size Decimal) TickInterface
+
+	Time() string
+	TimeCarbon() *carbon.Carbon
+	TimeT() time.Time
+	SetTime(time string) TickInterface
+	SetTimeT(t time.Time) TickInterface
+
+	TradeID() string
+	SetTradeID(tradeID string) TickInterface
+}
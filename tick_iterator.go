@@ -0,0 +1,33 @@
+package tradingstore
+
+// tickIteratorBatchSize is the number of ticks loaded per batch
+const tickIteratorBatchSize = 1000
+
+// TickIteratorInterface iterates over stored ticks in ascending time order,
+// loading them from the database in batches
+type TickIteratorInterface interface {
+	// Next advances to the next tick, returns false when there are no more
+	// ticks or an error occurred
+	Next() bool
+
+	// Tick returns the current tick
+	Tick() TickInterface
+
+	// Err returns the error which stopped the iteration, if any
+	Err() error
+
+	// Close stops the iteration
+	Close() error
+}
+
+// tickIterator implements TickIteratorInterface using keyset pagination
+// on the tick time, so large series are never loaded at once
+type tickIterator struct {
+	*keysetIterator[TickInterface]
+}
+
+var _ TickIteratorInterface = (*tickIterator)(nil) // verify interface is implemented
+
+func (it *tickIterator) Tick() TickInterface {
+	return it.current
+}
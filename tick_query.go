@@ -0,0 +1,217 @@
+package tradingstore
+
+import (
+	"errors"
+	"time"
+)
+
+// TickQuery is a shortcut for NewTickQuery
+func TickQuery() TickQueryInterface {
+	return NewTickQuery()
+}
+
+// NewTickQuery creates a new tick query
+func NewTickQuery() TickQueryInterface {
+	return &tickQueryImplementation{
+		timeRangeQuery: newTimeRangeQuery(),
+	}
+}
+
+// tickQueryCopy returns a copy of the query, so the store can change the
+// time range of a query without changing the query of the caller
+func tickQueryCopy(options TickQueryInterface) TickQueryInterface {
+	query := TickQuery()
+
+	if options.IsColumnsSet() {
+		query.SetColumns(options.Columns())
+	}
+
+	if options.IsCountOnlySet() {
+		query.SetCountOnly(options.IsCountOnly())
+	}
+
+	if options.IsIDSet() {
+		query.SetID(options.ID())
+	}
+
+	if options.IsIDInSet() {
+		query.SetIDIn(options.IDIn())
+	}
+
+	if options.IsLimitSet() {
+		query.SetLimit(options.Limit())
+	}
+
+	if options.IsOffsetSet() {
+		query.SetOffset(options.Offset())
+	}
+
+	if options.IsOrderBySet() {
+		query.SetOrderBy(options.OrderBy())
+	}
+
+	if options.IsOrderDirectionSet() {
+		query.SetOrderDirection(options.OrderDirection())
+	}
+
+	if options.IsSideSet() {
+		query.SetSide(options.Side())
+	}
+
+	if options.IsTimeSet() {
+		query.SetTime(options.Time())
+	}
+
+	if options.IsTimeGteSet() {
+		query.SetTimeGte(options.TimeGte())
+	}
+
+	if options.IsTimeLteSet() {
+		query.SetTimeLte(options.TimeLte())
+	}
+
+	if options.IsTradeIDSet() {
+		query.SetTradeID(options.TradeID())
+	}
+
+	return query
+}
+
+type tickQueryImplementation struct {
+	timeRangeQuery
+}
+
+func (c *tickQueryImplementation) Validate() error {
+	if err := c.validate("tick query"); err != nil {
+		return err
+	}
+
+	if c.IsSideSet() && c.Side() != TICK_SIDE_BUY && c.Side() != TICK_SIDE_SELL {
+		return errors.New("tick query. side must be TICK_SIDE_BUY or TICK_SIDE_SELL")
+	}
+
+	if c.IsTradeIDSet() && c.TradeID() == "" {
+		return errors.New("tick query. trade_id cannot be empty")
+	}
+
+	return nil
+}
+
+func (c *tickQueryImplementation) SetColumns(columns []string) TickQueryInterface {
+	c.properties["columns"] = columns
+
+	return c
+}
+
+func (c *tickQueryImplementation) SetCountOnly(countOnly bool) TickQueryInterface {
+	c.properties["count_only"] = countOnly
+
+	return c
+}
+
+func (c *tickQueryImplementation) SetID(id string) TickQueryInterface {
+	c.properties["id"] = id
+
+	return c
+}
+
+func (c *tickQueryImplementation) SetIDIn(idIn []string) TickQueryInterface {
+	c.properties["id_in"] = idIn
+
+	return c
+}
+
+func (c *tickQueryImplementation) SetLimit(limit int) TickQueryInterface {
+	c.properties["limit"] = limit
+
+	return c
+}
+
+func (c *tickQueryImplementation) SetOffset(offset int) TickQueryInterface {
+	c.properties["offset"] = offset
+
+	return c
+}
+
+func (c *tickQueryImplementation) SetOrderBy(orderBy string) TickQueryInterface {
+	c.properties["order_by"] = orderBy
+
+	return c
+}
+
+func (c *tickQueryImplementation) SetOrderDirection(orderDirection string) TickQueryInterface {
+	c.properties["order_direction"] = orderDirection
+
+	return c
+}
+
+func (c *tickQueryImplementation) IsSideSet() bool {
+	return c.hasProperty("side")
+}
+
+func (c *tickQueryImplementation) Side() string {
+	if !c.IsSideSet() {
+		return ""
+	}
+
+	return c.properties["side"].(string)
+}
+
+// SetSide filters the ticks by the side of the aggressor,
+// TICK_SIDE_BUY or TICK_SIDE_SELL
+func (c *tickQueryImplementation) SetSide(side string) TickQueryInterface {
+	c.properties["side"] = side
+
+	return c
+}
+
+func (c *tickQueryImplementation) SetTime(time string) TickQueryInterface {
+	c.properties["time"] = time
+
+	return c
+}
+
+// SetTimeT sets the time to match, as a time.Time in any location
+func (c *tickQueryImplementation) SetTimeT(t time.Time) TickQueryInterface {
+	return c.SetTime(tickTimeFormat(t))
+}
+
+func (c *tickQueryImplementation) SetTimeGte(timeGte string) TickQueryInterface {
+	c.properties["time_gte"] = timeGte
+
+	return c
+}
+
+// SetTimeGteT sets the inclusive lower time bound, as a time.Time in any location
+func (c *tickQueryImplementation) SetTimeGteT(t time.Time) TickQueryInterface {
+	return c.SetTimeGte(tickTimeFormat(t))
+}
+
+func (c *tickQueryImplementation) SetTimeLte(timeLte string) TickQueryInterface {
+	c.properties["time_lte"] = timeLte
+
+	return c
+}
+
+// SetTimeLteT sets the inclusive upper time bound, as a time.Time in any location
+func (c *tickQueryImplementation) SetTimeLteT(t time.Time) TickQueryInterface {
+	return c.SetTimeLte(tickTimeFormat(t))
+}
+
+func (c *tickQueryImplementation) IsTradeIDSet() bool {
+	return c.hasProperty("trade_id")
+}
+
+func (c *tickQueryImplementation) TradeID() string {
+	if !c.IsTradeIDSet() {
+		return ""
+	}
+
+	return c.properties["trade_id"].(string)
+}
+
+func (c *tickQueryImplementation) SetTradeID(tradeID string) TickQueryInterface {
+	c.properties["trade_id"] = tradeID
+
+	return c
+}
@@ -0,0 +1,64 @@
+package tradingstore
+
+import "time"
+
+type TickQueryInterface interface {
+	Validate() error
+
+	IsColumnsSet() bool
+	Columns() []string
+	SetColumns(columns []string) TickQueryInterface
+
+	IsCountOnlySet() bool
+	IsCountOnly() bool
+	SetCountOnly(countOnly bool) TickQueryInterface
+
+	IsSideSet() bool
+	Side() string
+	SetSide(side string) TickQueryInterface
+
+	IsTimeSet() bool
+	Time() string
+	SetTime(createdAt string) TickQueryInterface
+	SetTimeT(t time.Time) TickQueryInterface
+
+	IsTimeGteSet() bool
+	TimeGte() string
+	SetTimeGte(createdAtGte string) TickQueryInterface
+	SetTimeGteT(t time.Time) TickQueryInterface
+
+	IsTimeLteSet() bool
+	TimeLte() string
+	SetTimeLte(createdAtLte string) TickQueryInterface
+	SetTimeLteT(t time.Time) TickQueryInterface
+
+	IsIDSet() bool
+	ID() string
+	SetID(id string) TickQueryInterface
+
+	IsIDInSet() bool
+	IDIn() []string
+	SetIDIn(idIn []string) TickQueryInterface
+
+	IsLimitSet() bool
+	Limit() int
+	SetLimit(limit int) TickQueryInterface
+
+	IsOffsetSet() bool
+	Offset() int
+	SetOffset(offset int) TickQueryInterface
+
+	IsOrderBySet() bool
+	OrderBy() string
+	SetOrderBy(orderBy string) TickQueryInterface
+
+	IsOrderDirectionSet() bool
+	OrderDirection() string
+	SetOrderDirection(orderDirection string) TickQueryInterface
+
+	IsTradeIDSet() bool
+	TradeID() string
+	SetTradeID(tradeID string) TickQueryInterface
+
+	hasProperty(name string) bool
+}
@@ -0,0 +1,246 @@
+package tradingstore
+
+import (
+	"errors"
+
+	"github.com/dromara/carbon/v2"
+)
+
+// timeRangeQueryInterface is the part of the tick, quote and order book
+// snapshot queries which selects rows by id and time range
+type timeRangeQueryInterface interface {
+	IsColumnsSet() bool
+	Columns() []string
+
+	IsCountOnlySet() bool
+	IsCountOnly() bool
+
+	IsIDSet() bool
+	ID() string
+
+	IsIDInSet() bool
+	IDIn() []string
+
+	IsLimitSet() bool
+	Limit() int
+
+	IsOffsetSet() bool
+	Offset() int
+
+	IsOrderBySet() bool
+	OrderBy() string
+
+	IsOrderDirectionSet() bool
+	OrderDirection() string
+
+	IsTimeSet() bool
+	Time() string
+
+	IsTimeGteSet() bool
+	TimeGte() string
+
+	IsTimeLteSet() bool
+	TimeLte() string
+}
+
+// timeRangeQuery implements the properties shared by the tick, quote and
+// order book snapshot queries. The queries embed it and add their own
+// setters, which return their query interface.
+type timeRangeQuery struct {
+	properties map[string]any
+}
+
+var _ timeRangeQueryInterface = (*timeRangeQuery)(nil) // verify interface is implemented
+
+func newTimeRangeQuery() timeRangeQuery {
+	return timeRangeQuery{
+		properties: make(map[string]any),
+	}
+}
+
+func (c *timeRangeQuery) hasProperty(name string) bool {
+	_, ok := c.properties[name]
+	return ok
+}
+
+// validate checks the shared properties, the name prefixes the errors,
+// i.e. "tick query"
+func (c *timeRangeQuery) validate(name string) error {
+	if c.IsIDSet() && c.ID() == "" {
+		return errors.New(name + ". id cannot be empty")
+	}
+
+	if c.IsIDInSet() && len(c.IDIn()) == 0 {
+		return errors.New(name + ". id_in cannot be empty")
+	}
+
+	if c.IsOrderBySet() && c.OrderBy() == "" {
+		return errors.New(name + ". order_by cannot be empty")
+	}
+
+	if c.IsOrderDirectionSet() && c.OrderDirection() == "" {
+		return errors.New(name + ". order_direction cannot be empty")
+	}
+
+	if c.IsLimitSet() && c.Limit() <= 0 {
+		return errors.New(name + ". limit must be greater than 0")
+	}
+
+	if c.IsOffsetSet() && c.Offset() < 0 {
+		return errors.New(name + ". offset must be greater than or equal to 0")
+	}
+
+	if c.IsTimeSet() && c.Time() == "" {
+		return errors.New(name + ". time cannot be empty")
+	}
+
+	if c.IsTimeGteSet() && c.TimeGte() == "" {
+		return errors.New(name + ". time_gte cannot be empty")
+	}
+
+	if c.IsTimeLteSet() && c.TimeLte() == "" {
+		return errors.New(name + ". time_lte cannot be empty")
+	}
+
+	times := map[string]string{"time": c.Time(), "time_gte": c.TimeGte(), "time_lte": c.TimeLte()}
+
+	for timeName, value := range times {
+		if value != "" && carbon.Parse(value, carbon.UTC).Error != nil {
+			return errors.New(name + ". " + timeName + " is not a valid time: " + value)
+		}
+	}
+
+	return nil
+}
+
+func (c *timeRangeQuery) IsColumnsSet() bool {
+	return c.hasProperty("columns")
+}
+
+func (c *timeRangeQuery) Columns() []string {
+	if !c.hasProperty("columns") {
+		return []string{}
+	}
+
+	return c.properties["columns"].([]string)
+}
+
+func (c *timeRangeQuery) IsCountOnlySet() bool {
+	return c.hasProperty("count_only")
+}
+
+func (c *timeRangeQuery) IsCountOnly() bool {
+	if !c.IsCountOnlySet() {
+		return false
+	}
+
+	return c.properties["count_only"].(bool)
+}
+
+func (c *timeRangeQuery) IsIDSet() bool {
+	return c.hasProperty("id")
+}
+
+func (c *timeRangeQuery) ID() string {
+	if !c.hasProperty("id") {
+		return ""
+	}
+
+	return c.properties["id"].(string)
+}
+
+func (c *timeRangeQuery) IsIDInSet() bool {
+	return c.hasProperty("id_in")
+}
+
+func (c *timeRangeQuery) IDIn() []string {
+	if !c.hasProperty("id_in") {
+		return []string{}
+	}
+
+	return c.properties["id_in"].([]string)
+}
+
+func (c *timeRangeQuery) IsLimitSet() bool {
+	return c.hasProperty("limit")
+}
+
+func (c *timeRangeQuery) Limit() int {
+	if !c.IsLimitSet() {
+		return 0
+	}
+
+	return c.properties["limit"].(int)
+}
+
+func (c *timeRangeQuery) IsOffsetSet() bool {
+	return c.hasProperty("offset")
+}
+
+func (c *timeRangeQuery) Offset() int {
+	if !c.IsOffsetSet() {
+		return 0
+	}
+
+	return c.properties["offset"].(int)
+}
+
+func (c *timeRangeQuery) IsOrderBySet() bool {
+	return c.hasProperty("order_by")
+}
+
+func (c *timeRangeQuery) OrderBy() string {
+	if !c.IsOrderBySet() {
+		return ""
+	}
+
+	return c.properties["order_by"].(string)
+}
+
+func (c *timeRangeQuery) IsOrderDirectionSet() bool {
+	return c.hasProperty("order_direction")
+}
+
+func (c *timeRangeQuery) OrderDirection() string {
+	if !c.IsOrderDirectionSet() {
+		return ""
+	}
+
+	return c.properties["order_direction"].(string)
+}
+
+func (c *timeRangeQuery) IsTimeSet() bool {
+	return c.hasProperty("time")
+}
+
+func (c *timeRangeQuery) Time() string {
+	if !c.IsTimeSet() {
+		return ""
+	}
+
+	return c.properties["time"].(string)
+}
+
+func (c *timeRangeQuery) IsTimeGteSet() bool {
+	return c.hasProperty("time_gte")
+}
+
+func (c *timeRangeQuery) TimeGte() string {
+	if !c.IsTimeGteSet() {
+		return ""
+	}
+
+	return c.properties["time_gte"].(string)
+}
+
+func (c *timeRangeQuery) IsTimeLteSet() bool {
+	return c.hasProperty("time_lte")
+}
+
+func (c *timeRangeQuery) TimeLte() string {
+	if !c.IsTimeLteSet() {
+		return ""
+	}
+
+	return c.properties["time_lte"].(string)
+}
@@ -0,0 +1,226 @@
+package tradingstore
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// timeframeDurations holds the nominal duration of each supported timeframe.
+// Months and years are calendar based, their nominal duration is only used
+// to compare timeframes against each other.
+var timeframeDurations = map[string]time.Duration{
+	TIMEFRAME_1_MINUTE:   time.Minute,
+	TIMEFRAME_5_MINUTES:  5 * time.Minute,
+	TIMEFRAME_15_MINUTES: 15 * time.Minute,
+	TIMEFRAME_30_MINUTES: 30 * time.Minute,
+	TIMEFRAME_1_HOUR:     time.Hour,
+	TIMEFRAME_4_HOURS:    4 * time.Hour,
+	TIMEFRAME_1_DAY:      24 * time.Hour,
+	TIMEFRAME_1_WEEK:     7 * 24 * time.Hour,
+	TIMEFRAME_1_MONTH:    30 * 24 * time.Hour,
+	TIMEFRAME_1_YEAR:     365 * 24 * time.Hour,
+}
+
+// TimeframeDuration returns the nominal duration of a timeframe
+//
+// Parameters:
+// - timeframe: one of the TIMEFRAME_* constants
+//
+// Returns:
+// - time.Duration: the nominal duration (30 days for a month, 365 days for a year)
+// - error: if the timeframe is not supported
+func TimeframeDuration(timeframe string) (time.Duration, error) {
+	duration, ok := timeframeDurations[strings.ToLower(timeframe)]
+
+	if !ok {
+		return 0, errors.New("timeframe not supported: " + timeframe)
+	}
+
+	return duration, nil
+}
+
+// TimeframeBucketOptions define how the buckets of daily and larger
+// timeframes are aligned. The zero value aligns them to midnight UTC.
+type TimeframeBucketOptions struct {
+	// Location is the time zone the buckets are aligned in, defaults to UTC
+	Location *time.Location
+
+	// DayAnchor is the local time of day daily buckets start at,
+	// formatted as HH:MM, defaults to 00:00. Forex is usually anchored
+	// at 17:00 New York.
+	//
+	// A daily bucket belongs to the trading date of its midpoint, so with
+	// a 17:00 anchor the bucket starting on Sunday 17:00 is Monday's bar,
+	// and starts the week.
+	DayAnchor string
+}
+
+// location returns the time zone of the buckets
+func (options TimeframeBucketOptions) location() *time.Location {
+	if options.Location == nil {
+		return time.UTC
+	}
+
+	return options.Location
+}
+
+// anchor returns the offset of the day anchor from midnight
+func (options TimeframeBucketOptions) anchor() (time.Duration, error) {
+	if options.DayAnchor == "" {
+		return 0, nil
+	}
+
+	anchor, err := time.Parse("15:04", options.DayAnchor)
+
+	if err != nil {
+		return 0, errors.New("timeframe bucket: day anchor must be formatted as HH:MM: " + options.DayAnchor)
+	}
+
+	return time.Duration(anchor.Hour())*time.Hour + time.Duration(anchor.Minute())*time.Minute, nil
+}
+
+// dayStart returns the start of the daily bucket of a trading date
+func (options TimeframeBucketOptions) dayStart(tradingDate time.Time, anchor time.Duration) time.Time {
+	day := time.Date(tradingDate.Year(), tradingDate.Month(), tradingDate.Day(), 0, 0, 0, 0, options.location())
+
+	if anchor >= 12*time.Hour {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return time.Date(day.Year(), day.Month(), day.Day(), int(anchor/time.Hour), int(anchor%time.Hour/time.Minute), 0, 0, options.location())
+}
+
+// tradingDate returns the local midnight of the trading date the time falls in
+func (options TimeframeBucketOptions) tradingDate(t time.Time, anchor time.Duration) time.Time {
+	local := t.In(options.location())
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, options.location())
+
+	start := options.dayStart(day, anchor)
+
+	if local.Before(start) {
+		return day.AddDate(0, 0, -1)
+	}
+
+	if next := options.dayStart(day.AddDate(0, 0, 1), anchor); !local.Before(next) {
+		return day.AddDate(0, 0, 1)
+	}
+
+	return day
+}
+
+// TimeframeBucketStart returns the start of the timeframe bucket the given time falls in.
+// Buckets are aligned to UTC, weeks start on Monday.
+//
+// Parameters:
+// - timeframe: one of the TIMEFRAME_* constants
+// - t: the time to find the bucket for
+//
+// Returns:
+// - time.Time: the start of the bucket, in UTC
+// - error: if the timeframe is not supported
+func TimeframeBucketStart(timeframe string, t time.Time) (time.Time, error) {
+	return TimeframeBucketStartWithOptions(timeframe, t, TimeframeBucketOptions{})
+}
+
+// TimeframeBucketStartWithOptions returns the start of the timeframe bucket the given time falls in.
+// Daily and larger buckets are aligned in the options time zone at the day anchor,
+// weeks start on Monday. Intraday buckets are always aligned to UTC.
+//
+// Parameters:
+// - timeframe: one of the TIMEFRAME_* constants
+// - t: the time to find the bucket for
+// - options: the bucket alignment options
+//
+// Returns:
+// - time.Time: the start of the bucket, in UTC
+// - error: if the timeframe or the options are not supported
+func TimeframeBucketStartWithOptions(timeframe string, t time.Time, options TimeframeBucketOptions) (time.Time, error) {
+	duration, err := TimeframeDuration(timeframe)
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if duration < timeframeDurations[TIMEFRAME_1_DAY] {
+		return t.UTC().Truncate(duration), nil
+	}
+
+	anchor, err := options.anchor()
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	tradingDate := options.tradingDate(t, anchor)
+
+	switch strings.ToLower(timeframe) {
+	case TIMEFRAME_1_WEEK:
+		daysSinceMonday := (int(tradingDate.Weekday()) + 6) % 7
+		tradingDate = tradingDate.AddDate(0, 0, -daysSinceMonday)
+	case TIMEFRAME_1_MONTH:
+		tradingDate = time.Date(tradingDate.Year(), tradingDate.Month(), 1, 0, 0, 0, 0, tradingDate.Location())
+	case TIMEFRAME_1_YEAR:
+		tradingDate = time.Date(tradingDate.Year(), time.January, 1, 0, 0, 0, 0, tradingDate.Location())
+	}
+
+	return options.dayStart(tradingDate, anchor).UTC(), nil
+}
+
+// TimeframeBucketEnd returns the (exclusive) end of the timeframe bucket
+// starting at the given time
+//
+// Parameters:
+// - timeframe: one of the TIMEFRAME_* constants
+// - start: the start of the bucket, as returned by TimeframeBucketStart
+//
+// Returns:
+// - time.Time: the end of the bucket
+// - error: if the timeframe is not supported
+func TimeframeBucketEnd(timeframe string, start time.Time) (time.Time, error) {
+	return TimeframeBucketEndWithOptions(timeframe, start, TimeframeBucketOptions{})
+}
+
+// TimeframeBucketEndWithOptions returns the (exclusive) end of the timeframe
+// bucket starting at the given time
+//
+// Parameters:
+// - timeframe: one of the TIMEFRAME_* constants
+// - start: the start of the bucket, as returned by TimeframeBucketStartWithOptions
+// - options: the bucket alignment options
+//
+// Returns:
+// - time.Time: the end of the bucket, in UTC
+// - error: if the timeframe or the options are not supported
+func TimeframeBucketEndWithOptions(timeframe string, start time.Time, options TimeframeBucketOptions) (time.Time, error) {
+	duration, err := TimeframeDuration(timeframe)
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if duration < timeframeDurations[TIMEFRAME_1_DAY] {
+		return start.UTC().Add(duration), nil
+	}
+
+	anchor, err := options.anchor()
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	tradingDate := options.tradingDate(start, anchor)
+
+	switch strings.ToLower(timeframe) {
+	case TIMEFRAME_1_DAY:
+		tradingDate = tradingDate.AddDate(0, 0, 1)
+	case TIMEFRAME_1_WEEK:
+		tradingDate = tradingDate.AddDate(0, 0, 7)
+	case TIMEFRAME_1_MONTH:
+		tradingDate = tradingDate.AddDate(0, 1, 0)
+	case TIMEFRAME_1_YEAR:
+		tradingDate = tradingDate.AddDate(1, 0, 0)
+	}
+
+	return options.dayStart(tradingDate, anchor).UTC(), nil
+}